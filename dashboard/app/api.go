@@ -31,6 +31,7 @@ import (
 	"github.com/google/syzkaller/pkg/email"
 	"github.com/google/syzkaller/pkg/gcs"
 	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/report"
 	"github.com/google/syzkaller/pkg/subsystem"
 	"github.com/google/syzkaller/sys/targets"
 	"github.com/google/uuid"
@@ -921,6 +922,12 @@ func reportCrash(c context.Context, build *Build, req *dashapi.Crash) (*Bug, err
 		bug.MergedTitles = mergeString(bug.MergedTitles, bug.Title)
 		bug.MergedTitles = mergeString(bug.MergedTitles, req.Title)
 		bug.AltTitles = mergeStringList(bug.AltTitles, req.AltTitles)
+		if len(bug.StackFrames) == 0 && len(req.StackFrames) != 0 {
+			// Comparing the stack with all open bugs is too expensive to do here,
+			// probable duplicates are searched by handleProbableDups.
+			bug.StackFrames = req.StackFrames
+			bug.NeedProbableDups = true
+		}
 		if _, err = db.Put(c, bugKey, bug); err != nil {
 			return fmt.Errorf("failed to put bug: %w", err)
 		}
@@ -939,6 +946,95 @@ func reportCrash(c context.Context, build *Build, req *dashapi.Crash) (*Bug, err
 	return bug, nil
 }
 
+// handleProbableDups searches probable duplicates of the bugs that have recently got StackFrames.
+func handleProbableDups(w http.ResponseWriter, r *http.Request) {
+	c := appengine.NewContext(r)
+	const updateBugsCount = 100
+	for ns := range getConfig(c).Namespaces {
+		if err := updateProbableDups(c, ns, updateBugsCount); err != nil {
+			log.Errorf(c, "failed to update probable duplicates for %s: %v", ns, err)
+		}
+	}
+}
+
+type bugStack struct {
+	key       string
+	firstTime time.Time
+	frames    []string
+}
+
+func updateProbableDups(c context.Context, ns string, count int) error {
+	var bugs []*Bug
+	keys, err := db.NewQuery("Bug").
+		Filter("Namespace=", ns).
+		Filter("NeedProbableDups=", true).
+		Limit(count).
+		GetAll(c, &bugs)
+	if err != nil {
+		return fmt.Errorf("failed to query bugs: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	// Stacks of the open bugs are loaded once for all bugs.
+	var stacks []bugStack
+	err = foreachBug(c, func(query *db.Query) *db.Query {
+		return query.Filter("Namespace=", ns).
+			Filter("Status=", BugStatusOpen)
+	}, func(bug *Bug, key *db.Key) error {
+		if len(bug.StackFrames) != 0 {
+			stacks = append(stacks, bugStack{key.StringID(), bug.FirstTime, bug.StackFrames})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof(c, "searching probable duplicates of %d bugs among %d bugs in %v", len(keys), len(stacks), ns)
+	for i, bugKey := range keys {
+		dups := findProbableDups(bugs[i], bugKey, stacks)
+		tx := func(c context.Context) error {
+			bug := new(Bug)
+			if err := db.Get(c, bugKey, bug); err != nil {
+				return fmt.Errorf("failed to get bug: %w", err)
+			}
+			bug.ProbableDups = dups
+			bug.NeedProbableDups = false
+			if _, err := db.Put(c, bugKey, bug); err != nil {
+				return fmt.Errorf("failed to put bug: %w", err)
+			}
+			return nil
+		}
+		if err := runInTransaction(c, tx, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findProbableDups returns older open bugs whose crash stacks are similar to the bug stack,
+// the most similar first. Such bugs often differ from the new one only in the guilty frame.
+func findProbableDups(bug *Bug, bugKey *db.Key, stacks []bugStack) []BugProbableDup {
+	const maxDups = 3
+	var res []BugProbableDup
+	for _, other := range stacks {
+		if other.key == bugKey.StringID() || !other.firstTime.Before(bug.FirstTime) {
+			continue
+		}
+		score := report.FramesSimilarity(bug.StackFrames, other.frames)
+		if score >= report.DuplicateSimilarity {
+			res = append(res, BugProbableDup{Bug: other.key, Score: score})
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Score > res[j].Score
+	})
+	if len(res) > maxDups {
+		res = res[:maxDups]
+	}
+	return res
+}
+
 func parseCrashAssets(c context.Context, req *dashapi.Crash, ns string) ([]Asset, error) {
 	assets := []Asset{}
 	for i, toAdd := range req.Assets {
@@ -1968,7 +2064,11 @@ func apiSaveCoverage(c context.Context, payload io.Reader) (interface{}, error)
 		sss = service.List()
 		log.Infof(c, "found %d subsystems for %s namespace", len(sss), descr.Namespace)
 	}
-	rowsCreated, err := coveragedb.SaveMergeResult(c, getCoverageDBClient(c), descr, jsonDec, sss)
+	storage, err := getCoverageStorage(c, descr.Namespace)
+	if err != nil {
+		return 0, err
+	}
+	rowsCreated, err := storage.SaveMergeResult(c, descr, jsonDec, sss)
 	if err != nil {
 		log.Errorf(c, "error storing coverage for ns %s, date %s: %v",
 			descr.Namespace, descr.DateTo.String(), err)
@@ -583,6 +583,7 @@ var testConfig = &GlobalConfig{
 			Coverage: &CoverageConfig{
 				EmailRegressionsTo:  "test@test.test",
 				RegressionThreshold: 1,
+				FuncRegressionDays:  1,
 			},
 			AccessLevel: AccessPublic,
 			Key:         "coveragetestskeycoveragetestskeycoveragetestskey",
@@ -434,6 +434,11 @@ func (ad *crashAssetDeprecator) needThisCrashAsset(crashKey *db.Key, crashAsset
 		// They're not that big to set stricter limits.
 		return ad.bugStatusPolicy(crashKey, crashAsset)
 	}
+	if crashAsset.Type == dashapi.KernelCrashDump {
+		// Crash dumps are only uploaded for crashes with corrupted console reports,
+		// so they are needed until the bug is investigated.
+		return ad.bugStatusPolicy(crashKey, crashAsset)
+	}
 	return false, fmt.Errorf("no deprecation policy for %s", crashAsset.Type)
 }
 
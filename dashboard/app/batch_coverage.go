@@ -42,7 +42,12 @@ func handleBatchCoverage(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			log.Errorf(ctx, "failed nsDataAvailable(%s): %s", ns, err)
 		}
-		periodsMerged, rowsMerged, err := coveragedb.NsDataMerged(ctx, coverageDBClient, ns)
+		storage, err := getCoverageStorage(ctx, ns)
+		if err != nil {
+			log.Errorf(ctx, "failed getCoverageStorage(%s): %s", ns, err)
+			continue
+		}
+		periodsMerged, rowsMerged, err := storage.NsDataMerged(ctx, ns)
 		if err != nil {
 			log.Errorf(ctx, "failed coveragedb.NsDataMerged(%s): %s", ns, err)
 		}
@@ -153,7 +158,16 @@ func nsDataAvailable(ctx context.Context, ns string) ([]coveragedb.TimePeriod, [
 
 func handleBatchCoverageClean(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	totalDeleted, err := coveragedb.DeleteGarbage(ctx, coverageDBClient)
+	var totalDeleted int64
+	storages, err := coverageStorages(ctx)
+	for _, storage := range storages {
+		var deleted int64
+		deleted, err = storage.DeleteGarbage(ctx)
+		totalDeleted += deleted
+		if err != nil {
+			break
+		}
+	}
 	if err != nil {
 		errMsg := fmt.Sprintf("failed to coveragedb.DeleteGarbage: %s", err.Error())
 		log.Errorf(ctx, "%s", errMsg)
@@ -174,6 +174,10 @@ type CoverageConfig struct {
 	SyzEnvInitScript    string
 	DashboardClientName string
 
+	// LocalDBDir is the directory with the local coverage database (see coveragedb.NewLocalStorage).
+	// If set, it's used for all coverage data of the namespace (uploads, merges, reports) instead of Spanner.
+	LocalDBDir string
+
 	// WebGitURI specifies where can we get the kernel file source code directly from AppEngine.
 	// It may be the Git or Gerrit compatible repo.
 	WebGitURI string
@@ -186,6 +190,12 @@ type CoverageConfig struct {
 	// The amount of files in the dir and other factors do not matter.
 	// Defaults to defaultRegressionThreshold.
 	RegressionThreshold int
+
+	// FuncRegressionDays is the number of days a previously covered function must have no coverage
+	// (across all managers) to be reported to EmailRegressionsTo.
+	// Only the first and the last of these days are checked to bound the amount of the coverage data read.
+	// If 0, function coverage regressions are not reported.
+	FuncRegressionDays int
 }
 
 // DiscussionEmailConfig defines the correspondence between an email and a DiscussionSource.
@@ -625,6 +635,9 @@ func checkCoverageConfig(ns string, cfg *Config) {
 	if _, err := mail.ParseAddress(cfg.Coverage.EmailRegressionsTo); err != nil {
 		panic(fmt.Sprintf("bad cfg.Coverage.EmailRegressionsTo in '%s': %s", ns, err.Error()))
 	}
+	if cfg.Coverage.FuncRegressionDays < 0 {
+		panic(fmt.Sprintf("bad cfg.Coverage.FuncRegressionDays in '%s': %v", ns, cfg.Coverage.FuncRegressionDays))
+	}
 }
 
 func checkSubsystems(ns string, cfg *Config) {
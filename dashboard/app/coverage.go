@@ -12,6 +12,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"cloud.google.com/go/civil"
 	"github.com/google/syzkaller/pkg/cover"
@@ -52,8 +53,56 @@ func getCoverageDBClient(ctx context.Context) spannerclient.SpannerClient {
 	return coverageDBClient
 }
 
+var (
+	localCoverageStoragesMu sync.Mutex
+	localCoverageStorages   = map[string]coveragedb.Storage{}
+)
+
+// getCoverageStorage returns the coverage database of the namespace.
+// It's the local directory database if Coverage.LocalDBDir is set and the Spanner one otherwise.
+// The storage is shared between requests and must not be closed.
+func getCoverageStorage(ctx context.Context, ns string) (coveragedb.Storage, error) {
+	var dir string
+	if cfg := getNsConfig(ctx, ns); cfg != nil && cfg.Coverage != nil {
+		dir = cfg.Coverage.LocalDBDir
+	}
+	if dir == "" {
+		return coveragedb.NewSpannerStorage(getCoverageDBClient(ctx)), nil
+	}
+	// Only one instance of the local database may be open.
+	localCoverageStoragesMu.Lock()
+	defer localCoverageStoragesMu.Unlock()
+	if storage := localCoverageStorages[dir]; storage != nil {
+		return storage, nil
+	}
+	storage, err := coveragedb.NewLocalStorage(dir)
+	if err != nil {
+		return nil, fmt.Errorf("coveragedb.NewLocalStorage: %w", err)
+	}
+	localCoverageStorages[dir] = storage
+	return storage, nil
+}
+
+// coverageStorages returns the coverage databases of all namespaces with coverage, each database once.
+func coverageStorages(ctx context.Context) ([]coveragedb.Storage, error) {
+	var res []coveragedb.Storage
+	seen := make(map[string]bool)
+	for ns, cfg := range getConfig(ctx).Namespaces {
+		if cfg.Coverage == nil || seen[cfg.Coverage.LocalDBDir] {
+			continue
+		}
+		seen[cfg.Coverage.LocalDBDir] = true
+		storage, err := getCoverageStorage(ctx, ns)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, storage)
+	}
+	return res, nil
+}
+
 type funcStyleBodyJS func(
-	ctx context.Context, client spannerclient.SpannerClient,
+	ctx context.Context, storage coveragedb.Storage,
 	scope *coveragedb.SelectScope, onlyUnique bool, sss, managers []string, dataFilters cover.Format,
 ) (template.CSS, template.HTML, template.HTML, error)
 
@@ -223,9 +272,13 @@ func handleHeatmap(c context.Context, w http.ResponseWriter, hdr *uiHeader, p *c
 	slices.Sort(managers)
 	slices.Sort(subsystems)
 
+	storage, err := getCoverageStorage(c, hdr.Namespace)
+	if err != nil {
+		return err
+	}
 	var style template.CSS
 	var body, js template.HTML
-	if style, body, js, err = f(c, getCoverageDBClient(c),
+	if style, body, js, err = f(c, storage,
 		&coveragedb.SelectScope{
 			Ns:        hdr.Namespace,
 			Subsystem: p.subsystem,
@@ -291,12 +344,12 @@ func handleFileCoverage(c context.Context, w http.ResponseWriter, r *http.Reques
 		return fmt.Errorf("coveragedb.MakeTimePeriod: %w", err)
 	}
 	mainNsRepo, _ := nsConfig.mainRepoBranch()
-	client := getCoverageDBClient(c)
-	if client == nil {
-		return fmt.Errorf("spannerdb client is nil")
+	storage, err := getCoverageStorage(c, hdr.Namespace)
+	if err != nil {
+		return err
 	}
-	hitLines, hitCounts, err := coveragedb.ReadLinesHitCount(
-		c, client, hdr.Namespace, targetCommit, kernelFilePath, manager, tp)
+	hitLines, hitCounts, err := storage.ReadLinesHitCount(
+		c, hdr.Namespace, targetCommit, kernelFilePath, manager, tp)
 	covMap := coveragedb.MakeCovMap(hitLines, hitCounts)
 	if err != nil {
 		return fmt.Errorf("coveragedb.ReadLinesHitCount(%s): %w", manager, err)
@@ -304,8 +357,8 @@ func handleFileCoverage(c context.Context, w http.ResponseWriter, r *http.Reques
 	if getParam[bool](r, UniqueOnly.ParamName()) {
 		// This request is expected to be made second by tests.
 		// Moving it to goroutine don't forget to change multiManagerCovDBFixture.
-		allHitLines, allHitCounts, err := coveragedb.ReadLinesHitCount(
-			c, client, hdr.Namespace, targetCommit, kernelFilePath, "*", tp)
+		allHitLines, allHitCounts, err := storage.ReadLinesHitCount(
+			c, hdr.Namespace, targetCommit, kernelFilePath, "*", tp)
 		if err != nil {
 			return fmt.Errorf("coveragedb.ReadLinesHitCount(*): %w", err)
 		}
@@ -359,7 +412,11 @@ func handleCoverageGraph(c context.Context, w http.ResponseWriter, r *http.Reque
 	if periodType != coveragedb.QuarterPeriod && periodType != coveragedb.MonthPeriod {
 		return fmt.Errorf("only quarter and month are allowed, but received %s instead", periodType)
 	}
-	hist, err := MergedCoverage(c, getCoverageDBClient(c), hdr.Namespace, periodType)
+	storage, err := getCoverageStorage(c, hdr.Namespace)
+	if err != nil {
+		return err
+	}
+	hist, err := MergedCoverage(c, storage, hdr.Namespace, periodType)
 	if err != nil {
 		return err
 	}
@@ -393,3 +450,128 @@ func handleCoverageGraph(c context.Context, w http.ResponseWriter, r *http.Reque
 	}
 	return serveTemplate(w, "graph_histogram.html", data)
 }
+
+// coverageDataDelay is the maximum expected delay of the daily coverage data propagation.
+const coverageDataDelay = 2
+
+// loadFuncRegressions returns the functions that were covered, but have no coverage across all managers
+// for the last days before targetDate (inclusive).
+// Reading the whole window every day is too expensive, so only the day before the window,
+// the first and the last days of the window are checked. The function must be covered right before
+// the window and not covered on its first day, thus every regression is still reported only once.
+func loadFuncRegressions(ctx context.Context, ns string, targetDate civil.Date, days int,
+) ([]*coveragedb.FuncRegression, error) {
+	periods, err := coveragedb.GenNPeriodsTill(days+1, targetDate, coveragedb.DayPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("coveragedb.GenNPeriodsTill: %w", err)
+	}
+	sampled := periods[:2]
+	if days > 1 {
+		sampled = append(sampled, periods[days])
+	}
+	storage, err := getCoverageStorage(ctx, ns)
+	if err != nil {
+		return nil, err
+	}
+	history, err := coveragedb.FuncsCoverageHistory(ctx, storage, ns, sampled)
+	if err != nil {
+		return nil, fmt.Errorf("coveragedb.FuncsCoverageHistory: %w", err)
+	}
+	return history.Regressions(len(sampled) - 1), nil
+}
+
+// splitFuncRegressions separates the functions that are not instrumented anymore
+// from the ones that are instrumented, but lost coverage.
+func splitFuncRegressions(regressions []*coveragedb.FuncRegression) (lost, notInstrumented []*coveragedb.FuncRegression) {
+	for _, reg := range regressions {
+		if reg.NotInstrumented {
+			notInstrumented = append(notInstrumented, reg)
+		} else {
+			lost = append(lost, reg)
+		}
+	}
+	return lost, notInstrumented
+}
+
+type uiFuncRegressionFile struct {
+	FilePath string
+	Funcs    []string
+}
+
+// groupFuncRegressions groups the functions by file preserving the order of the regressions.
+// At most limit functions are taken (0 means no limit), the number of the omitted ones is returned.
+func groupFuncRegressions(regressions []*coveragedb.FuncRegression, limit int) ([]*uiFuncRegressionFile, int) {
+	var files []*uiFuncRegressionFile
+	for i, reg := range regressions {
+		if limit != 0 && i == limit {
+			return files, len(regressions) - limit
+		}
+		if len(files) == 0 || files[len(files)-1].FilePath != reg.FilePath {
+			files = append(files, &uiFuncRegressionFile{FilePath: reg.FilePath})
+		}
+		file := files[len(files)-1]
+		file.Funcs = append(file.Funcs, reg.FuncName)
+	}
+	return files, 0
+}
+
+func funcRegressionsPageLink(ns string, targetDate civil.Date) string {
+	return urlutil.SetParam("/"+ns+"/coverage/func-regressions", DateTo.ParamName(), targetDate.String())
+}
+
+type uiFuncRegressionsPage struct {
+	Header      *uiHeader
+	Days        int
+	LastCovered civil.Date
+	CommitFrom  string
+	CommitTo    string
+	Files       []*uiFuncRegressionFile
+	// NotInstrumentedFiles are the functions that are not instrumented anymore.
+	NotInstrumentedFiles []*uiFuncRegressionFile
+	FileList             string
+}
+
+// handleFuncRegressions shows the full list of the function coverage regressions
+// reported by handleCoverageFuncRegressions.
+func handleFuncRegressions(c context.Context, w http.ResponseWriter, r *http.Request) error {
+	hdr, err := commonHeader(c, r, w, "")
+	if err != nil {
+		return err
+	}
+	nsConfig := getNsConfig(c, hdr.Namespace)
+	if nsConfig.Coverage == nil || nsConfig.Coverage.FuncRegressionDays == 0 {
+		return ErrClientNotFound
+	}
+	days := nsConfig.Coverage.FuncRegressionDays
+	targetDate := getParam[civil.Date](r, DateTo.ParamName(),
+		civil.DateOf(timeNow(c)).AddDays(-coverageDataDelay))
+	regressions, err := loadFuncRegressions(c, hdr.Namespace, targetDate, days)
+	if err != nil {
+		return err
+	}
+	lost, notInstrumented := splitFuncRegressions(regressions)
+	files, _ := groupFuncRegressions(lost, 0)
+	notInstrumentedFiles, _ := groupFuncRegressions(notInstrumented, 0)
+	page := &uiFuncRegressionsPage{
+		Header:               hdr,
+		Days:                 days,
+		Files:                files,
+		NotInstrumentedFiles: notInstrumentedFiles,
+	}
+	if len(regressions) != 0 {
+		// All regressions have the same periods, thus the same commit range.
+		page.LastCovered = regressions[0].LastCovered.DateTo
+		page.CommitFrom = regressions[0].CommitFrom
+		page.CommitTo = regressions[0].CommitTo
+		page.FileList = funcRegressionsFileList(append(files, notInstrumentedFiles...))
+	}
+	return serveTemplate(w, "coverage_func_regressions.html", page)
+}
+
+func funcRegressionsFileList(files []*uiFuncRegressionFile) string {
+	var paths []string
+	for _, file := range files {
+		paths = append(paths, file.FilePath)
+	}
+	return strings.Join(paths, " ")
+}
@@ -5,11 +5,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strings"
 	"testing"
 
+	"cloud.google.com/go/civil"
 	"github.com/google/syzkaller/pkg/coveragedb"
 	"github.com/google/syzkaller/pkg/coveragedb/mocks"
 	"github.com/google/syzkaller/pkg/coveragedb/spannerclient"
@@ -24,6 +26,57 @@ func setCoverageDBClient(ctx context.Context, client spannerclient.SpannerClient
 	return context.WithValue(ctx, &keyCoverageDBClient, client)
 }
 
+// saveLocalCoverage stores the daily merge result in the JSONLWrapper format to the local coverage database.
+func (c *Ctx) saveLocalCoverage(storage coveragedb.Storage, ns string, day civil.Date, commit, jsonl string) {
+	_, err := storage.SaveMergeResult(c.ctx, &coveragedb.HistoryRecord{
+		Namespace: ns,
+		Repo:      "git://syzkaller.org/test.git",
+		Commit:    commit,
+		Duration:  1,
+		DateTo:    day,
+		TotalRows: 1,
+	}, json.NewDecoder(strings.NewReader(jsonl)), nil)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func TestCoverageHeatmapLocalStorage(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	storage := c.setLocalCoverageDB("coverage-tests")
+	c.saveLocalCoverage(storage, "coverage-tests", civil.Date{Year: 1999, Month: 12, Day: 30}, "commit0", `
+{"MCR":{"Manager":"*","FilePath":"dir/file_name.c",
+	"FileData":{"Instrumented":2,"Covered":1,"LinesInstrumented":[1,2],"HitCounts":[0,1]}}}
+`)
+	reply, err := c.AuthGET(AccessAdmin, "/coverage-tests/coverage?dateto=1999-12-30&period=day&period_count=1")
+	assert.NoError(t, err)
+	assert.Contains(t, string(reply), "file_name.c")
+}
+
+func TestCoverageGraphLocalStorage(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	storage := c.setLocalCoverageDB("coverage-tests")
+	_, err := storage.SaveMergeResult(c.ctx, &coveragedb.HistoryRecord{
+		Namespace: "coverage-tests",
+		Repo:      "git://syzkaller.org/test.git",
+		Commit:    "commit0",
+		Duration:  31,
+		DateTo:    civil.Date{Year: 1999, Month: 12, Day: 31},
+		TotalRows: 1,
+	}, json.NewDecoder(strings.NewReader(`
+{"MCR":{"Manager":"*","FilePath":"dir/file_name.c",
+	"FileData":{"Instrumented":4,"Covered":1,"LinesInstrumented":[1,2,3,4],"HitCounts":[0,1,0,0]}}}
+`)), nil)
+	assert.NoError(t, err)
+	reply, err := c.AuthGET(AccessAdmin, "/coverage-tests/graph/coverage?period=month")
+	assert.NoError(t, err)
+	assert.Contains(t, string(reply), "0.25")
+}
+
 func TestFileCoverage_BadRequest(t *testing.T) {
 	badURL := "/test2/coverage/file?dateto=2025-01-31'&period=month" +
 		"&commit=c0e75905caf368e19aab585d20151500e750de89&filepath=virt/kvm/kvm_main.c"
@@ -131,6 +131,17 @@ type Bug struct {
 	// FixCandidateJob holds the key of the latest successful cross-tree fix bisection job.
 	FixCandidateJob string
 	ReproAttempts   []BugReproAttempt
+	// StackFrames is the normalized main stack of the first crash that had one (see report.NormalizedStack).
+	StackFrames []string `datastore:",noindex"`
+	// ProbableDups are older open bugs with similar stacks (see handleProbableDups).
+	ProbableDups []BugProbableDup `datastore:",noindex"`
+	// NeedProbableDups is set when StackFrames are recorded and ProbableDups are not searched yet.
+	NeedProbableDups bool
+}
+
+type BugProbableDup struct {
+	Bug   string // key hash of the bug
+	Score float64
 }
 
 type BugTreeTestInfo struct {
@@ -600,6 +611,7 @@ type Job struct {
 	Log         int64 // reference to Log text entity
 	Error       int64 // reference to Error text entity, if set job failed
 	Flags       dashapi.JobDoneFlags
+	Confidence  float64 // estimated probability that the bisection result is correct
 
 	Reported         bool   // have we reported result back to user?
 	InvalidatedBy    string // user who marked this bug as invalid, empty by default
@@ -1104,6 +1104,7 @@ func doneJob(c context.Context, req *dashapi.JobDoneReq) error {
 		job.Finished = now
 		job.IsRunning = false
 		job.Flags = req.Flags
+		job.Confidence = req.Confidence
 		if job.Type == JobBisectCause || job.Type == JobBisectFix {
 			// Update bug.BisectCause/Fix status and also remember current bug reporting to send results.
 			var err error
@@ -1552,6 +1553,7 @@ func makeJobInfo(c context.Context, job *Job, jobKey *db.Key, bug *Bug, build *B
 		JobKey:           jobKey.Encode(),
 		Type:             dashapi.JobType(job.Type),
 		Flags:            job.Flags,
+		Confidence:       job.Confidence,
 		Created:          job.Created,
 		BugLink:          bugLink(jobKey.Parent().StringID()),
 		ExternalLink:     job.Link,
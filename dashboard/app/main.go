@@ -21,6 +21,7 @@ import (
 	"cloud.google.com/go/logging"
 	"cloud.google.com/go/logging/logadmin"
 	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/csource"
 	"github.com/google/syzkaller/pkg/debugtracer"
 	"github.com/google/syzkaller/pkg/email"
 	"github.com/google/syzkaller/pkg/hash"
@@ -70,6 +71,7 @@ func initHTTPHandlers() {
 		http.Handle("/"+ns+"/graph/coverage", handlerWrapper(handleCoverageGraph))
 		http.Handle("/"+ns+"/coverage/file", handlerWrapper(handleFileCoverage))
 		http.Handle("/"+ns+"/coverage", handlerWrapper(handleCoverageHeatmap))
+		http.Handle("/"+ns+"/coverage/func-regressions", handlerWrapper(handleFuncRegressions))
 		http.Handle("/"+ns+"/graph/coverage_heatmap", handleMovedPermanently("/"+ns+"/coverage"))
 		if nsConfig.Subsystems.Service != nil {
 			http.Handle("/"+ns+"/graph/coverage_subsystems_heatmap",
@@ -87,6 +89,7 @@ func initHTTPHandlers() {
 	http.HandleFunc("/cron/minute_cache_update", handleMinuteCacheUpdate)
 	http.HandleFunc("/cron/deprecate_assets", handleDeprecateAssets)
 	http.HandleFunc("/cron/refresh_subsystems", handleRefreshSubsystems)
+	http.HandleFunc("/cron/probable_dups", handleProbableDups)
 	http.HandleFunc("/cron/subsystem_reports", handleSubsystemReports)
 }
 
@@ -294,6 +297,7 @@ type uiBugPage struct {
 	FixCandidate    *uiJob
 	Sections        []*uiCollapsible
 	SampleReport    template.HTML
+	Severity        *report.Severity
 	Crashes         *uiCrashTable
 	TestPatchJobs   *uiJobList
 	LabelGroups     []*uiBugLabelGroup
@@ -1094,8 +1098,22 @@ func handleBug(c context.Context, w http.ResponseWriter, r *http.Request) error
 			})
 		}
 	}
+	if bug.DupOf == "" && bug.Status == BugStatusOpen && len(bug.ProbableDups) != 0 {
+		probableDups, err := loadProbableDupsUI(c, bug, accessLevel, state, managers)
+		if err != nil {
+			return err
+		}
+		if len(probableDups.Bugs) > 0 {
+			sections = append(sections, &uiCollapsible{
+				Title: "Probably duplicate of (similar crash stacks)",
+				Show:  true,
+				Type:  sectionBugList,
+				Value: probableDups,
+			})
+		}
+	}
 	uiBug := createUIBug(c, bug, state, managers)
-	crashes, sampleReport, err := loadCrashesForBug(c, bug)
+	crashes, sampleReport, severity, err := loadCrashesForBug(c, bug)
 	if err != nil {
 		return err
 	}
@@ -1210,6 +1228,7 @@ func handleBug(c context.Context, w http.ResponseWriter, r *http.Request) error
 		FixCandidate: fixCandidate,
 		Sections:     sections,
 		SampleReport: sampleReport,
+		Severity:     severity,
 		Crashes:      crashesTable,
 		LabelGroups:  getLabelGroups(c, bug),
 	}
@@ -1533,12 +1552,12 @@ func handleTextImpl(c context.Context, w http.ResponseWriter, r *http.Request, t
 	// Unfortunately filename does not work in chrome on linux due to:
 	// https://bugs.chromium.org/p/chromium/issues/detail?id=608342
 	w.Header().Set("Content-Disposition", "inline; filename="+textFilename(tag))
-	augmentRepro(c, w, tag, bug, crash)
+	augmentRepro(c, w, tag, bug, crash, data)
 	w.Write(data)
 	return nil
 }
 
-func augmentRepro(c context.Context, w http.ResponseWriter, tag string, bug *Bug, crash *Crash) {
+func augmentRepro(c context.Context, w http.ResponseWriter, tag string, bug *Bug, crash *Crash, data []byte) {
 	if tag == textReproSyz || tag == textReproC {
 		// Users asked for the bug link in reproducers (in case you only saved the repro link).
 		if bug != nil {
@@ -1555,6 +1574,7 @@ func augmentRepro(c context.Context, w http.ResponseWriter, tag string, bug *Bug
 		if crash != nil {
 			fmt.Fprintf(w, "#%s\n", crash.ReproOpts)
 		}
+		w.Write([]byte(reproExplanationLegend(data)))
 	}
 }
 
@@ -1843,6 +1863,29 @@ func loadDupsForBug(c context.Context, r *http.Request, bug *Bug, state *Reporti
 	return group, nil
 }
 
+// loadProbableDupsUI returns the bugs from bug.ProbableDups that are still open and visible to the user.
+func loadProbableDupsUI(c context.Context, bug *Bug, accessLevel AccessLevel, state *ReportingState,
+	managers []string) (*uiBugGroup, error) {
+	var keys []*db.Key
+	for _, dup := range bug.ProbableDups {
+		keys = append(keys, db.NewKey(c, "Bug", dup.Bug, 0, nil))
+	}
+	dups := make([]*Bug, len(keys))
+	if err := db.GetMulti(c, keys, dups); err != nil {
+		return nil, fmt.Errorf("failed to load probable duplicates: %w", err)
+	}
+	res := &uiBugGroup{
+		Now: timeNow(c),
+	}
+	for _, dup := range dups {
+		if dup.Status != BugStatusOpen || accessLevel < dup.sanitizeAccess(c, accessLevel) {
+			continue
+		}
+		res.Bugs = append(res.Bugs, createUIBug(c, dup, state, managers))
+	}
+	return res, nil
+}
+
 func loadSimilarBugsUI(c context.Context, r *http.Request, bug *Bug, state *ReportingState) (*uiBugGroup, error) {
 	managers := make(map[string][]string)
 	accessLevel := accessLevel(c, r)
@@ -2020,12 +2063,12 @@ func updateBugBadness(c context.Context, bug *uiBug) {
 	bug.NumCrashesBad = bug.NumCrashes >= 10000 && timeNow(c).Sub(bug.LastTime) < 24*time.Hour
 }
 
-func loadCrashesForBug(c context.Context, bug *Bug) ([]*uiCrash, template.HTML, error) {
+func loadCrashesForBug(c context.Context, bug *Bug) ([]*uiCrash, template.HTML, *report.Severity, error) {
 	bugKey := bug.key(c)
 	// We can have more than maxCrashes crashes, if we have lots of reproducers.
 	crashes, _, err := queryCrashesForBug(c, bugKey, 2*maxCrashes()+200)
 	if err != nil || len(crashes) == 0 {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 	builds := make(map[string]*Build)
 	var results []*uiCrash
@@ -2034,7 +2077,7 @@ func loadCrashesForBug(c context.Context, bug *Bug) ([]*uiCrash, template.HTML,
 		if build == nil {
 			build, err = loadBuild(c, bug.Namespace, crash.BuildID)
 			if err != nil {
-				return nil, "", err
+				return nil, "", nil, err
 			}
 			builds[crash.BuildID] = build
 		}
@@ -2042,11 +2085,26 @@ func loadCrashesForBug(c context.Context, bug *Bug) ([]*uiCrash, template.HTML,
 	}
 	sampleReport, _, err := getText(c, textCrashReport, crashes[0].Report)
 	if err != nil {
-		return nil, "", err
+		return nil, "", nil, err
 	}
 	sampleBuild := builds[crashes[0].BuildID]
 	linkifiedReport := linkifyReport(sampleReport, sampleBuild.KernelRepo, sampleBuild.KernelCommit)
-	return results, linkifiedReport, nil
+	return results, linkifiedReport, crashSeverity(bug, crashes[0], sampleBuild, sampleReport), nil
+}
+
+func crashSeverity(bug *Bug, crash *Crash, build *Build, rep []byte) *report.Severity {
+	title := crash.Title
+	if title == "" {
+		title = bug.Title
+	}
+	var sandbox string
+	if crash.ReproSyz != 0 {
+		opts, err := csource.DeserializeOptions(crash.ReproOpts)
+		if err == nil {
+			sandbox = opts.Sandbox
+		}
+	}
+	return report.ClassifySeverity(title, report.ParseDetails(build.OS, rep), sandbox)
 }
 
 func linkifyReport(report []byte, repo, commit string) template.HTML {
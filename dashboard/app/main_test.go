@@ -485,3 +485,70 @@ func TestReproSubmitAccess(t *testing.T) {
 		assert.Contains(t, string(reply), "Send a reproducer")
 	}
 }
+
+func TestCrashSeverity(t *testing.T) {
+	rep := []byte(`BUG: KASAN: slab-use-after-free in foo+0x10/0x100
+Write of size 8 at addr ffff88801ba4c0e8 by task syz-executor/5000
+`)
+	bug := &Bug{Title: "KASAN: slab-use-after-free Write in foo"}
+	build := &Build{OS: "linux"}
+	crash := &Crash{}
+	sev := crashSeverity(bug, crash, build, rep)
+	assert.Equal(t, "high", sev.Tier.String())
+
+	crash.ReproSyz = 1
+	crash.ReproOpts = []byte(`{"sandbox":"namespace"}`)
+	sev = crashSeverity(bug, crash, build, rep)
+	assert.Equal(t, "critical", sev.Tier.String())
+	assert.Contains(t, sev.Reasons, "8-byte write may overwrite a pointer")
+}
+
+func TestProbableDups(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	client := c.makeClient(clientPublicEmail, keyPublicEmail, true)
+	build := testBuild(1)
+	c.expectOK(client.UploadBuild(build))
+
+	crash1 := testCrash(build, 1)
+	crash1.StackFrames = []string{"foo", "bar", "baz", "do_syscall_64"}
+	client.ReportCrash(crash1)
+	extID1 := c.pollEmailExtID()
+	c.advanceTime(time.Hour)
+
+	// Same stack, but a different guilty frame and title.
+	crash2 := testCrash(build, 2)
+	crash2.StackFrames = []string{"foo", "bar", "baz", "do_syscall_64"}
+	client.ReportCrash(crash2)
+	extID2 := c.pollEmailExtID()
+
+	crash3 := testCrash(build, 3)
+	crash3.StackFrames = []string{"qux", "quux", "do_syscall_64"}
+	client.ReportCrash(crash3)
+	extID3 := c.pollEmailExtID()
+
+	// Duplicates are searched asynchronously.
+	bug2, _, _ := c.loadBug(extID2)
+	c.expectEQ(len(bug2.ProbableDups), 0)
+	c.expectEQ(bug2.NeedProbableDups, true)
+	_, err := c.AuthGET(AccessUser, "/cron/probable_dups")
+	c.expectOK(err)
+
+	bug1, _, _ := c.loadBug(extID1)
+	bug2, _, _ = c.loadBug(extID2)
+	bug3, _, _ := c.loadBug(extID3)
+	c.expectEQ(bug2.NeedProbableDups, false)
+	c.expectEQ(len(bug1.ProbableDups), 0)
+	c.expectEQ(len(bug3.ProbableDups), 0)
+	c.expectEQ(len(bug2.ProbableDups), 1)
+	c.expectEQ(bug2.ProbableDups[0].Bug, bug1.keyHash(c.ctx))
+
+	reply, err := c.AuthGET(AccessAdmin, "/bug?id="+bug2.keyHash(c.ctx))
+	c.expectOK(err)
+	assert.Contains(t, string(reply), "Probably duplicate of")
+	assert.Contains(t, string(reply), crash1.Title)
+	reply, err = c.AuthGET(AccessAdmin, "/bug?id="+bug3.keyHash(c.ctx))
+	c.expectOK(err)
+	assert.NotContains(t, string(reply), "Probably duplicate of")
+}
@@ -188,8 +188,11 @@ func writeExtAPICoverageFor(ctx context.Context, w io.Writer, ns, repo string, p
 		return fmt.Errorf("coveragedb.GenNPeriodsTill: %w", err)
 	}
 
-	covDBClient := getCoverageDBClient(ctx)
-	ff, err := coveragedb.MakeFuncFinder(ctx, covDBClient, ns, tps[0])
+	storage, err := getCoverageStorage(ctx, ns)
+	if err != nil {
+		return err
+	}
+	ff, err := storage.MakeFuncFinder(ctx, ns, tps[0])
 	if err != nil {
 		return fmt.Errorf("coveragedb.MakeFuncFinder: %w", err)
 	}
@@ -199,7 +202,7 @@ func writeExtAPICoverageFor(ctx context.Context, w io.Writer, ns, repo string, p
 		subsystem = p.subsystem
 		manager = p.manager
 	}
-	covCh, errCh := coveragedb.FilesCoverageStream(ctx, covDBClient,
+	covCh, errCh := storage.FilesCoverageStream(ctx,
 		&coveragedb.SelectScope{
 			Ns:        ns,
 			Subsystem: subsystem,
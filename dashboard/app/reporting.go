@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"reflect"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -621,10 +622,23 @@ func loadReproSyz(c context.Context, crash *Crash) ([]byte, error) {
 	if len(crash.ReproOpts) != 0 {
 		fmt.Fprintf(buf, "#%s\n", crash.ReproOpts)
 	}
+	buf.WriteString(reproExplanationLegend(reproSyz))
 	buf.Write(reproSyz)
 	return buf.Bytes(), nil
 }
 
+// reproExplanationLegend returns a comment that describes the argument annotations
+// added by syz-repro, or an empty string if the reproducer has no annotations.
+func reproExplanationLegend(reproSyz []byte) string {
+	if !reproExplainedRe.Match(reproSyz) {
+		return ""
+	}
+	return "# \"essential\" arguments are required to trigger the bug, " +
+		"\"irrelevant\" arguments can be changed to any value.\n"
+}
+
+var reproExplainedRe = regexp.MustCompile(`(?m)^# (essential|irrelevant): `)
+
 // fillBugReport fills common report fields for bug and job reports.
 func fillBugReport(c context.Context, rep *dashapi.BugReport, bug *Bug, bugReporting *BugReporting,
 	build *Build) error {
@@ -40,6 +40,7 @@ import (
 
 func initEmailReporting() {
 	http.HandleFunc("/cron/email_coverage_reports", handleCoverageReports)
+	http.HandleFunc("/cron/email_coverage_func_regressions", handleCoverageFuncRegressions)
 	http.HandleFunc("/cron/email_poll", handleEmailPoll)
 	http.HandleFunc("/_ah/mail/", handleIncomingMail)
 	http.HandleFunc("/_ah/bounce", handleEmailBounce)
@@ -199,10 +200,104 @@ func sendNsCoverageReport(ctx context.Context, ns, email string,
 	return nil
 }
 
+// handleCoverageFuncRegressions reports functions that were covered, but have no coverage across all managers
+// for the last Coverage.FuncRegressionDays days. Since the function must be covered right before these days,
+// every regression is reported only once. Functions that are not instrumented anymore are listed separately.
+func handleCoverageFuncRegressions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	targetDate := civil.DateOf(timeNow(ctx)).AddDays(-coverageDataDelay)
+	wg := sync.WaitGroup{}
+	for nsName, nsConfig := range getConfig(ctx).Namespaces {
+		if nsConfig.Coverage == nil || nsConfig.Coverage.EmailRegressionsTo == "" ||
+			nsConfig.Coverage.FuncRegressionDays == 0 {
+			continue
+		}
+		emailTo := nsConfig.Coverage.EmailRegressionsTo
+		days := nsConfig.Coverage.FuncRegressionDays
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sendNsFuncRegressions(ctx, nsName, emailTo, targetDate, days); err != nil {
+				log.Errorf(ctx, "error generating function coverage regressions for ns '%s': %s",
+					nsName, err.Error())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// maxEmailFuncRegressions is the maximum number of functions listed in the regressions email,
+// the full list is available on the web page.
+const maxEmailFuncRegressions = 30
+
+func sendNsFuncRegressions(ctx context.Context, ns, email string, targetDate civil.Date, days int) error {
+	regressions, err := loadFuncRegressions(ctx, ns, targetDate, days)
+	if err != nil {
+		return err
+	}
+	if len(regressions) == 0 {
+		return nil
+	}
+	lost, notInstrumented := splitFuncRegressions(regressions)
+	files, omitted := groupFuncRegressions(lost, maxEmailFuncRegressions)
+	notInstrumentedFiles, notInstrumentedOmitted := groupFuncRegressions(notInstrumented, maxEmailFuncRegressions)
+	// All regressions have the same periods, thus the same commit range.
+	args := struct {
+		Namespace   string
+		Days        int
+		LastCovered civil.Date
+		Link        string
+		ListLink    string
+		Regressions []string
+		Omitted     int
+		// NotInstrumented are the functions that lost coverage because they are not instrumented anymore.
+		NotInstrumented        []string
+		NotInstrumentedOmitted int
+		CommitFrom             string
+		CommitTo               string
+		Files                  string
+	}{
+		Namespace:   ns,
+		Days:        days,
+		LastCovered: regressions[0].LastCovered.DateTo,
+		Link: fmt.Sprintf("%s%s", appURL(ctx),
+			coveragePageLink(ns, coveragedb.DayPeriod, targetDate.String(), 0, days+1, true)),
+		ListLink:               appURL(ctx) + funcRegressionsPageLink(ns, targetDate),
+		Regressions:            funcRegressionsLines(files),
+		Omitted:                omitted,
+		NotInstrumented:        funcRegressionsLines(notInstrumentedFiles),
+		NotInstrumentedOmitted: notInstrumentedOmitted,
+		CommitFrom:             regressions[0].CommitFrom,
+		CommitTo:               regressions[0].CommitTo,
+		Files:                  funcRegressionsFileList(append(files, notInstrumentedFiles...)),
+	}
+	title := fmt.Sprintf("%s function coverage regression (%s)", ns, args.LastCovered)
+	return sendMailTemplate(ctx, &mailSendParams{
+		templateName: "mail_ns_coverage_funcs.txt",
+		templateArg:  args,
+		title:        title,
+		cfg: &EmailConfig{
+			Email: email,
+		},
+		reportID: "coverage-report",
+	})
+}
+
+func funcRegressionsLines(files []*uiFuncRegressionFile) []string {
+	var lines []string
+	for _, file := range files {
+		lines = append(lines, fmt.Sprintf("%s: %s", file.FilePath, strings.Join(file.Funcs, ", ")))
+	}
+	return lines
+}
+
 func coverageTable(ctx context.Context, ns string, fromTo []coveragedb.TimePeriod, minDrop int) (string, error) {
-	covAndDates, err := coveragedb.FilesCoverageWithDetails(
+	storage, err := getCoverageStorage(ctx, ns)
+	if err != nil {
+		return "", err
+	}
+	covAndDates, err := storage.FilesCoverageWithDetails(
 		ctx,
-		getCoverageDBClient(ctx),
 		&coveragedb.SelectScope{
 			Ns:      ns,
 			Periods: fromTo,
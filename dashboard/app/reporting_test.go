@@ -9,9 +9,11 @@ import (
 	"html"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"cloud.google.com/go/civil"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/syzkaller/dashboard/dashapi"
 	"github.com/google/syzkaller/pkg/coveragedb"
@@ -1417,3 +1419,89 @@ Blocks diff,	Path
 
 `, msg.Body)
 }
+
+func TestCoverageFuncRegression(t *testing.T) {
+	c := NewCtx(t)
+	defer c.Close()
+
+	storage := c.setLocalCoverageDB("coverage-tests")
+	for i, hitCount := range []int64{5, 0} {
+		jsonl := fmt.Sprintf(`
+{"MCR":{"Manager":"*","FilePath":"file_name.c",
+	"FileData":{"Instrumented":3,"LinesInstrumented":[1,2,3],"HitCounts":[%[1]v,1,%[1]v]}}}
+{"FL":{"FilePath":"file_name.c","FuncName":"foo","Lines":[1]}}
+{"FL":{"FilePath":"file_name.c","FuncName":"bar","Lines":[2]}}
+{"FL":{"FilePath":"file_name.c","FuncName":"baz","Lines":[3]}}
+{"FL":{"FilePath":"other.c","FuncName":"qux","Lines":[1]}}
+`, hitCount)
+		// other.c is not built anymore on the second day, qux is reported separately.
+		if i == 0 {
+			jsonl += `{"MCR":{"Manager":"*","FilePath":"other.c",` +
+				`"FileData":{"Instrumented":1,"LinesInstrumented":[1],"HitCounts":[1]}}}`
+		}
+		c.saveLocalCoverage(storage, "coverage-tests", civil.Date{Year: 1999, Month: 12, Day: 29 + i},
+			fmt.Sprintf("commit%v", hitCount), jsonl)
+	}
+
+	_, err := c.AuthGET(AccessAdmin, "/cron/email_coverage_func_regressions")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(c.emailSink))
+	msg := <-c.emailSink
+	assert.Equal(t, []string{"test@test.test"}, msg.To)
+	assert.Equal(t, "coverage-tests function coverage regression (1999-12-29)", msg.Subject)
+	wantLink := "https://testapp.appspot.com/coverage-tests/coverage?" +
+		"dateto=1999-12-30&order-by-cover-lines-drop=1&period=day&period_count=2"
+	assert.Equal(t, `Functions in 'coverage-tests' that were covered on 1999-12-29, `+
+		`but have no coverage across all managers for 1 day(s) since then:
+
+  file_name.c: baz, foo
+
+Functions in 'coverage-tests' that were covered on 1999-12-29, but are not instrumented anymore `+
+		`(e.g. the file is not built or the config option was disabled):
+
+  other.c: qux
+
+Full list: https://testapp.appspot.com/coverage-tests/coverage/func-regressions?dateto=1999-12-30
+Web version: `+wantLink+`
+
+The coverage was lost between kernel commits commit5 and commit0.
+Suspected causes (e.g. a changed config option or a broken syscall description) may be found with:
+
+  git log --oneline commit5..commit0 -- file_name.c other.c
+`, msg.Body)
+
+	reply, err := c.AuthGET(AccessAdmin, "/coverage-tests/coverage/func-regressions?dateto=1999-12-30")
+	assert.NoError(t, err)
+	assert.Contains(t, string(reply), "<td>baz, foo</td>")
+	assert.Contains(t, string(reply), "<td>qux</td>")
+	assert.Contains(t, string(reply), "git log --oneline commit5..commit0 -- file_name.c other.c")
+}
+
+func TestGroupFuncRegressions(t *testing.T) {
+	var regressions []*coveragedb.FuncRegression
+	for _, name := range []string{"a.c:foo", "a.c:bar", "b.c:baz", "c.c:qux"} {
+		file, fn, _ := strings.Cut(name, ":")
+		regressions = append(regressions, &coveragedb.FuncRegression{
+			FuncCoverage: &coveragedb.FuncCoverage{FilePath: file, FuncName: fn},
+		})
+	}
+	files, omitted := groupFuncRegressions(regressions, 0)
+	assert.Equal(t, []*uiFuncRegressionFile{
+		{FilePath: "a.c", Funcs: []string{"foo", "bar"}},
+		{FilePath: "b.c", Funcs: []string{"baz"}},
+		{FilePath: "c.c", Funcs: []string{"qux"}},
+	}, files)
+	assert.Equal(t, 0, omitted)
+	files, omitted = groupFuncRegressions(regressions, 3)
+	assert.Equal(t, []*uiFuncRegressionFile{
+		{FilePath: "a.c", Funcs: []string{"foo", "bar"}},
+		{FilePath: "b.c", Funcs: []string{"baz"}},
+	}, files)
+	assert.Equal(t, 1, omitted)
+}
+
+func TestReproExplanationLegend(t *testing.T) {
+	assert.Empty(t, reproExplanationLegend([]byte("pause()\nalarm(0xa)\n")))
+	assert.NotEmpty(t, reproExplanationLegend([]byte("pause()\n# essential: seconds\nalarm(0xa)\n")))
+	assert.NotEmpty(t, reproExplanationLegend([]byte("# irrelevant: a0\ntest$int(0x1)\n")))
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/syzkaller/dashboard/api"
 	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/coveragedb"
 	"github.com/google/syzkaller/pkg/coveragedb/spannerclient"
 	"github.com/google/syzkaller/pkg/covermerger"
 	"github.com/google/syzkaller/pkg/email"
@@ -237,6 +238,33 @@ func (c *Ctx) setCoverageMocks(ns string, dbClientMock spannerclient.SpannerClie
 	}
 }
 
+// setLocalCoverageDB makes the namespace use a new local coverage database and returns it.
+func (c *Ctx) setLocalCoverageDB(ns string) coveragedb.Storage {
+	dir := c.t.TempDir()
+	storage, err := coveragedb.NewLocalStorage(dir)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	// Share the instance with the dashboard, only one may be open.
+	localCoverageStoragesMu.Lock()
+	localCoverageStorages[dir] = storage
+	localCoverageStoragesMu.Unlock()
+	c.transformContext = func(ctx context.Context) context.Context {
+		newConfig := replaceNamespaceConfig(ctx, ns, func(cfg *Config) *Config {
+			ret := *cfg
+			coverage := CoverageConfig{}
+			if cfg.Coverage != nil {
+				coverage = *cfg.Coverage
+			}
+			coverage.LocalDBDir = dir
+			ret.Coverage = &coverage
+			return &ret
+		})
+		return contextWithConfig(ctx, newConfig)
+	}
+	return storage
+}
+
 func (c *Ctx) setKernelRepos(ns string, list []KernelRepo) {
 	c.transformContext = func(c context.Context) context.Context {
 		newConfig := replaceNamespaceConfig(c, ns, func(cfg *Config) *Config {
@@ -15,6 +15,7 @@ import (
 	"net/http"
 	"net/mail"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/google/syzkaller/pkg/auth"
@@ -217,6 +218,8 @@ type JobDoneReq struct {
 	// If there are more than 1: suspected commits due to skips (broken build/boot).
 	Commits []Commit
 	Flags   JobDoneFlags
+	// Estimated probability that the bisection result is correct (0 if unknown).
+	Confidence float64
 }
 
 type JobType int
@@ -230,11 +233,13 @@ const (
 type JobDoneFlags int64
 
 const (
-	BisectResultMerge      JobDoneFlags = 1 << iota // bisected to a merge commit
-	BisectResultNoop                                // commit does not affect resulting kernel binary
-	BisectResultRelease                             // commit is a kernel release
-	BisectResultIgnore                              // this particular commit should be ignored, see syz-ci/jobs.go
-	BisectResultInfraError                          // the bisect failed due to an infrastructure problem
+	BisectResultMerge        JobDoneFlags = 1 << iota // bisected to a merge commit
+	BisectResultNoop                                  // commit does not affect resulting kernel binary
+	BisectResultRelease                               // commit is a kernel release
+	BisectResultIgnore                                // this particular commit should be ignored, see syz-ci/jobs.go
+	BisectResultInfraError                            // the bisect failed due to an infrastructure problem
+	BisectResultNonMonotonic                          // test results contradict the commit (multiple culprits?)
+	BisectResultTitleChanged                          // the crash title changed during bisection
 )
 
 func (flags JobDoneFlags) String() string {
@@ -254,10 +259,20 @@ func (flags JobDoneFlags) String() string {
 	if flags&BisectResultIgnore != 0 {
 		res += "ignored "
 	}
-	if res == "" {
-		return res
+	if res != "" {
+		res = "[" + res + "commit]"
 	}
-	return "[" + res + "commit]"
+	var warnings []string
+	if flags&BisectResultNonMonotonic != 0 {
+		warnings = append(warnings, "bug reappeared")
+	}
+	if flags&BisectResultTitleChanged != 0 {
+		warnings = append(warnings, "crash title changed")
+	}
+	if len(warnings) != 0 {
+		res = strings.TrimSpace(res + " [" + strings.Join(warnings, ", ") + "]")
+	}
+	return res
 }
 
 func (dash *Dashboard) JobPoll(req *JobPollReq) (*JobPollResp, error) {
@@ -332,6 +347,9 @@ type Crash struct {
 	MachineInfo []byte
 	Assets      []NewAsset
 	GuiltyFiles []string
+	// Normalized function names of the main crash stack, used to find probable duplicates
+	// with different titles (see report.StackSimilarity).
+	StackFrames []string
 	// The following is optional and is filled only after repro.
 	ReproOpts     []byte
 	ReproSyz      []byte
@@ -511,6 +529,7 @@ const (
 	KernelImage        AssetType = "kernel_image"
 	HTMLCoverageReport AssetType = "html_coverage_report"
 	MountInRepro       AssetType = "mount_in_repro"
+	KernelCrashDump    AssetType = "kernel_crash_dump"
 )
 
 type BisectResult struct {
@@ -982,6 +1001,7 @@ type JobInfo struct {
 	ReproSyzLink     string
 	Commit           *Commit   // for conclusive bisection
 	Commits          []*Commit // for inconclusive bisection
+	Confidence       float64
 	Reported         bool
 	InvalidatedBy    string
 	TreeOrigin       bool
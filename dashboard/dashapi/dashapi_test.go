@@ -45,3 +45,22 @@ func TestNewOpts(t *testing.T) {
 		})
 	}
 }
+
+func TestJobDoneFlagsString(t *testing.T) {
+	tests := []struct {
+		flags JobDoneFlags
+		want  string
+	}{
+		{0, ""},
+		{BisectResultMerge | BisectResultNoop, "[merge no-op commit]"},
+		{BisectResultNonMonotonic, "[bug reappeared]"},
+		{BisectResultRelease | BisectResultNonMonotonic | BisectResultTitleChanged,
+			"[release commit] [bug reappeared, crash title changed]"},
+		{BisectResultInfraError | BisectResultTitleChanged, "[infra failure]"},
+	}
+	for _, test := range tests {
+		if got := test.flags.String(); got != test.want {
+			t.Errorf("flags %x: got %q, want %q", int64(test.flags), got, test.want)
+		}
+	}
+}
@@ -164,13 +164,14 @@ static void install_segv_handler(void)
 #if !GOOS_linux
 #if (SYZ_EXECUTOR || SYZ_REPEAT) && SYZ_EXECUTOR_USES_FORK_SERVER
 #include <signal.h>
+#include <sys/resource.h>
 #include <sys/types.h>
 #include <sys/wait.h>
 
-static void kill_and_wait(int pid, int* status)
+static void kill_and_wait(int pid, int* status, struct rusage* usage)
 {
 	kill(pid, SIGKILL);
-	while (waitpid(-1, status, 0) != pid) {
+	while (wait4(-1, status, 0, usage) != pid) {
 	}
 }
 #endif
@@ -680,10 +681,17 @@ static void loop(void)
 #if SYZ_EXECUTOR
 		uint64 last_executed = start;
 		uint32 executed_calls = output_data->completed.load(std::memory_order_relaxed);
+		// Resource usage of the test process is collected here rather than in the test process,
+		// so that it's available even if the test process is killed.
+		struct rusage usage = {};
 #endif
 		for (;;) {
 			sleep_ms(10);
+#if SYZ_EXECUTOR
+			if (wait4(-1, &status, WNOHANG | WAIT_FLAGS, &usage) == pid)
+#else
 			if (waitpid(-1, &status, WNOHANG | WAIT_FLAGS) == pid)
+#endif
 				break;
 #if SYZ_EXECUTOR
 			// Even though the test process executes exit at the end
@@ -729,10 +737,15 @@ static void loop(void)
 				continue;
 #endif
 			debug("killing hanging pid %d\n", pid);
-			kill_and_wait(pid, &status);
+#if SYZ_EXECUTOR
+			kill_and_wait(pid, &status, &usage);
+#else
+			kill_and_wait(pid, &status, NULL);
+#endif
 			break;
 		}
 #if SYZ_EXECUTOR
+		write_resource_usage(&usage);
 		if (WEXITSTATUS(status) == kFailStatus) {
 			errno = 0;
 			fail("child failed");
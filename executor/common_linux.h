@@ -4774,17 +4774,18 @@ static int fault_injected(int fail_fd)
 #include <fcntl.h>
 #include <signal.h>
 #include <string.h>
+#include <sys/resource.h>
 #include <sys/stat.h>
 #include <sys/types.h>
 #include <sys/wait.h>
 
-static void kill_and_wait(int pid, int* status)
+static void kill_and_wait(int pid, int* status, struct rusage* usage)
 {
 	kill(-pid, SIGKILL);
 	kill(pid, SIGKILL);
 	// First, give it up to 100 ms to surrender.
 	for (int i = 0; i < 100; i++) {
-		if (waitpid(-1, status, WNOHANG | __WALL) == pid)
+		if (wait4(-1, status, WNOHANG | __WALL, usage) == pid)
 			return;
 		usleep(1000);
 	}
@@ -4820,7 +4821,7 @@ static void kill_and_wait(int pid, int* status)
 		debug("failed to open /sys/fs/fuse/connections: %d\n", errno);
 	}
 	// Now, just wait, no other options.
-	while (waitpid(-1, status, __WALL) != pid) {
+	while (wait4(-1, status, __WALL, usage) != pid) {
 	}
 }
 #endif
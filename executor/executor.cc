@@ -22,6 +22,11 @@
 #include <unistd.h>
 #endif
 
+#if GOOS_linux || GOOS_freebsd || GOOS_netbsd || GOOS_openbsd || GOOS_darwin || GOOS_test
+#include <fcntl.h>
+#include <sys/resource.h>
+#endif
+
 #include "defs.h"
 
 #include "pkg/flatrpc/flatrpc.h"
@@ -72,6 +77,8 @@ const int kOutPipeFd = kMaxFd - 2; // remapped from stdout
 const int kCoverFd = kOutPipeFd - kMaxThreads;
 const int kExtraCoverFd = kCoverFd - 1;
 const int kMaxArgs = 9;
+// Max number of bytes pointed to by a call argument that are saved in call traces.
+const int kMaxTraceBuffer = 256;
 const int kCoverSize = 512 << 10;
 const int kFailStatus = 67;
 
@@ -114,6 +121,7 @@ static void receive_handshake();
 
 #if SYZ_EXECUTOR_USES_FORK_SERVER
 static void SnapshotPrepareParent();
+static void write_resource_usage(const struct rusage* usage);
 
 // Allocating (and forking) virtual memory for each executed process is expensive, so we only mmap
 // the amount we might possibly need for the specific received prog.
@@ -147,6 +155,20 @@ struct alignas(8) OutputData {
 	std::atomic<uint32> completed;
 	std::atomic<uint32> num_calls;
 	std::atomic<flatbuffers::Offset<flatbuffers::Vector<uint8_t>>> result_offset;
+	// Resource usage of the test process. The number of open fds is filled in by the test process
+	// right before it exits, the rest is filled in by the parent after the test process has exited.
+	std::atomic<uint64> peak_rss;
+	std::atomic<uint32> open_fds;
+	std::atomic<uint64> user_time;
+	std::atomic<uint64> system_time;
+	std::atomic<uint64> page_faults;
+	// Calls that are currently executing in each thread, used to send heartbeats to the host.
+	struct {
+		// Call index in the test program + 1, or 0 if the thread is idle.
+		std::atomic<uint32> call;
+		// current_time_ms() when the call was started.
+		std::atomic<uint64> start;
+	} executing[kMaxThreads];
 	struct {
 		// Call index in the test program (they may be out-of-order is some syscalls block).
 		int index;
@@ -161,6 +183,13 @@ struct alignas(8) OutputData {
 		completed.store(0, std::memory_order_relaxed);
 		num_calls.store(0, std::memory_order_relaxed);
 		result_offset.store(0, std::memory_order_relaxed);
+		peak_rss.store(0, std::memory_order_relaxed);
+		open_fds.store(0, std::memory_order_relaxed);
+		user_time.store(0, std::memory_order_relaxed);
+		system_time.store(0, std::memory_order_relaxed);
+		page_faults.store(0, std::memory_order_relaxed);
+		for (auto& exec : executing)
+			exec.call.store(0, std::memory_order_relaxed);
 	}
 };
 
@@ -280,6 +309,8 @@ static bool flag_threaded;
 
 // If true, then executor should write the comparisons data to fuzzer.
 static bool flag_comparisons;
+// Collect call traces: raw argument values, pointed-to memory before/after the call and the return value.
+static bool flag_collect_trace;
 
 static uint64 request_id;
 static rpc::RequestType request_type;
@@ -387,6 +418,9 @@ struct thread_t {
 	bool fault_injected;
 	cover_t cov;
 	bool soft_fail_state;
+	// Contents of memory pointed to by the arguments right before the call (if flag_collect_trace).
+	uint8 trace_before[kMaxArgs][kMaxTraceBuffer];
+	uint32 trace_size[kMaxArgs];
 };
 
 static thread_t threads[kMaxThreads];
@@ -461,6 +495,8 @@ static void handle_completion(thread_t* th);
 static void copyout_call_results(thread_t* th);
 static void write_call_output(thread_t* th, bool finished);
 static void write_extra_output();
+static uint32 trace_pointee(intptr_t arg, uint8* buf);
+static void write_open_fds();
 static void execute_call(thread_t* th);
 static void thread_create(thread_t* th, int id, bool need_coverage);
 static void thread_mmap_cover(thread_t* th);
@@ -814,13 +850,14 @@ void parse_execute(const execute_req& req)
 	flag_dedup_cover = req.exec_flags & (1 << 2);
 	flag_comparisons = req.exec_flags & (1 << 3);
 	flag_threaded = req.exec_flags & (1 << 4);
+	flag_collect_trace = req.exec_flags & (1 << 5);
 	all_call_signal = req.all_call_signal;
 	all_extra_signal = req.all_extra_signal;
 
 	debug("[%llums] exec opts: reqid=%llu type=%llu procid=%llu threaded=%d cover=%d comps=%d dedup=%d signal=%d "
-	      " sandbox=%d/%d/%d/%d timeouts=%llu/%llu/%llu kernel_64_bit=%d\n",
+	      "trace=%d sandbox=%d/%d/%d/%d timeouts=%llu/%llu/%llu kernel_64_bit=%d\n",
 	      current_time_ms() - start_time_ms, request_id, (uint64)request_type, procid, flag_threaded, flag_collect_cover,
-	      flag_comparisons, flag_dedup_cover, flag_collect_signal, flag_sandbox_none, flag_sandbox_setuid,
+	      flag_comparisons, flag_dedup_cover, flag_collect_signal, flag_collect_trace, flag_sandbox_none, flag_sandbox_setuid,
 	      flag_sandbox_namespace, flag_sandbox_android, syscall_timeout_ms, program_timeout_ms, slowdown_scale,
 	      is_kernel_64_bit);
 	if (syscall_timeout_ms == 0 || program_timeout_ms <= syscall_timeout_ms || slowdown_scale == 0)
@@ -848,7 +885,7 @@ void realloc_output_data()
 		mmap_output(kMaxOutputComparisons);
 	else if (flag_collect_cover)
 		mmap_output(kMaxOutputCoverage);
-	else if (flag_collect_signal)
+	else if (flag_collect_signal || flag_collect_trace)
 		mmap_output(kMaxOutputSignal);
 	if (close(kOutFd) < 0)
 		fail("failed to close kOutFd");
@@ -1099,6 +1136,8 @@ void execute_one()
 		}
 	}
 
+	// Note: this needs to be done before close_fds to count the descriptors the program has opened.
+	write_open_fds();
 #if SYZ_HAVE_CLOSE_FDS
 	close_fds();
 #endif
@@ -1156,11 +1195,17 @@ thread_t* schedule_call(int call_index, int call_num, uint64 copyout_index, uint
 		cover_reset(&th->cov);
 	th->executing = true;
 	th->call_index = call_index;
+	output_data->executing[th->id].start.store(current_time_ms(), std::memory_order_relaxed);
+	output_data->executing[th->id].call.store(call_index + 1, std::memory_order_release);
 	th->call_num = call_num;
 	th->num_args = num_args;
 	th->call_props = call_props;
 	for (int i = 0; i < kMaxArgs; i++)
 		th->args[i] = args[i];
+	if (flag_collect_trace) {
+		for (int i = 0; i < th->num_args; i++)
+			th->trace_size[i] = trace_pointee(th->args[i], th->trace_before[i]);
+	}
 	event_set(&th->ready);
 	running++;
 	return th;
@@ -1269,6 +1314,7 @@ void handle_completion(thread_t* th)
 	write_call_output(th, true);
 	write_extra_output();
 	th->executing = false;
+	output_data->executing[th->id].call.store(0, std::memory_order_relaxed);
 	running--;
 	if (running < 0) {
 		// This fires periodically for the past 2 years (see issue #502).
@@ -1318,7 +1364,42 @@ void copyout_call_results(thread_t* th)
 	}
 }
 
-void write_output(int index, cover_t* cov, rpc::CallFlag flags, uint32 error, bool all_signal)
+uint32 trace_pointee(intptr_t arg, uint8* buf)
+{
+	// We don't know argument types here, so we consider everything that points into the data region
+	// as a pointer and save at most kMaxTraceBuffer bytes (the host truncates it to the actual size).
+	const uint64 data_start = SYZ_DATA_OFFSET;
+	const uint64 data_end = data_start + SYZ_NUM_PAGES * SYZ_PAGE_SIZE;
+	uint64 addr = (uint64)arg;
+	if (addr < data_start || addr >= data_end)
+		return 0;
+	uint32 size = std::min<uint64>(kMaxTraceBuffer, data_end - addr);
+	if (!NONFAILING(memcpy(buf, (void*)arg, size)))
+		return 0;
+	return size;
+}
+
+flatbuffers::Offset<rpc::CallTraceRaw> write_trace(flatbuffers::FlatBufferBuilder& fbb, thread_t* th)
+{
+	uint64_t args[kMaxArgs];
+	flatbuffers::Offset<rpc::CallBufferRaw> buffers[kMaxArgs];
+	int num_buffers = 0;
+	for (int i = 0; i < th->num_args; i++) {
+		args[i] = (uint64)th->args[i];
+		if (!th->trace_size[i])
+			continue;
+		uint8 after[kMaxTraceBuffer];
+		uint32 after_size = trace_pointee(th->args[i], after);
+		auto before_off = fbb.CreateVector(th->trace_before[i], th->trace_size[i]);
+		auto after_off = fbb.CreateVector(after, after_size);
+		buffers[num_buffers++] = rpc::CreateCallBufferRaw(fbb, i, before_off, after_off);
+	}
+	auto args_off = fbb.CreateVector(args, th->num_args);
+	auto buffers_off = fbb.CreateVector(buffers, num_buffers);
+	return rpc::CreateCallTraceRaw(fbb, args_off, (uint64)th->res, buffers_off);
+}
+
+void write_output(int index, cover_t* cov, rpc::CallFlag flags, uint32 error, bool all_signal, thread_t* th)
 {
 	CoverAccessScope scope(cov);
 	auto& fbb = *output_builder;
@@ -1343,6 +1424,9 @@ void write_output(int index, cover_t* cov, rpc::CallFlag flags, uint32 error, bo
 				cover_off = write_cover<uint32>(fbb, cov);
 		}
 	}
+	flatbuffers::Offset<rpc::CallTraceRaw> trace_off = 0;
+	if (flag_collect_trace && th)
+		trace_off = write_trace(fbb, th);
 
 	rpc::CallInfoRawBuilder builder(*output_builder);
 	if (cov->overflow)
@@ -1355,6 +1439,8 @@ void write_output(int index, cover_t* cov, rpc::CallFlag flags, uint32 error, bo
 		builder.add_cover(cover_off);
 	if (comps_off)
 		builder.add_comps(comps_off);
+	if (!trace_off.IsNull())
+		builder.add_trace(trace_off);
 	auto off = builder.Finish();
 	uint32 slot = output_data->completed.load(std::memory_order_relaxed);
 	if (slot >= kMaxCalls)
@@ -1381,7 +1467,7 @@ void write_call_output(thread_t* th, bool finished)
 			flags |= rpc::CallFlag::FaultInjected;
 	}
 	bool all_signal = th->call_index < 64 ? (all_call_signal & (1ull << th->call_index)) : false;
-	write_output(th->call_index, &th->cov, flags, reserrno, all_signal);
+	write_output(th->call_index, &th->cov, flags, reserrno, all_signal, th);
 }
 
 void write_extra_output()
@@ -1391,10 +1477,52 @@ void write_extra_output()
 	cover_collect(&extra_cov);
 	if (!extra_cov.size)
 		return;
-	write_output(-1, &extra_cov, rpc::CallFlag::NONE, 997, all_extra_signal);
+	write_output(-1, &extra_cov, rpc::CallFlag::NONE, 997, all_extra_signal, nullptr);
 	cover_reset(&extra_cov);
 }
 
+#if GOOS_linux || GOOS_freebsd || GOOS_netbsd || GOOS_openbsd || GOOS_darwin
+void write_open_fds()
+{
+	uint32 open_fds = 0;
+	for (int fd = 0; fd < kMaxFd; fd++) {
+		if (fcntl(fd, F_GETFD) != -1)
+			open_fds++;
+	}
+	output_data->open_fds.store(open_fds, std::memory_order_relaxed);
+}
+#else
+void write_open_fds()
+{
+}
+#endif
+
+#if SYZ_EXECUTOR_USES_FORK_SERVER
+#if GOOS_linux || GOOS_freebsd || GOOS_netbsd || GOOS_openbsd || GOOS_darwin
+// Called by the parent after the test process has exited, so that the usage is reported even if
+// the test process was killed (e.g. because it hanged or by the OOM killer).
+// usage is the result of wait4 for the test process.
+// Note: peak RSS includes the memory the test process has inherited from the executor.
+void write_resource_usage(const struct rusage* usage)
+{
+#if GOOS_darwin
+	uint64 peak_rss = usage->ru_maxrss;
+#else
+	uint64 peak_rss = usage->ru_maxrss * 1024ull;
+#endif
+	auto ns = [](const timeval& tv) { return tv.tv_sec * 1000000000ull + tv.tv_usec * 1000ull; };
+	output_data->peak_rss.store(peak_rss, std::memory_order_relaxed);
+	output_data->user_time.store(ns(usage->ru_utime), std::memory_order_relaxed);
+	output_data->system_time.store(ns(usage->ru_stime), std::memory_order_relaxed);
+	output_data->page_faults.store(usage->ru_minflt + usage->ru_majflt, std::memory_order_relaxed);
+}
+#else
+void write_resource_usage(const struct rusage* usage)
+{
+}
+#endif
+#endif
+
 flatbuffers::span<uint8_t> finish_output(OutputData* output, int proc_id, uint64 req_id, uint32 num_calls, uint64 elapsed,
 					 uint64 freshness, uint32 status, bool hanged, const std::vector<uint8_t>* process_output)
 {
@@ -1422,7 +1550,12 @@ flatbuffers::span<uint8_t> finish_output(OutputData* output, int proc_id, uint64
 		}
 		calls[call.index] = call.offset;
 	}
-	auto prog_info_off = rpc::CreateProgInfoRawDirect(fbb, &calls, &extra, 0, elapsed, freshness);
+	auto prog_info_off = rpc::CreateProgInfoRawDirect(fbb, &calls, &extra, 0, elapsed, freshness,
+							   output->peak_rss.load(std::memory_order_relaxed),
+							   output->open_fds.load(std::memory_order_relaxed),
+							   output->user_time.load(std::memory_order_relaxed),
+							   output->system_time.load(std::memory_order_relaxed),
+							   output->page_faults.load(std::memory_order_relaxed));
 	flatbuffers::Offset<flatbuffers::String> error_off = 0;
 	if (status == kFailStatus)
 		error_off = fbb.CreateString("process failed");
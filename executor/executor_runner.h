@@ -180,6 +180,20 @@ public:
 		return;
 	}
 
+	// Appends calls that are currently executing in the test process to calls.
+	void CollectExecuting(uint64 now, std::vector<rpc::ExecutingCallRaw>& calls)
+	{
+		if (state_ != State::Executing || !msg_)
+			return;
+		for (const auto& exec : resp_mem_->executing) {
+			uint32 call = exec.call.load(std::memory_order_acquire);
+			uint64 start = exec.start.load(std::memory_order_relaxed);
+			if (call == 0 || start > now)
+				continue;
+			calls.emplace_back(msg_->id, id_, call - 1, (now - start) * 1000 * 1000);
+		}
+	}
+
 private:
 	enum State : uint8 {
 		// The process has just started.
@@ -568,6 +582,7 @@ private:
 	uint32 slowdown_ = 0;
 	uint32 syscall_timeout_ms_ = 0;
 	uint32 program_timeout_ms_ = 0;
+	uint64 last_heartbeat_ = 0;
 
 	friend std::ostream& operator<<(std::ostream& ss, const Runner& runner)
 	{
@@ -627,6 +642,25 @@ private:
 
 		if (restarting_ < 0 || restarting_ > static_cast<int>(procs_.size()))
 			failmsg("bad restarting", "restarting=%d", restarting_);
+
+		constexpr uint64 kHeartbeatPeriod = 10 * 1000;
+		if (now - last_heartbeat_ >= kHeartbeatPeriod) {
+			last_heartbeat_ = now;
+			SendHeartbeat(now);
+		}
+	}
+
+	// Heartbeat lets the host know what calls are executing and for how long,
+	// so that it can detect hanging calls before the kernel or program timeouts do.
+	// It's sent even if nothing is executing, so that the host drops calls from the previous heartbeat.
+	void SendHeartbeat(uint64 now)
+	{
+		rpc::HeartbeatRawT heartbeat;
+		for (auto& proc : procs_)
+			proc->CollectExecuting(now, heartbeat.calls);
+		rpc::ExecutorMessageRawT raw;
+		raw.msg.Set(std::move(heartbeat));
+		conn_.Send(raw);
 	}
 
 	// Implementation must match that in pkg/rpcserver/rpcserver.go.
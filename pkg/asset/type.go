@@ -57,6 +57,12 @@ var assetTypes = map[dashapi.AssetType]*TypeDescription{
 		// the omnipresent gzip compression.
 		customCompressor: gzipCompressor,
 	},
+	dashapi.KernelCrashDump: {
+		GetTitle:      constTitle("kernel crash dump"),
+		ReportingPrio: 6,
+		// Crash dumps are as large as the VM memory, xz is too slow for them.
+		customCompressor: gzipCompressor,
+	},
 }
 
 type QueryTypeTitle func(*targets.Target) string
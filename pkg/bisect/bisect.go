@@ -63,6 +63,9 @@ type KernelConfig struct {
 	Userspace      string
 	// Extra commits to cherry pick to older kernel revisions.
 	Backports []vcs.BackportCommit
+	// Series is a set of candidate commits for RunSeries.
+	// The commits are cherry-picked on top of Commit in the given order.
+	Series []string
 }
 
 type SyzkallerConfig struct {
@@ -103,6 +106,14 @@ type env struct {
 	// A cache of already performed revision tests.
 	results  map[string]*testResult
 	buildCfg instance.BuildKernelConfig
+	// Release tags older than the first good release during cause bisection.
+	olderReleases []string
+	// If set, the checkout is already prepared for the next build (fix backports are applied),
+	// and build uses it instead of calling EnvForCommit.
+	bisectEnv *vcs.BisectEnv
+	// If set, it's used instead of the HEAD commit hash in logs and debug file names
+	// (series bisection tests the same base commit with different parts of the series applied).
+	label string
 }
 
 const MaxNumTests = 20 // number of tests we do per commit
@@ -126,6 +137,9 @@ const MaxNumTests = 20 // number of tests we do per commit
 //   - Commit points to the oldest/latest commit where crash happens.
 //
 // 4. Config contains kernel config used for bisection.
+//
+// 5. Confidence is the estimated probability that the result is correct.
+// Flaky, NonMonotonic and Titles describe patterns that often make bisection results wrong.
 type Result struct {
 	Commits    []*vcs.Commit
 	Report     *report.Report
@@ -134,30 +148,47 @@ type Result struct {
 	NoopChange bool
 	IsRelease  bool
 	Confidence float64
+	// Flaky is set if the reproducer did not crash the kernel on every run.
+	Flaky bool
+	// NonMonotonic is set if some test results contradict the cause/fix commit,
+	// e.g. the bug appeared, disappeared and reappeared again.
+	// It usually means that there are multiple culprits.
+	NonMonotonic bool
+	// Titles are all distinct crash titles observed during bisection.
+	// Several titles mean that the bisection may have followed different bugs.
+	Titles []string
 }
 
 // Run does the bisection and returns either the Result,
 // or, if the crash is not reproduced on the start commit, an error.
 func Run(cfg *Config) (*Result, error) {
-	if err := checkConfig(cfg); err != nil {
+	repo, inst, err := setup(cfg)
+	if err != nil {
 		return nil, err
 	}
+	return runImpl(cfg, repo, inst)
+}
+
+func setup(cfg *Config) (vcs.Repo, instance.Env, error) {
+	if err := checkConfig(cfg); err != nil {
+		return nil, nil, err
+	}
 	cfg.Manager.Cover = false // it's not supported somewhere back in time
 	repo, err := vcs.NewRepo(cfg.Manager.TargetOS, cfg.Manager.Type, cfg.Manager.KernelSrc)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	inst, err := instance.NewEnv(cfg.Manager, cfg.BuildSemaphore, cfg.TestSemaphore)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if _, err = repo.CheckoutBranch(cfg.Kernel.Repo, cfg.Kernel.Branch); err != nil {
-		return nil, &build.InfraError{Title: fmt.Sprintf("%v", err)}
+		return nil, nil, &build.InfraError{Title: fmt.Sprintf("%v", err)}
 	}
-	return runImpl(cfg, repo, inst)
+	return repo, inst, nil
 }
 
-func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
+func newEnv(cfg *Config, repo vcs.Repo, inst instance.Env) (*env, error) {
 	bisecter, ok := repo.(vcs.Bisecter)
 	if !ok {
 		return nil, fmt.Errorf("bisection is not implemented for %v", cfg.Manager.TargetOS)
@@ -166,7 +197,7 @@ func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
 	if !ok && len(cfg.Kernel.BaselineConfig) != 0 {
 		return nil, fmt.Errorf("config minimization is not implemented for %v", cfg.Manager.TargetOS)
 	}
-	env := &env{
+	return &env{
 		cfg:        cfg,
 		repo:       repo,
 		bisecter:   bisecter,
@@ -185,6 +216,13 @@ func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
 			KernelConfig: cfg.Kernel.Config,
 			BuildCPUs:    cfg.BuildCPUs,
 		},
+	}, nil
+}
+
+func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
+	env, err := newEnv(cfg, repo, inst)
+	if err != nil {
+		return nil, err
 	}
 	head, err := repo.Commit(vcs.HEAD)
 	if err != nil {
@@ -239,6 +277,13 @@ func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
 	}
 	com := res.Commits[0]
 	env.logf("first %v commit: %v %v", what, com.Hash, com.Title)
+	env.logf("confidence: %.2f", res.Confidence)
+	if res.NonMonotonic {
+		env.logf("warning: test results contradict the %v commit", what)
+	}
+	if len(res.Titles) > 1 {
+		env.logf("warning: the crash title changed during bisection: %q", res.Titles)
+	}
 	env.logf("recipients (to): %q", com.Recipients.GetEmails(vcs.To))
 	env.logf("recipients (cc): %q", com.Recipients.GetEmails(vcs.Cc))
 	if res.Report != nil {
@@ -247,21 +292,27 @@ func runImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*Result, error) {
 	return res, nil
 }
 
-func (env *env) bisect() (*Result, error) {
-	err := env.bisecter.PrepareBisect()
-	if err != nil {
-		return nil, err
+func (env *env) prepare() error {
+	if err := env.bisecter.PrepareBisect(); err != nil {
+		return err
 	}
-
-	cfg := env.cfg
 	if err := env.inst.CleanKernel(&env.buildCfg); err != nil {
-		return nil, fmt.Errorf("kernel clean failed: %w", err)
+		return fmt.Errorf("kernel clean failed: %w", err)
+	}
+	env.logf("building syzkaller on %v", env.cfg.Syzkaller.Commit)
+	if _, err := env.inst.BuildSyzkaller(env.cfg.Syzkaller.Repo, env.cfg.Syzkaller.Commit); err != nil {
+		return err
 	}
-	env.logf("building syzkaller on %v", cfg.Syzkaller.Commit)
-	if _, err := env.inst.BuildSyzkaller(cfg.Syzkaller.Repo, cfg.Syzkaller.Commit); err != nil {
+	return nil
+}
+
+func (env *env) bisect() (*Result, error) {
+	err := env.prepare()
+	if err != nil {
 		return nil, err
 	}
 
+	cfg := env.cfg
 	cfg.Kernel.Commit, err = env.identifyRewrittenCommit()
 	if err != nil {
 		return nil, err
@@ -335,10 +386,134 @@ func (env *env) bisect() (*Result, error) {
 			env.logf("failed to detect noop change: %v", err)
 		}
 		res.NoopChange = noopChange
+		if err := env.checkMonotonicity(res, com); err != nil {
+			env.logf("failed to check monotonicity: %v", err)
+		}
 	}
+	res.Flaky = env.flaky
+	res.Titles = env.crashTitles()
 	return res, nil
 }
 
+// checkMonotonicity looks for test results that contradict the culprit commit.
+// For cause bisection the revisions that contain the culprit must crash and the others must not,
+// for fix bisection the revisions that contain the original commit must crash until the culprit.
+// For cause bisection we additionally test one release older than the first good one:
+// bisection can't notice that the bug appeared, disappeared and then reappeared otherwise.
+// The confidence is scaled by the share of the results that agree with the culprit.
+func (env *env) checkMonotonicity(res *Result, culprit *vcs.Commit) error {
+	if !env.cfg.Fix && len(env.olderReleases) != 0 {
+		testRes, err := env.testRelease(env.olderReleases[0])
+		if err != nil {
+			return err
+		}
+		env.results[testRes.com.Hash] = testRes
+	}
+	contradicting, total, err := env.contradictingResults(culprit)
+	if err != nil {
+		return err
+	}
+	if len(contradicting) == 0 {
+		return nil
+	}
+	for _, testRes := range contradicting {
+		env.logf("%v %v: crashed=%v contradicts the result",
+			testRes.com.Hash, testRes.com.Title, testRes.badRatio > 0)
+	}
+	res.NonMonotonic = true
+	res.Confidence *= float64(total-len(contradicting)) / float64(total)
+	env.logf("%v out of %v results contradict the result, there may be multiple culprits",
+		len(contradicting), total)
+	return nil
+}
+
+// contradictingResults returns the test results that disagree with the culprit
+// and the total number of the results that can be checked.
+func (env *env) contradictingResults(culprit *vcs.Commit) ([]*testResult, int, error) {
+	start, err := env.repo.Commit(env.cfg.Kernel.Commit)
+	if err != nil {
+		return nil, 0, err
+	}
+	var contradicting []*testResult
+	total := 0
+	for _, testRes := range env.results {
+		if testRes.com == nil || testRes.verdict == vcs.BisectSkip {
+			continue
+		}
+		hasCulprit, err := env.isAncestor(culprit.Hash, testRes.com.Hash)
+		if err != nil {
+			return nil, 0, err
+		}
+		shouldCrash := hasCulprit
+		if env.cfg.Fix {
+			hasStart, err := env.isAncestor(start.Hash, testRes.com.Hash)
+			if err != nil {
+				return nil, 0, err
+			}
+			if !hasStart {
+				continue
+			}
+			shouldCrash = !hasCulprit
+		}
+		total++
+		if crashed := testRes.badRatio > 0; crashed != shouldCrash {
+			contradicting = append(contradicting, testRes)
+		}
+	}
+	return contradicting, total, nil
+}
+
+// testRelease tests the release tag and then restores the current checkout.
+func (env *env) testRelease(tag string) (res *testResult, err error) {
+	head, err := env.repo.Commit(vcs.HEAD)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if _, restoreErr := env.repo.SwitchCommit(head.Hash); restoreErr != nil && err == nil {
+			res, err = nil, fmt.Errorf("failed to restore checkout of %v: %w", head.Hash, restoreErr)
+		}
+	}()
+	env.logf("testing release %v to check if the bug existed before", tag)
+	if _, err := env.repo.SwitchCommit(tag); err != nil {
+		return nil, err
+	}
+	return env.test()
+}
+
+// isAncestor returns whether ancestor is reachable from the commit.
+func (env *env) isAncestor(ancestor, commit string) (bool, error) {
+	if ancestor == commit {
+		return true, nil
+	}
+	bases, err := env.repo.MergeBases(ancestor, commit)
+	if err != nil {
+		return false, err
+	}
+	for _, base := range bases {
+		if base.Hash == ancestor {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// crashTitles returns the distinct crash titles of all tested revisions.
+func (env *env) crashTitles() []string {
+	titles := make(map[string]bool)
+	for _, testRes := range env.results {
+		if testRes.verdict != vcs.BisectSkip && testRes.badRatio > 0 && testRes.rep != nil {
+			titles[testRes.rep.Title] = true
+		}
+	}
+	var ret []string
+	for title := range titles {
+		ret = append(ret, title)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
 func (env *env) identifyRewrittenCommit() (string, error) {
 	cfg := env.cfg
 	if cfg.Kernel.Commit != "" && cfg.CrossTree {
@@ -532,7 +707,7 @@ func (env *env) commitRangeForCause() (*vcs.Commit, *vcs.Commit, []*testResult,
 
 	lastBad := env.commit
 	var results []*testResult
-	for _, tag := range pickedTags {
+	for i, tag := range pickedTags {
 		env.logf("testing release %v", tag)
 		com, err := env.repo.SwitchCommit(tag)
 		if err != nil {
@@ -544,6 +719,7 @@ func (env *env) commitRangeForCause() (*vcs.Commit, *vcs.Commit, []*testResult,
 		}
 		results = append(results, res)
 		if res.verdict == vcs.BisectGood {
+			env.olderReleases = pickedTags[i+1:]
 			return lastBad, com, results, nil
 		}
 		if res.verdict == vcs.BisectBad {
@@ -607,15 +783,19 @@ func (env *env) build() (*vcs.Commit, string, error) {
 		return nil, "", err
 	}
 
-	bisectEnv, err := env.bisecter.EnvForCommit(
-		env.cfg.DefaultCompiler, env.cfg.CompilerType,
-		env.cfg.BinDir, current.Hash, env.kernelConfig,
-		env.cfg.Kernel.Backports,
-	)
-	if err != nil {
-		return current, "", err
+	bisectEnv := env.bisectEnv
+	env.bisectEnv = nil
+	if bisectEnv == nil {
+		bisectEnv, err = env.bisecter.EnvForCommit(
+			env.cfg.DefaultCompiler, env.cfg.CompilerType,
+			env.cfg.BinDir, current.Hash, env.kernelConfig,
+			env.cfg.Kernel.Backports,
+		)
+		if err != nil {
+			return current, "", err
+		}
 	}
-	env.logf("testing commit %v %v", current.Hash, env.cfg.CompilerType)
+	env.logf("testing commit %v %v", env.revision(current), env.cfg.CompilerType)
 	buildStart := time.Now()
 	buildCfg := env.buildCfg
 	buildCfg.CompilerBin = bisectEnv.Compiler
@@ -654,15 +834,15 @@ func (env *env) test() (*testResult, error) {
 		return res, fmt.Errorf("couldn't get repo HEAD: %w", err)
 	}
 	if err != nil {
-		errInfo := fmt.Sprintf("failed building %v: ", current.Hash)
+		errInfo := fmt.Sprintf("failed building %v: ", env.revision(current))
 		var verr *osutil.VerboseError
 		var kerr *build.KernelError
 		if errors.As(err, &verr) {
 			errInfo += verr.Title
-			env.saveDebugFile(current.Hash, 0, verr.Output)
+			env.saveDebugFile(env.revision(current), 0, verr.Output)
 		} else if errors.As(err, &kerr) {
 			errInfo += string(kerr.Report)
-			env.saveDebugFile(current.Hash, 0, kerr.Output)
+			env.saveDebugFile(env.revision(current), 0, kerr.Output)
 		} else {
 			errInfo += err.Error()
 			env.logf("%v", err)
@@ -705,7 +885,7 @@ func (env *env) test() (*testResult, error) {
 	}
 	if res.verdict == vcs.BisectSkip {
 		res.rep = &report.Report{
-			Title: fmt.Sprintf("failed testing reproducer on %v", current.Hash),
+			Title: fmt.Sprintf("failed testing reproducer on %v", env.revision(current)),
 		}
 	} else {
 		// Pick the most relevant as the main one.
@@ -881,13 +1061,13 @@ func (env *env) processResults(current *vcs.Commit, results []instance.EnvTestRe
 			if testError.Report != nil {
 				output = testError.Report.Output
 			}
-			env.saveDebugFile(current.Hash, i, output)
+			env.saveDebugFile(env.revision(current), i, output)
 		case errors.As(res.Error, &crashError):
 			output := crashError.Report.Report
 			if len(output) == 0 {
 				output = crashError.Report.Output
 			}
-			env.saveDebugFile(current.Hash, i, output)
+			env.saveDebugFile(env.revision(current), i, output)
 			if env.isTransientError(crashError.Report) {
 				verdicts = append(verdicts, fmt.Sprintf("ignore: %v", crashError))
 				break
@@ -1033,6 +1213,14 @@ func (env *env) isTransientError(rep *report.Report) bool {
 	return false
 }
 
+// revision returns the name of the tested kernel revision for logs and debug files.
+func (env *env) revision(com *vcs.Commit) string {
+	if env.label != "" {
+		return env.label
+	}
+	return com.Hash
+}
+
 func (env *env) saveDebugFile(hash string, idx int, data []byte) {
 	env.cfg.Trace.SaveFile(fmt.Sprintf("%v.%v", hash, idx), data)
 }
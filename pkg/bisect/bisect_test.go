@@ -29,6 +29,8 @@ type testEnv struct {
 	// Kernel config used in "build"
 	config string
 	test   BisectionTest
+	// Commits that were tested.
+	tested map[int]bool
 }
 
 func (env *testEnv) BuildSyzkaller(repo, commit string) (string, error) {
@@ -56,6 +58,7 @@ func (env *testEnv) BuildKernel(buildCfg *instance.BuildKernelConfig) (string, b
 
 func (env *testEnv) Test(numVMs int, reproSyz, reproOpts, reproC []byte) ([]instance.EnvTestResult, error) {
 	commit := env.headCommit()
+	env.tested[commit] = true
 	if commit >= env.test.brokenStart && commit <= env.test.brokenEnd ||
 		env.config == "baseline-skip" {
 		var ret []instance.EnvTestResult
@@ -106,8 +109,9 @@ func (env *testEnv) Test(numVMs int, reproSyz, reproOpts, reproC []byte) ([]inst
 		introduced = commit != nil
 	}
 
+	earlierBug := commit >= env.test.earlierBugStart && commit <= env.test.earlierBugEnd
 	if (env.config == "baseline-repro" || env.config == "new-minimized-config" || env.config == "original config") &&
-		introduced && !fixed {
+		(introduced && !fixed || earlierBug) {
 		if env.test.flaky {
 			crashed := max(2, numVMs/6)
 			ret = crashErrors(crashed, numVMs-crashed, "crash occurs", env.test.reportType)
@@ -237,9 +241,10 @@ func testBisection(t *testing.T, baseDir string, test BisectionTest) {
 		CrossTree: test.crossTree,
 	}
 	inst := &testEnv{
-		t:    t,
-		r:    r,
-		test: test,
+		t:      t,
+		r:      r,
+		test:   test,
+		tested: make(map[int]bool),
 	}
 
 	checkBisectionError := func(test BisectionTest, res *Result, err error) {
@@ -259,6 +264,9 @@ func testBisection(t *testing.T, baseDir string, test BisectionTest) {
 		if test.extraTest != nil {
 			test.extraTest(t, res)
 		}
+		for _, commit := range test.tested {
+			assert.True(t, inst.tested[commit], "commit %v was not tested", commit)
+		}
 	}
 
 	res, err := runImpl(cfg, r, inst)
@@ -271,6 +279,55 @@ func testBisection(t *testing.T, baseDir string, test BisectionTest) {
 	}
 }
 
+func TestTestReleaseRestoresCheckout(t *testing.T) {
+	baseDir := createTestRepo(t)
+	r, err := vcs.NewRepo(targets.TestOS, targets.TestArch64, baseDir, vcs.OptPrecious)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := r.SwitchCommit("master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{
+		Trace: &debugtracer.TestTracer{T: t},
+		Manager: &mgrconfig.Config{
+			Derived: mgrconfig.Derived{
+				TargetOS:     targets.TestOS,
+				TargetVMArch: targets.TestArch64,
+			},
+			Type:      "qemu",
+			KernelSrc: baseDir,
+		},
+		Kernel: KernelConfig{
+			Repo:   baseDir,
+			Branch: "master",
+			Commit: head.Hash,
+			Config: []byte("original config"),
+		},
+	}
+	inst := &testEnv{
+		t:      t,
+		r:      r,
+		tested: make(map[int]bool),
+	}
+	env, err := newEnv(cfg, r, inst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := env.testRelease("v5.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "500", res.com.Title)
+	assert.True(t, inst.tested[500])
+	cur, err := r.Commit(vcs.HEAD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, head.Hash, cur.Hash)
+}
+
 func checkBisectionResult(t *testing.T, test BisectionTest, res *Result) {
 	if len(res.Commits) != test.commitLen {
 		t.Fatalf("expected %d commits got %d commits", test.commitLen, len(res.Commits))
@@ -338,6 +395,9 @@ type BisectionTest struct {
 	// The commit fixing the bug.
 	// If empty, the bug is never fixed.
 	fixCommit string
+	// Range of commits that crash regardless of introduced/fixCommit.
+	earlierBugStart int
+	earlierBugEnd   int
 
 	baselineConfig  string
 	resultingConfig string
@@ -345,6 +405,8 @@ type BisectionTest struct {
 	noFakeHashTest  bool
 
 	extraTest func(t *testing.T, res *Result)
+	// Commits that must be tested during bisection.
+	tested []int
 }
 
 var bisectionTests = []BisectionTest{
@@ -357,7 +419,26 @@ var bisectionTests = []BisectionTest{
 		introduced:  "602",
 		extraTest: func(t *testing.T, res *Result) {
 			assert.Greater(t, res.Confidence, 0.99)
+			assert.False(t, res.NonMonotonic)
+			assert.False(t, res.Flaky)
+			assert.Equal(t, []string{"crashes at crash occurs"}, res.Titles)
+		},
+	},
+	{
+		name:        "cause-finds-cause-reappeared",
+		startCommit: 905,
+		commitLen:   1,
+		expectRep:   true,
+		introduced:  "702",
+		// The bug also existed in 600, but was fixed by 700 and reintroduced by 702.
+		earlierBugStart: 500,
+		earlierBugEnd:   699,
+		extraTest: func(t *testing.T, res *Result) {
+			assert.True(t, res.NonMonotonic)
+			assert.Less(t, res.Confidence, 0.99)
 		},
+		// The release older than the first good one (v7.0) is tested even though nothing is ambiguous.
+		tested: []int{500},
 	},
 	{
 		name:        "cause-finds-cause-flaky",
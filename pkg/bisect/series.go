@@ -0,0 +1,277 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package bisect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/pkg/bisect/minimize"
+	"github.com/google/syzkaller/pkg/hash"
+	"github.com/google/syzkaller/pkg/instance"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/pkg/vcs"
+)
+
+// Series bisection finds the minimal subset of a set of candidate commits
+// (e.g. a stable backport queue or a patch series under review) that introduces
+// or fixes the bug when applied on top of the base commit (Config.Kernel.Commit).
+// For cause bisection the base commit must not crash and the base commit with all
+// the series applied must crash, for fix bisection it's the other way around.
+// The subset is found with delta debugging (see pkg/bisect/minimize).
+
+// SeriesResult describes series bisection result.
+type SeriesResult struct {
+	// Commits is the minimal subset of the series that introduces/fixes the bug.
+	Commits []*vcs.Commit
+	// Report is the crash on the base commit with Commits applied for cause bisection,
+	// and the crash on the base commit for fix bisection.
+	Report     *report.Report
+	Config     []byte
+	Confidence float64
+	// ApplyFailures lists the tested subsets of the series that did not apply on top of the base commit
+	// (e.g. because they lack commits they depend on). Such subsets are considered not to introduce/fix the bug.
+	ApplyFailures []string
+}
+
+// SeriesApplyError is returned if the series does not apply on top of the base commit.
+type SeriesApplyError struct {
+	Commits string // see seriesKey
+	Err     error
+}
+
+func (err *SeriesApplyError) Error() string {
+	return fmt.Sprintf("failed to apply series commits %v: %v", err.Commits, err.Err)
+}
+
+func (err *SeriesApplyError) Unwrap() error {
+	return err.Err
+}
+
+// RunSeries does the series bisection and returns either the SeriesResult,
+// or, if the crash does not depend on the series, an error.
+func RunSeries(cfg *Config) (*SeriesResult, error) {
+	repo, inst, err := setup(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return runSeriesImpl(cfg, repo, inst)
+}
+
+type seriesEnv struct {
+	*env
+	picker        vcs.CherryPicker
+	base          *vcs.Commit
+	results       map[string]*testResult
+	applyErrors   map[string]error
+	applyFailures []string
+}
+
+func runSeriesImpl(cfg *Config, repo vcs.Repo, inst instance.Env) (*SeriesResult, error) {
+	if len(cfg.Kernel.Series) == 0 {
+		return nil, fmt.Errorf("no series commits")
+	}
+	picker, ok := repo.(vcs.CherryPicker)
+	if !ok {
+		return nil, fmt.Errorf("cherry-picking is not implemented for %v", cfg.Manager.TargetOS)
+	}
+	env, err := newEnv(cfg, repo, inst)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Commit(vcs.HEAD)
+	if err != nil {
+		return nil, err
+	}
+	defer env.repo.SwitchCommit(head.Hash)
+	env.head = head
+	what, done := "introducing", "introduced"
+	if cfg.Fix {
+		what, done = "fixing", "fixed"
+	}
+	env.logf("bisecting %v commits in a series of %v commits on top of %v",
+		what, len(cfg.Kernel.Series), cfg.Kernel.Commit)
+	senv := &seriesEnv{
+		env:         env,
+		picker:      picker,
+		results:     make(map[string]*testResult),
+		applyErrors: make(map[string]error),
+	}
+	res, err := senv.bisect()
+	env.logf("revisions tested: %v, total time: %v (build: %v, test: %v)",
+		env.numTests, time.Since(env.startTime), env.buildTime, env.testTime)
+	if err != nil {
+		env.logf("error: %v", err)
+		return nil, err
+	}
+	for _, subset := range res.ApplyFailures {
+		env.logf("series commits %v did not apply", subset)
+	}
+	env.logf("the bug is %v by %v commit(s) of the series:", done, len(res.Commits))
+	for _, com := range res.Commits {
+		env.logf("%v %v", com.Hash, com.Title)
+	}
+	if res.Report != nil {
+		env.logf("crash: %v\n%s", res.Report.Title, res.Report.Report)
+	}
+	return res, nil
+}
+
+func (env *seriesEnv) bisect() (*SeriesResult, error) {
+	if err := env.prepare(); err != nil {
+		return nil, err
+	}
+	cfg := env.cfg
+	var series []*vcs.Commit
+	for _, hash := range cfg.Kernel.Series {
+		com, err := env.repo.Commit(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query series commit %v: %w", hash, err)
+		}
+		series = append(series, com)
+	}
+	base, err := env.repo.SwitchCommit(cfg.Kernel.Commit)
+	if err != nil {
+		return nil, err
+	}
+	env.base = base
+	env.commit = base
+	env.kernelConfig = cfg.Kernel.Config
+
+	env.logf("ensuring the crash depends on the series")
+	without, err := env.test(nil)
+	if err != nil {
+		return nil, err
+	}
+	with, err := env.test(series)
+	if err != nil {
+		return nil, err
+	}
+	bad, good := with, without
+	if cfg.Fix {
+		bad, good = without, with
+	}
+	if bad.verdict != vcs.BisectBad {
+		return nil, fmt.Errorf("the crash wasn't reproduced %v the series", seriesPresence(!cfg.Fix))
+	}
+	if good.verdict != vcs.BisectGood {
+		return nil, fmt.Errorf("the crash is reproduced %v the series", seriesPresence(cfg.Fix))
+	}
+	env.reportTypes = bad.types
+	env.reproChance = bad.badRatio
+
+	commits, err := minimize.Slice(minimize.Config[*vcs.Commit]{
+		Pred: func(commits []*vcs.Commit) (bool, error) {
+			res, err := env.test(commits)
+			var applyErr *SeriesApplyError
+			if errors.As(err, &applyErr) {
+				// The subset can't be tested on its own, so it can't be the answer.
+				env.applyFailures = append(env.applyFailures, applyErr.Commits)
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			env.postTestResult(res)
+			if cfg.Fix {
+				return res.verdict == vcs.BisectGood, nil
+			}
+			return res.verdict == vcs.BisectBad, nil
+		},
+		Logf: env.logf,
+	}, series)
+	if err != nil {
+		return nil, err
+	}
+	env.logf("accumulated error probability: %0.2f", 1.0-env.confidence)
+	res := &SeriesResult{
+		Commits:       commits,
+		Report:        bad.rep,
+		Config:        env.kernelConfig,
+		Confidence:    env.confidence,
+		ApplyFailures: env.applyFailures,
+	}
+	if !cfg.Fix {
+		if testRes := env.results[seriesKey(commits)]; testRes != nil {
+			res.Report = testRes.rep
+		}
+	}
+	return res, nil
+}
+
+// test applies the commits on top of the base commit and tests the resulting kernel.
+// If the commits don't apply cleanly, SeriesApplyError is returned.
+func (env *seriesEnv) test(commits []*vcs.Commit) (*testResult, error) {
+	key := seriesKey(commits)
+	if res := env.results[key]; res != nil {
+		return res, nil
+	}
+	if err := env.applyErrors[key]; err != nil {
+		return nil, err
+	}
+	if _, err := env.repo.SwitchCommit(env.base.Hash); err != nil {
+		return nil, err
+	}
+	// Fix backports are applied before the series, so that the series commits are applied
+	// to the same tree for all subsets and the tested kernel contains exactly the subset on top.
+	bisectEnv, err := env.bisecter.EnvForCommit(
+		env.cfg.DefaultCompiler, env.cfg.CompilerType,
+		env.cfg.BinDir, env.base.Hash, env.kernelConfig,
+		env.cfg.Kernel.Backports,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare %v: %w", env.base.Hash, err)
+	}
+	var hashes []string
+	for _, com := range commits {
+		hashes = append(hashes, com.Hash)
+	}
+	env.logf("testing %v with %v series commit(s) applied: %v", env.base.Hash, len(commits), key)
+	if err := env.picker.CherryPick(hashes...); err != nil {
+		env.logf("failed to apply the commits: %v", err)
+		applyErr := &SeriesApplyError{Commits: key, Err: err}
+		env.applyErrors[key] = applyErr
+		return nil, applyErr
+	}
+	env.bisectEnv = bisectEnv
+	env.label = seriesLabel(env.base, commits)
+	res, err := env.env.test()
+	env.bisectEnv, env.label = nil, ""
+	if err != nil {
+		return nil, err
+	}
+	env.results[key] = res
+	return res, nil
+}
+
+func seriesKey(commits []*vcs.Commit) string {
+	var hashes []string
+	for _, com := range commits {
+		hashes = append(hashes, com.Hash[:min(len(com.Hash), 12)])
+	}
+	return "[" + strings.Join(hashes, " ") + "]"
+}
+
+// seriesLabel names the kernel revision with the commits applied on top of the base commit.
+func seriesLabel(base *vcs.Commit, commits []*vcs.Commit) string {
+	const maxCommits = 8
+	label := base.Hash
+	if len(commits) > maxCommits {
+		// Keep debug file names reasonably short.
+		return fmt.Sprintf("%v+%v-commits-%v", label, len(commits), hash.String([]byte(seriesKey(commits)))[:12])
+	}
+	for _, com := range commits {
+		label += "+" + com.Hash[:min(len(com.Hash), 12)]
+	}
+	return label
+}
+
+func seriesPresence(with bool) string {
+	if with {
+		return "with"
+	}
+	return "without"
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package bisect
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/build"
+	"github.com/google/syzkaller/pkg/debugtracer"
+	"github.com/google/syzkaller/pkg/instance"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/vcs"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
+)
+
+// seriesTestEnv crashes depending on the files present in the kernel checkout.
+type seriesTestEnv struct {
+	dir     string
+	crashes func(present func(string) bool) bool
+}
+
+func (env *seriesTestEnv) BuildSyzkaller(repo, commit string) (string, error) {
+	return "", nil
+}
+
+func (env *seriesTestEnv) CleanKernel(buildCfg *instance.BuildKernelConfig) error {
+	return nil
+}
+
+func (env *seriesTestEnv) BuildKernel(buildCfg *instance.BuildKernelConfig) (string, build.ImageDetails, error) {
+	return "", build.ImageDetails{}, nil
+}
+
+func (env *seriesTestEnv) Test(numVMs int, reproSyz, reproOpts, reproC []byte) ([]instance.EnvTestResult, error) {
+	present := func(file string) bool {
+		return osutil.IsExist(filepath.Join(env.dir, file))
+	}
+	if env.crashes(present) {
+		return crashErrors(numVMs, 0, "crash occurs", ""), nil
+	}
+	return make([]instance.EnvTestResult, numVMs), nil
+}
+
+func TestSeriesBisection(t *testing.T) {
+	dir := t.TempDir()
+	repo := vcs.MakeTestRepo(t, dir)
+	repo.Git("checkout", "-b", "master")
+	repo.Git("commit", "--allow-empty", "-m", "base")
+	repo.Git("tag", "base")
+	repo.Git("checkout", "-b", "series")
+	var series []string
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		if err := osutil.WriteFile(filepath.Join(dir, name), []byte(name)); err != nil {
+			t.Fatal(err)
+		}
+		repo.Git("add", name)
+		series = append(series, repo.CommitChange(name).Hash)
+	}
+	// Commit g modifies file a, so it does not apply without commit a.
+	for _, name := range []string{"a", "g"} {
+		if err := osutil.WriteFile(filepath.Join(dir, name), []byte("g")); err != nil {
+			t.Fatal(err)
+		}
+		repo.Git("add", name)
+	}
+	series = append(series, repo.CommitChange("g").Hash)
+	repo.Git("checkout", "master")
+
+	tests := []struct {
+		name    string
+		fix     bool
+		crashes func(present func(string) bool) bool
+		want    []string
+		// Whether the minimal subset is expected to be found via subsets that fail to apply.
+		applyFailures bool
+		wantErr       bool
+	}{
+		{
+			name: "cause",
+			crashes: func(present func(string) bool) bool {
+				return present("b") && present("e")
+			},
+			want: []string{"b", "e"},
+		},
+		{
+			name: "fix",
+			fix:  true,
+			crashes: func(present func(string) bool) bool {
+				return !present("c")
+			},
+			want: []string{"c"},
+		},
+		{
+			name: "dependent",
+			crashes: func(present func(string) bool) bool {
+				return present("g")
+			},
+			want:          []string{"a", "g"},
+			applyFailures: true,
+		},
+		{
+			name: "independent",
+			crashes: func(present func(string) bool) bool {
+				return true
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := vcs.NewRepo(targets.TestOS, targets.TestArch64, dir, vcs.OptDontSandbox)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg := &Config{
+				Fix:   test.fix,
+				Trace: &debugtracer.TestTracer{T: t},
+				Manager: &mgrconfig.Config{
+					Derived: mgrconfig.Derived{
+						TargetOS:     targets.TestOS,
+						TargetVMArch: targets.TestArch64,
+					},
+					Type:      "qemu",
+					KernelSrc: dir,
+				},
+				Kernel: KernelConfig{
+					Repo:   dir,
+					Branch: "master",
+					Commit: "base",
+					Config: []byte("original config"),
+					Series: series,
+				},
+			}
+			res, err := runSeriesImpl(cfg, r, &seriesTestEnv{dir: dir, crashes: test.crashes})
+			if test.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, com := range res.Commits {
+				got = append(got, com.Title)
+			}
+			assert.Equal(t, test.want, got)
+			assert.NotNil(t, res.Report)
+			if test.applyFailures {
+				assert.NotEmpty(t, res.ApplyFailures)
+			}
+		})
+	}
+}
@@ -37,6 +37,7 @@ type Corpus struct {
 type focusAreaState struct {
 	FocusArea
 	*ProgramsList
+	auto bool
 }
 
 type FocusArea struct {
@@ -190,30 +191,86 @@ func (corpus *Corpus) Save(inp NewInput) {
 
 func (corpus *Corpus) applyFocusAreas(item *Item, coverDelta []uint64) {
 	for _, area := range corpus.focusAreas {
-		matches := false
-		for _, pc := range coverDelta {
-			if _, ok := area.CoverPCs[pc]; ok {
-				matches = true
-				break
-			}
-		}
-		if !matches {
+		if !coverIntersects(coverDelta, area.CoverPCs) {
 			continue
 		}
 		area.saveProgram(item.Prog, item.Signal)
 		if item.areas == nil {
 			item.areas = make(map[*focusAreaState]struct{})
+		}
+		item.areas[area] = struct{}{}
+	}
+}
+
+// SetAutoFocusAreas replaces the focus areas that were previously set by SetAutoFocusAreas.
+// Unlike the focus areas passed to NewFocusedCorpus, these can change over time,
+// so the existing corpus programs are assigned to the new areas based on their coverage.
+// If there are no other focus areas, the rest of the corpus is treated as an area with weight 1.
+func (corpus *Corpus) SetAutoFocusAreas(areas []FocusArea) {
+	corpus.mu.Lock()
+	defer corpus.mu.Unlock()
+	var old []*focusAreaState
+	var keep []*focusAreaState
+	for _, area := range corpus.focusAreas {
+		if area.auto {
+			old = append(old, area)
+		} else {
+			keep = append(keep, area)
+		}
+	}
+	corpus.focusAreas = keep
+	var added []*focusAreaState
+	for _, area := range areas {
+		state := &focusAreaState{
+			FocusArea:    area,
+			ProgramsList: &ProgramsList{},
+			auto:         true,
+		}
+		corpus.focusAreas = append(corpus.focusAreas, state)
+		added = append(added, state)
+	}
+	if len(old) == 0 && len(added) == 0 {
+		return
+	}
+	for _, item := range corpus.progsMap {
+		for _, area := range old {
+			delete(item.areas, area)
+		}
+		for _, area := range added {
+			if !coverIntersects(item.Cover, area.CoverPCs) {
+				continue
+			}
+			area.saveProgram(item.Prog, item.Signal)
+			if item.areas == nil {
+				item.areas = make(map[*focusAreaState]struct{})
+			}
 			item.areas[area] = struct{}{}
 		}
 	}
 }
 
+func coverIntersects(cover []uint64, pcs map[uint64]struct{}) bool {
+	for _, pc := range cover {
+		if _, ok := pcs[pc]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (corpus *Corpus) Signal() signal.Signal {
 	corpus.mu.RLock()
 	defer corpus.mu.RUnlock()
 	return corpus.signal.Copy()
 }
 
+// Cover returns the total coverage of all corpus programs.
+func (corpus *Corpus) Cover() []uint64 {
+	corpus.mu.RLock()
+	defer corpus.mu.RUnlock()
+	return corpus.cover.Serialize()
+}
+
 func (corpus *Corpus) Items() []*Item {
 	corpus.mu.RLock()
 	defer corpus.mu.RUnlock()
@@ -49,14 +49,22 @@ func (corpus *Corpus) ChooseProgram(r *rand.Rand) *prog.Prog {
 	var randArea *focusAreaState
 	if len(corpus.focusAreas) > 0 {
 		sum := 0.0
-		nonEmpty := make([]*focusAreaState, 0, len(corpus.focusAreas))
+		nonEmpty := make([]*focusAreaState, 0, len(corpus.focusAreas)+1)
+		onlyAuto := true
 		for _, area := range corpus.focusAreas {
+			onlyAuto = onlyAuto && area.auto
 			if len(area.progs) == 0 {
 				continue
 			}
 			sum += area.Weight
 			nonEmpty = append(nonEmpty, area)
 		}
+		if onlyAuto {
+			// Automatic focus areas must not starve the rest of the corpus.
+			rest := &focusAreaState{FocusArea: FocusArea{Weight: 1}, ProgramsList: corpus.ProgramsList}
+			sum += rest.Weight
+			nonEmpty = append(nonEmpty, rest)
+		}
 		val := r.Float64() * sum
 		currSum := 0.0
 		for _, area := range nonEmpty {
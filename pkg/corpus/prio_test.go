@@ -117,3 +117,45 @@ func TestFocusAreas(t *testing.T) {
 	assert.InDelta(t, secondCount, TOTAL*0.3, TOTAL/25)
 	assert.InDelta(t, thirdCount, TOTAL*0.6, TOTAL/25)
 }
+
+func TestAutoFocusAreas(t *testing.T) {
+	target := getTarget(t, targets.TestOS, targets.TestArch64)
+	corpus := NewCorpus(context.Background())
+	rs := rand.NewSource(0)
+	focused := map[*prog.Prog]bool{}
+	for i := 0; i < 20; i++ {
+		inp := generateRangedInput(target, rs, 0, 1)
+		if i%2 == 0 {
+			inp = generateRangedInput(target, rs, 4, 5)
+			focused[inp.Prog] = true
+		}
+		corpus.Save(inp)
+	}
+	count := func() int {
+		rnd := rand.New(rs)
+		ret := 0
+		for i := 0; i < 10000; i++ {
+			if focused[corpus.ChooseProgram(rnd)] {
+				ret++
+			}
+		}
+		return ret
+	}
+	// Existing programs are assigned to the new areas, the rest of the corpus gets weight 1.
+	corpus.SetAutoFocusAreas([]FocusArea{{
+		Name:     "auto",
+		CoverPCs: map[uint64]struct{}{4: {}},
+		Weight:   3,
+	}})
+	assert.Equal(t, map[string]int{"auto": 10}, corpus.ProgsPerArea())
+	assert.InDelta(t, 7500+2500/2, count(), 400)
+	// New programs are assigned to the area as well.
+	inp := generateRangedInput(target, rs, 4, 4)
+	focused[inp.Prog] = true
+	corpus.Save(inp)
+	assert.Equal(t, map[string]int{"auto": 11}, corpus.ProgsPerArea())
+	// Replacing the areas restores the normal distribution.
+	corpus.SetAutoFocusAreas(nil)
+	assert.Empty(t, corpus.ProgsPerArea())
+	assert.InDelta(t, 5000, count(), 500)
+}
@@ -33,6 +33,21 @@ type Symbol struct {
 	Start      uint64
 	End        uint64
 	Symbolized bool
+	// Callees are the functions called directly from this function.
+	// Only available for the core kernel on architectures that support readCoverPoints.
+	Callees []*Symbol
+	// IndirectCallees are the groups of functions that may be called indirectly from this function.
+	// Only available if directed fuzzing is enabled, see readIndirectCalls.
+	IndirectCallees []*FuncGroup
+	// callSites are the direct calls (used to attribute calls to basic blocks).
+	callSites []callSite
+}
+
+// FuncGroup is a set of functions of the same type that are potential targets of indirect calls.
+// Groups are shared between all symbols that may call them.
+type FuncGroup struct {
+	Type  string
+	Funcs []*Symbol
 }
 
 // ObjectUnit represents either CompileUnit or Symbol.
@@ -87,7 +102,9 @@ func Make(cfg *mgrconfig.Config, modules []*vminfo.KernelModule) (*Impl, error)
 		// details.
 		delimiters = []string{"/aosp/", "/private/"}
 	}
-	return makeELF(target, kernelDirs, delimiters, moduleObj, modules)
+	// The indirect call graph is only needed to compute target distances, and it's slow to build.
+	indirectCalls := len(cfg.Experimental.DirectedTargets) != 0
+	return makeELF(target, kernelDirs, delimiters, moduleObj, modules, indirectCalls)
 }
 
 func GetPCBase(cfg *mgrconfig.Config) (uint64, error) {
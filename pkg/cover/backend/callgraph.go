@@ -0,0 +1,395 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package backend
+
+import (
+	"debug/dwarf"
+	"fmt"
+	"sort"
+)
+
+// callSite is a direct call of callee at pc.
+type callSite struct {
+	pc     uint64
+	callee *Symbol
+}
+
+// indirectCalls describes potential targets of indirect calls in an object file.
+// There is no way to statically tell what an indirect call may call, so we approximate it by types:
+// a function that contains indirect calls may call any function which type matches one of
+// the function pointer types known in its compile unit.
+type indirectCalls struct {
+	// funcs maps function start PCs to the function types.
+	funcs map[uint64]string
+	// callers maps start PCs of functions that contain indirect calls to indexes in units.
+	callers map[uint64]int
+	// units contains function pointer types of the compile units.
+	units [][]string
+}
+
+// dwarfFunc is a function definition in DWARF.
+type dwarfFunc struct {
+	start    uint64
+	typed    bool
+	ret      dwarf.Offset
+	params   []dwarf.Offset
+	varargs  bool
+	indirect bool
+}
+
+// GCC emits DW_TAG_GNU_call_site instead of DW_TAG_call_site for DWARF < 5.
+const tagGNUCallSite = dwarf.Tag(0x4109)
+
+func readIndirectCalls(debugInfo *dwarf.Data, pcFix pcFixFn) (*indirectCalls, error) {
+	res := &indirectCalls{
+		funcs:   make(map[uint64]string),
+		callers: make(map[uint64]int),
+	}
+	for r := debugInfo.Reader(); ; {
+		ent, err := r.Next()
+		if err != nil {
+			return nil, err
+		}
+		if ent == nil {
+			break
+		}
+		if ent.Tag != dwarf.TagCompileUnit {
+			return nil, fmt.Errorf("found unexpected tag %v on top level", ent.Tag)
+		}
+		if !ent.Children {
+			continue
+		}
+		if err := readUnitIndirectCalls(debugInfo, r, pcFix, res); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func readUnitIndirectCalls(debugInfo *dwarf.Data, r *dwarf.Reader, pcFix pcFixFn, res *indirectCalls) error {
+	pointers := make(map[dwarf.Offset]dwarf.Offset)
+	typedefs := make(map[dwarf.Offset]dwarf.Offset)
+	subroutines := make(map[dwarf.Offset]bool)
+	var funcs []*dwarfFunc
+	var cur *dwarfFunc
+	for depth := 1; depth > 0; {
+		ent, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if ent == nil {
+			return fmt.Errorf("unexpected end of DWARF data")
+		}
+		if ent.Tag == 0 {
+			depth--
+			continue
+		}
+		if depth == 1 {
+			cur = nil
+			if ent.Tag == dwarf.TagSubprogram {
+				cur = newDwarfFunc(ent, pcFix)
+				if cur != nil {
+					funcs = append(funcs, cur)
+				}
+			}
+		}
+		typ, _ := ent.Val(dwarf.AttrType).(dwarf.Offset)
+		switch ent.Tag {
+		case dwarf.TagPointerType:
+			pointers[ent.Offset] = typ
+		case dwarf.TagTypedef:
+			typedefs[ent.Offset] = typ
+		case dwarf.TagSubroutineType:
+			subroutines[ent.Offset] = true
+		case dwarf.TagFormalParameter:
+			if cur != nil && depth == 2 {
+				cur.params = append(cur.params, typ)
+			}
+		case dwarf.TagUnspecifiedParameters:
+			if cur != nil && depth == 2 {
+				cur.varargs = true
+			}
+		case dwarf.TagCallSite, tagGNUCallSite:
+			if cur != nil && ent.Val(dwarf.AttrCallOrigin) == nil && ent.Val(dwarf.AttrAbstractOrigin) == nil {
+				cur.indirect = true
+			}
+		}
+		if ent.Children {
+			depth++
+		}
+	}
+	isFunc := func(off dwarf.Offset) bool {
+		// Function pointer types may also point to typedefs of function types.
+		for i := 0; i < 10; i++ {
+			if subroutines[off] {
+				return true
+			}
+			next, ok := typedefs[off]
+			if !ok {
+				return false
+			}
+			off = next
+		}
+		return false
+	}
+	unitTypes := make(map[string]bool)
+	for ptr, target := range pointers {
+		if !isFunc(target) {
+			continue
+		}
+		typ, err := debugInfo.Type(ptr)
+		if err != nil {
+			continue
+		}
+		ptrType, ok := typ.(*dwarf.PtrType)
+		if !ok {
+			continue
+		}
+		if fn, ok := unwrapFuncType(ptrType.Type); ok {
+			unitTypes[funcTypeString(fn.ReturnType, fn.ParamType)] = true
+		}
+	}
+	var types []string
+	for typ := range unitTypes {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+	unit := len(res.units)
+	res.units = append(res.units, types)
+	for _, fn := range funcs {
+		if fn.indirect && len(types) != 0 {
+			res.callers[fn.start] = unit
+		}
+		if !fn.typed {
+			continue
+		}
+		if typ, err := dwarfFuncType(debugInfo, fn); err == nil {
+			res.funcs[fn.start] = typ
+		}
+	}
+	return nil
+}
+
+func newDwarfFunc(ent *dwarf.Entry, pcFix pcFixFn) *dwarfFunc {
+	start, ok := ent.Val(dwarf.AttrLowpc).(uint64)
+	if !ok {
+		return nil // a declaration or an inline function
+	}
+	if pcFix != nil {
+		r, filtered := pcFix([2]uint64{start, start + 1})
+		if filtered {
+			return nil
+		}
+		start = r[0]
+	}
+	ret, _ := ent.Val(dwarf.AttrType).(dwarf.Offset)
+	return &dwarfFunc{
+		start: start,
+		// Out-of-line instances of inline functions refer to the abstract definition
+		// and don't have the types of their own.
+		typed: ent.Val(dwarf.AttrAbstractOrigin) == nil && ent.Val(dwarf.AttrSpecification) == nil,
+		ret:   ret,
+	}
+}
+
+func dwarfFuncType(debugInfo *dwarf.Data, fn *dwarfFunc) (string, error) {
+	var ret dwarf.Type = new(dwarf.VoidType)
+	if fn.ret != 0 {
+		var err error
+		if ret, err = debugInfo.Type(fn.ret); err != nil {
+			return "", err
+		}
+	}
+	var params []dwarf.Type
+	for _, off := range fn.params {
+		param, err := debugInfo.Type(off)
+		if err != nil {
+			return "", err
+		}
+		params = append(params, param)
+	}
+	if fn.varargs {
+		params = append(params, new(dwarf.DotDotDotType))
+	}
+	return funcTypeString(ret, params), nil
+}
+
+func unwrapFuncType(typ dwarf.Type) (*dwarf.FuncType, bool) {
+	for {
+		switch t := typ.(type) {
+		case *dwarf.FuncType:
+			return t, true
+		case *dwarf.TypedefType:
+			typ = t.Type
+		default:
+			return nil, false
+		}
+	}
+}
+
+// funcTypeString returns a string that is the same for function types that are compatible for indirect calls.
+// Qualifiers of the parameters are not part of the function type in C.
+func funcTypeString(ret dwarf.Type, params []dwarf.Type) string {
+	unqual := func(typ dwarf.Type) dwarf.Type {
+		for {
+			qual, ok := typ.(*dwarf.QualType)
+			if !ok {
+				return typ
+			}
+			typ = qual.Type
+		}
+	}
+	fn := &dwarf.FuncType{ReturnType: unqual(ret)}
+	for _, param := range params {
+		fn.ParamType = append(fn.ParamType, unqual(param))
+	}
+	return fn.String()
+}
+
+// buildIndirectCallGraph fills in Symbol.IndirectCallees.
+func buildIndirectCallGraph(symbols []*Symbol, calls *indirectCalls) {
+	groups := make(map[string]*FuncGroup)
+	for start, typ := range calls.funcs {
+		sym := findSymbol(symbols, start, true)
+		if sym == nil {
+			continue
+		}
+		group := groups[typ]
+		if group == nil {
+			group = &FuncGroup{Type: typ}
+			groups[typ] = group
+		}
+		group.Funcs = append(group.Funcs, sym)
+	}
+	for _, group := range groups {
+		sort.Slice(group.Funcs, func(i, j int) bool {
+			return group.Funcs[i].Start < group.Funcs[j].Start
+		})
+	}
+	unitGroups := make([][]*FuncGroup, len(calls.units))
+	for i, types := range calls.units {
+		for _, typ := range types {
+			if group := groups[typ]; group != nil {
+				unitGroups[i] = append(unitGroups[i], group)
+			}
+		}
+	}
+	for start, unit := range calls.callers {
+		if sym := findSymbol(symbols, start, true); sym != nil {
+			sym.IndirectCallees = unitGroups[unit]
+		}
+	}
+}
+
+// Distances are distances to the target functions in the static call graph.
+// They are used for directed fuzzing similar to AFLGo ("Directed Greybox Fuzzing", CCS'17).
+type Distances struct {
+	// Funcs contains the functions that can reach at least one of the targets.
+	// The distance is the harmonic mean of the number of calls needed to reach each of the reachable targets,
+	// so functions that reach more targets are closer. The targets themselves have distance 0.
+	Funcs map[*Symbol]float64
+	// PCs contains distances of coverage callback PCs of the functions in Funcs.
+	// We don't have CFG, so we approximate basic block distances: basic blocks that directly call
+	// functions closer to the targets get the distance of the callee + 1,
+	// and the rest get the distance of the function + 1.
+	PCs map[uint64]float64
+}
+
+// TargetDistances calculates distances to the functions with the target names.
+func TargetDistances(symbols []*Symbol, targets []string) (*Distances, error) {
+	byName := make(map[string][]*Symbol)
+	callers := make(map[*Symbol][]*Symbol)
+	groupCallers := make(map[*FuncGroup][]*Symbol)
+	groups := make(map[*Symbol][]*FuncGroup)
+	for _, sym := range symbols {
+		byName[sym.Name] = append(byName[sym.Name], sym)
+		for _, callee := range sym.Callees {
+			callers[callee] = append(callers[callee], sym)
+		}
+		for _, group := range sym.IndirectCallees {
+			if groupCallers[group] == nil {
+				for _, fn := range group.Funcs {
+					groups[fn] = append(groups[fn], group)
+				}
+			}
+			groupCallers[group] = append(groupCallers[group], sym)
+		}
+	}
+	isTarget := make(map[*Symbol]bool)
+	inverse := make(map[*Symbol]float64)
+	for _, name := range targets {
+		syms := byName[name]
+		if len(syms) == 0 {
+			return nil, fmt.Errorf("unknown target function %v", name)
+		}
+		// Functions with the same name (e.g. static functions in different files) are considered
+		// to be the same target. Do BFS backwards from the target.
+		dist := make(map[*Symbol]int)
+		visitedGroups := make(map[*FuncGroup]bool)
+		for _, sym := range syms {
+			isTarget[sym] = true
+			dist[sym] = 0
+		}
+		for queue := syms; len(queue) != 0; queue = queue[1:] {
+			sym := queue[0]
+			next := callers[sym]
+			for _, group := range groups[sym] {
+				if !visitedGroups[group] {
+					visitedGroups[group] = true
+					next = append(next[:len(next):len(next)], groupCallers[group]...)
+				}
+			}
+			for _, caller := range next {
+				if _, ok := dist[caller]; !ok {
+					dist[caller] = dist[sym] + 1
+					queue = append(queue, caller)
+				}
+			}
+		}
+		for sym, d := range dist {
+			if d != 0 {
+				inverse[sym] += 1 / float64(d)
+			}
+		}
+	}
+	res := &Distances{
+		Funcs: make(map[*Symbol]float64),
+		PCs:   make(map[uint64]float64),
+	}
+	for sym := range isTarget {
+		res.Funcs[sym] = 0
+	}
+	for sym, inv := range inverse {
+		if !isTarget[sym] {
+			res.Funcs[sym] = 1 / inv
+		}
+	}
+	for sym, dist := range res.Funcs {
+		if dist == 0 {
+			for _, pc := range sym.PCs {
+				res.PCs[pc] = 0
+			}
+			continue
+		}
+		blocks := make([]float64, len(sym.PCs))
+		for i := range blocks {
+			blocks[i] = dist + 1
+		}
+		for _, call := range sym.callSites {
+			calleeDist, ok := res.Funcs[call.callee]
+			// Coverage callbacks are at the beginning of basic blocks,
+			// so the call belongs to the block of the preceding callback.
+			idx := sort.Search(len(sym.PCs), func(i int) bool {
+				return sym.PCs[i] > call.pc
+			}) - 1
+			if ok && idx >= 0 {
+				blocks[idx] = min(blocks[idx], calleeDist+1)
+			}
+		}
+		for i, pc := range sym.PCs {
+			res.PCs[pc] = blocks[i]
+		}
+	}
+	return res, nil
+}
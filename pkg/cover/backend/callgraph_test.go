@@ -0,0 +1,127 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package backend
+
+import (
+	"debug/elf"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadIndirectCalls(t *testing.T) {
+	target := targets.Get(targets.Linux, runtime.GOARCH)
+	if runtime.GOOS != targets.Linux || target == nil || target.BrokenCompiler != "" {
+		t.Skip("no host compiler")
+	}
+	dir := t.TempDir()
+	src := filepath.Join(dir, "test.c")
+	bin := filepath.Join(dir, "test")
+	require.NoError(t, os.WriteFile(src, []byte(`
+struct ops {
+	int (*open)(int, const char*);
+	void (*close)(void*);
+};
+__attribute__((noinline)) int foo_open(int x, const char* s) { return x + s[0]; }
+__attribute__((noinline)) int bar_open(const int x, const char* s) { return x - s[1]; }
+__attribute__((noinline)) void foo_close(void* p) { *(volatile char*)p = 0; }
+struct ops ops1 = {foo_open, foo_close};
+struct ops ops2 = {bar_open, foo_close};
+__attribute__((noinline)) int dispatch(struct ops* o, int x) {
+	int r = o->open(x, "ab");
+	o->close(&x);
+	return r;
+}
+int main(int argc, char** argv) { return dispatch(argc ? &ops1 : &ops2, argc); }
+`), 0644))
+	if _, err := osutil.RunCmd(time.Minute, "", target.CCompiler,
+		"-g", "-O2", "-o", bin, src); err != nil {
+		t.Skipf("failed to compile: %v", err)
+	}
+	file, err := elf.Open(bin)
+	require.NoError(t, err)
+	defer file.Close()
+	syms, err := file.Symbols()
+	require.NoError(t, err)
+	funcs := make(map[string]uint64)
+	for _, sym := range syms {
+		if elf.ST_TYPE(sym.Info) == elf.STT_FUNC {
+			funcs[sym.Name] = sym.Value
+		}
+	}
+	debugInfo, err := file.DWARF()
+	require.NoError(t, err)
+	calls, err := readIndirectCalls(debugInfo, nil)
+	require.NoError(t, err)
+
+	openType := "func(int, *const char) int"
+	assert.Equal(t, openType, calls.funcs[funcs["foo_open"]])
+	assert.Equal(t, openType, calls.funcs[funcs["bar_open"]])
+	assert.Equal(t, "func(*void) void", calls.funcs[funcs["foo_close"]])
+	unit, ok := calls.callers[funcs["dispatch"]]
+	if !ok {
+		t.Skip("the compiler does not emit call sites")
+	}
+	assert.Contains(t, calls.units[unit], openType)
+	assert.NotContains(t, calls.callers, funcs["foo_open"])
+}
+
+func TestTargetDistances(t *testing.T) {
+	sym := func(name string, start uint64, pcs ...uint64) *Symbol {
+		return &Symbol{ObjectUnit: ObjectUnit{Name: name, PCs: pcs}, Start: start, End: start + 0x100}
+	}
+	entry := sym("entry", 0x100, 0x110, 0x120, 0x130)
+	dispatch := sym("dispatch", 0x200, 0x210)
+	handler := sym("handler", 0x300, 0x310, 0x320)
+	target := sym("target", 0x400, 0x410)
+	other := sym("other", 0x500, 0x510)
+	unrelated := sym("unrelated", 0x600, 0x610)
+	symbols := []*Symbol{entry, dispatch, handler, target, other, unrelated}
+	// entry calls dispatch from the second basic block, and other from the third one;
+	// dispatch calls handler indirectly; handler and other call target directly.
+	buildCallGraph(symbols, []callEdge{
+		{pc: 0x125, target: 0x200},
+		{pc: 0x135, target: 0x500},
+		{pc: 0x325, target: 0x400},
+		{pc: 0x515, target: 0x400},
+	})
+	handlers := &FuncGroup{Type: "func()", Funcs: []*Symbol{handler, unrelated}}
+	dispatch.IndirectCallees = []*FuncGroup{handlers}
+
+	dist, err := TargetDistances(symbols, []string{"target"})
+	require.NoError(t, err)
+	assert.Equal(t, map[*Symbol]float64{
+		target:   0,
+		handler:  1,
+		other:    1,
+		dispatch: 2,
+		entry:    2,
+	}, dist.Funcs)
+	assert.Equal(t, map[uint64]float64{
+		0x110: 3,
+		0x120: 3,
+		0x130: 2,
+		0x210: 3,
+		0x310: 2,
+		0x320: 1,
+		0x410: 0,
+		0x510: 1,
+	}, dist.PCs)
+
+	// Two targets: the harmonic mean of the distances.
+	dist, err = TargetDistances(symbols, []string{"target", "handler"})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, dist.Funcs[handler])
+	assert.InDelta(t, 1/(1.0/2+1.0/1), dist.Funcs[dispatch], 1e-9)
+
+	_, err = TargetDistances(symbols, []string{"nonexistent"})
+	assert.Error(t, err)
+}
@@ -37,6 +37,7 @@ type dwarfParams struct {
 	readModuleCoverPoints func(*targets.Target, *vminfo.KernelModule, *symbolInfo) ([2][]uint64, error)
 	readTextRanges        func(*vminfo.KernelModule) ([]pcRange, []*CompileUnit, error)
 	getCompilerVersion    func(string) string
+	readIndirectCalls     func(*vminfo.KernelModule) (*indirectCalls, error)
 }
 
 type Arch struct {
@@ -115,6 +116,14 @@ func makeDWARF(params *dwarfParams) (impl *Impl, err error) {
 type Result struct {
 	CoverPoints [2][]uint64
 	Symbols     []*Symbol
+	Calls       []callEdge
+	Indirect    *indirectCalls
+}
+
+// callEdge is a direct call instruction at pc that calls target.
+type callEdge struct {
+	pc     uint64
+	target uint64
 }
 
 func processModule(params *dwarfParams, module *vminfo.KernelModule, info *symbolInfo,
@@ -126,6 +135,8 @@ func processModule(params *dwarfParams, module *vminfo.KernelModule, info *symbo
 
 	var data []byte
 	var coverPoints [2][]uint64
+	var calls []callEdge
+	var indirect *indirectCalls
 	if _, ok := arches[target.Arch]; !ok {
 		coverPoints, err = objdump(target, module)
 	} else if module.Name == "" {
@@ -134,6 +145,13 @@ func processModule(params *dwarfParams, module *vminfo.KernelModule, info *symbo
 			return nil, err
 		}
 		coverPoints, err = readCoverPoints(target, info, data)
+		calls = readCalls(target, info, data)
+		if params.readIndirectCalls != nil {
+			indirect, err = params.readIndirectCalls(module)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read indirect calls: %w", err)
+			}
+		}
 	} else {
 		coverPoints, err = params.readModuleCoverPoints(target, module, info)
 	}
@@ -144,6 +162,8 @@ func processModule(params *dwarfParams, module *vminfo.KernelModule, info *symbo
 	result := &Result{
 		Symbols:     symbols,
 		CoverPoints: coverPoints,
+		Calls:       calls,
+		Indirect:    indirect,
 	}
 	return result, nil
 }
@@ -161,9 +181,13 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 	var allRanges []pcRange
 	var allUnits []*CompileUnit
 	preciseCoverage := true
+	var allCalls []callEdge
+	var allIndirect []*indirectCalls
 	type binResult struct {
 		symbols     []*Symbol
 		coverPoints [2][]uint64
+		calls       []callEdge
+		indirect    *indirectCalls
 		ranges      []pcRange
 		units       []*CompileUnit
 		err         error
@@ -192,7 +216,8 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 				binC <- binResult{err: err}
 				return
 			}
-			binC <- binResult{symbols: result.Symbols, coverPoints: result.CoverPoints, ranges: ranges, units: units}
+			binC <- binResult{symbols: result.Symbols, coverPoints: result.CoverPoints, calls: result.Calls,
+				indirect: result.Indirect, ranges: ranges, units: units}
 		}()
 		if isKcovBrokenInCompiler(params.getCompilerVersion(module.Path)) {
 			preciseCoverage = false
@@ -208,6 +233,10 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 		allCoverPoints[1] = append(allCoverPoints[1], result.coverPoints[1]...)
 		allRanges = append(allRanges, result.ranges...)
 		allUnits = append(allUnits, result.units...)
+		allCalls = append(allCalls, result.calls...)
+		if result.indirect != nil {
+			allIndirect = append(allIndirect, result.indirect)
+		}
 	}
 	log.Logf(1, "discovered %v source files, %v symbols", len(allUnits), len(allSymbols))
 	// TODO: need better way to remove symbols having the same Start
@@ -234,6 +263,10 @@ func makeDWARFUnsafe(params *dwarfParams) (*Impl, error) {
 	}
 
 	allSymbols = buildSymbols(allSymbols, allRanges, allCoverPoints)
+	buildCallGraph(allSymbols, allCalls)
+	for _, indirect := range allIndirect {
+		buildIndirectCallGraph(allSymbols, indirect)
+	}
 	nunit := 0
 	for _, unit := range allUnits {
 		if len(unit.PCs) == 0 {
@@ -321,6 +354,35 @@ func buildSymbols(symbols []*Symbol, ranges []pcRange, coverPoints [2][]uint64)
 	return symbols
 }
 
+// buildCallGraph fills in Symbol.Callees from the direct calls.
+// Calls of symbols that were dropped (e.g. non-instrumented functions) are ignored.
+func buildCallGraph(symbols []*Symbol, calls []callEdge) {
+	seen := make(map[[2]*Symbol]bool)
+	for _, call := range calls {
+		caller, callee := findSymbol(symbols, call.pc, false), findSymbol(symbols, call.target, true)
+		if caller == nil || callee == nil || caller == callee {
+			continue
+		}
+		caller.callSites = append(caller.callSites, callSite{call.pc, callee})
+		if seen[[2]*Symbol{caller, callee}] {
+			continue
+		}
+		seen[[2]*Symbol{caller, callee}] = true
+		caller.Callees = append(caller.Callees, callee)
+	}
+}
+
+// findSymbol returns the symbol that contains pc (or starts at pc, if exact is set) in the sorted symbols.
+func findSymbol(symbols []*Symbol, pc uint64, exact bool) *Symbol {
+	idx := sort.Search(len(symbols), func(i int) bool {
+		return pc < symbols[i].End
+	})
+	if idx == len(symbols) || pc < symbols[idx].Start || exact && pc != symbols[idx].Start {
+		return nil
+	}
+	return symbols[idx]
+}
+
 // Regexps to parse compiler version string in isKcovBrokenInCompiler.
 // Some targets (e.g. NetBSD) use g++ instead of gcc.
 var gccRE = regexp.MustCompile(`gcc|GCC|g\+\+`)
@@ -644,6 +706,22 @@ func readCoverPoints(target *targets.Target, info *symbolInfo, data []byte) ([2]
 	return pcs, nil
 }
 
+// readCalls finds all direct calls in the object file, except for calls of coverage callbacks.
+func readCalls(target *targets.Target, info *symbolInfo, data []byte) []callEdge {
+	var calls []callEdge
+	arch := arches[target.Arch]
+	for i := 0; ; {
+		callTarget, pc := nextCallTarget(arch, info.textAddr, data, &i)
+		if callTarget == 0 {
+			break
+		}
+		if !info.tracePC[callTarget] && !info.traceCmp[callTarget] {
+			calls = append(calls, callEdge{pc, callTarget})
+		}
+	}
+	return calls
+}
+
 // Source files for Android may be split between two subdirectories: the common AOSP kernel
 // and the device-specific drivers: https://source.android.com/docs/setup/build/building-pixel-kernels.
 // Android build system references these subdirectories in various ways, which often results in
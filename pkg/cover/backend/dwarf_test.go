@@ -5,6 +5,8 @@ package backend
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 // kcov is known to be broken in GCC versions < 14.
@@ -174,3 +176,29 @@ func TestNextCallTargetAMD64(t *testing.T) {
 		runNextCallTarget(t, test)
 	}
 }
+
+func TestBuildCallGraph(t *testing.T) {
+	foo := &Symbol{ObjectUnit: ObjectUnit{Name: "foo"}, Start: 0x100, End: 0x200}
+	bar := &Symbol{ObjectUnit: ObjectUnit{Name: "bar"}, Start: 0x200, End: 0x300}
+	baz := &Symbol{ObjectUnit: ObjectUnit{Name: "baz"}, Start: 0x300, End: 0x400}
+	buildCallGraph([]*Symbol{foo, bar, baz}, []callEdge{
+		{pc: 0x110, target: 0x200},
+		{pc: 0x120, target: 0x200}, // duplicate
+		{pc: 0x130, target: 0x300},
+		{pc: 0x140, target: 0x100}, // recursion
+		{pc: 0x150, target: 0x500}, // unknown callee
+		{pc: 0x210, target: 0x310}, // not a function start
+		{pc: 0x500, target: 0x300}, // unknown caller
+		{pc: 0x310, target: 0x200},
+	})
+	names := func(syms []*Symbol) []string {
+		var res []string
+		for _, sym := range syms {
+			res = append(res, sym.Name)
+		}
+		return res
+	}
+	assert.Equal(t, []string{"bar", "baz"}, names(foo.Callees))
+	assert.Empty(t, bar.Callees)
+	assert.Equal(t, []string{"bar"}, names(baz.Callees))
+}
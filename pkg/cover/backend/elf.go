@@ -18,8 +18,8 @@ import (
 )
 
 func makeELF(target *targets.Target, kernelDirs *mgrconfig.KernelDirs, splitBuildDelimiters, moduleObj []string,
-	hostModules []*vminfo.KernelModule) (*Impl, error) {
-	return makeDWARF(&dwarfParams{
+	hostModules []*vminfo.KernelModule, indirectCalls bool) (*Impl, error) {
+	params := &dwarfParams{
 		target:                target,
 		kernelDirs:            kernelDirs,
 		splitBuildDelimiters:  splitBuildDelimiters,
@@ -30,7 +30,11 @@ func makeELF(target *targets.Target, kernelDirs *mgrconfig.KernelDirs, splitBuil
 		readModuleCoverPoints: elfReadModuleCoverPoints,
 		readTextRanges:        elfReadTextRanges,
 		getCompilerVersion:    elfGetCompilerVersion,
-	})
+	}
+	if indirectCalls {
+		params.readIndirectCalls = elfReadIndirectCalls
+	}
+	return makeDWARF(params)
 }
 
 const (
@@ -120,7 +124,6 @@ func elfReadTextRanges(module *vminfo.KernelModule) ([]pcRange, []*CompileUnit,
 	if text == nil {
 		return nil, nil, fmt.Errorf("no .text section in the object file")
 	}
-	kaslr := file.Section(".rela.text") != nil
 	debugInfo, err := file.DWARF()
 	if err != nil {
 		if module.Name != "" {
@@ -129,9 +132,29 @@ func elfReadTextRanges(module *vminfo.KernelModule) ([]pcRange, []*CompileUnit,
 		}
 		return nil, nil, fmt.Errorf("failed to parse DWARF: %w (set CONFIG_DEBUG_INFO=y on linux)", err)
 	}
+	return readTextRanges(debugInfo, module, elfPCFix(file, text))
+}
+
+func elfReadIndirectCalls(module *vminfo.KernelModule) (*indirectCalls, error) {
+	file, err := elf.Open(module.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	text := file.Section(".text")
+	if text == nil {
+		return nil, fmt.Errorf("no .text section in the object file")
+	}
+	debugInfo, err := file.DWARF()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DWARF: %w (set CONFIG_DEBUG_INFO=y on linux)", err)
+	}
+	return readIndirectCalls(debugInfo, elfPCFix(file, text))
+}
 
+func elfPCFix(file *elf.File, text *elf.Section) pcFixFn {
 	var pcFix pcFixFn
-	if kaslr {
+	if kaslr := file.Section(".rela.text") != nil; kaslr {
 		pcFix = func(r [2]uint64) ([2]uint64, bool) {
 			if r[0] >= r[1] || r[0] < text.Addr || r[1] > text.Addr+text.Size {
 				// Linux kernel binaries with CONFIG_RANDOMIZE_BASE=y are strange.
@@ -150,8 +173,7 @@ func elfReadTextRanges(module *vminfo.KernelModule) ([]pcRange, []*CompileUnit,
 			return r, false
 		}
 	}
-
-	return readTextRanges(debugInfo, module, pcFix)
+	return pcFix
 }
 
 func elfReadTextData(module *vminfo.KernelModule) ([]byte, error) {
@@ -0,0 +1,322 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/coveragedb"
+	"golang.org/x/exp/maps"
+)
+
+// CoverDiff describes the coverage changes between the base and the new coverage.
+type CoverDiff struct {
+	Subsystems []*SubsystemDiff `json:"subsystems"`
+}
+
+type SubsystemDiff struct {
+	Name string `json:"name"`
+	// Base and New are coverage summaries in the /subsystemcover format.
+	// They are present only for diffs of raw coverage.
+	Base                map[string]string `json:"base,omitempty"`
+	New                 map[string]string `json:"new,omitempty"`
+	NewlyCoveredLines   int               `json:"newly_covered_lines"`
+	NewlyUncoveredLines int               `json:"newly_uncovered_lines"`
+	NewlyCoveredFuncs   int               `json:"newly_covered_funcs"`
+	NewlyUncoveredFuncs int               `json:"newly_uncovered_funcs"`
+	Files               []*FileDiff       `json:"files,omitempty"`
+}
+
+type FileDiff struct {
+	Path                string   `json:"path"`
+	NewlyCoveredLines   []int    `json:"newly_covered_lines,omitempty"`
+	NewlyUncoveredLines []int    `json:"newly_uncovered_lines,omitempty"`
+	NewlyCoveredFuncs   []string `json:"newly_covered_funcs,omitempty"`
+	NewlyUncoveredFuncs []string `json:"newly_uncovered_funcs,omitempty"`
+}
+
+// fileCover holds instrumented lines and functions of a file, the values are true for the covered ones.
+type fileCover struct {
+	lines map[int]bool
+	funcs map[string]bool
+}
+
+func newFileCover() *fileCover {
+	return &fileCover{
+		lines: make(map[int]bool),
+		funcs: make(map[string]bool),
+	}
+}
+
+// uncovered returns the same instrumented lines and functions with no coverage.
+func (fc *fileCover) uncovered() *fileCover {
+	res := newFileCover()
+	for line := range fc.lines {
+		res.lines[line] = false
+	}
+	for fn := range fc.funcs {
+		res.funcs[fn] = false
+	}
+	return res
+}
+
+// diffFiles compares the coverage of the file.
+// Lines and functions that are not instrumented in one of the versions are ignored,
+// they were either added or removed rather than lost or gained coverage.
+// If the whole file is missing in one of the versions (e.g. it's not built anymore),
+// it's compared with the uncovered file, so the coverage of the file is reported as lost or gained.
+func diffFiles(path string, base, cur *fileCover) *FileDiff {
+	if base == nil {
+		base = cur.uncovered()
+	}
+	if cur == nil {
+		cur = base.uncovered()
+	}
+	fd := &FileDiff{Path: path}
+	for line, covered := range cur.lines {
+		if baseCovered, ok := base.lines[line]; ok && covered != baseCovered {
+			if covered {
+				fd.NewlyCoveredLines = append(fd.NewlyCoveredLines, line)
+			} else {
+				fd.NewlyUncoveredLines = append(fd.NewlyUncoveredLines, line)
+			}
+		}
+	}
+	for fn, covered := range cur.funcs {
+		if baseCovered, ok := base.funcs[fn]; ok && covered != baseCovered {
+			if covered {
+				fd.NewlyCoveredFuncs = append(fd.NewlyCoveredFuncs, fn)
+			} else {
+				fd.NewlyUncoveredFuncs = append(fd.NewlyUncoveredFuncs, fn)
+			}
+		}
+	}
+	if len(fd.NewlyCoveredLines)+len(fd.NewlyUncoveredLines)+
+		len(fd.NewlyCoveredFuncs)+len(fd.NewlyUncoveredFuncs) == 0 {
+		return nil
+	}
+	sort.Ints(fd.NewlyCoveredLines)
+	sort.Ints(fd.NewlyUncoveredLines)
+	sort.Strings(fd.NewlyCoveredFuncs)
+	sort.Strings(fd.NewlyUncoveredFuncs)
+	return fd
+}
+
+// diffFileSets compares the coverage of all files present in any of the versions.
+func diffFileSets(base, cur map[string]*fileCover) map[string]*FileDiff {
+	res := make(map[string]*FileDiff)
+	for _, files := range []map[string]*fileCover{base, cur} {
+		for path := range files {
+			if res[path] != nil {
+				continue
+			}
+			if fd := diffFiles(path, base[path], cur[path]); fd != nil {
+				res[path] = fd
+			}
+		}
+	}
+	return res
+}
+
+func (sd *SubsystemDiff) add(fd *FileDiff) {
+	sd.Files = append(sd.Files, fd)
+	sd.NewlyCoveredLines += len(fd.NewlyCoveredLines)
+	sd.NewlyUncoveredLines += len(fd.NewlyUncoveredLines)
+	sd.NewlyCoveredFuncs += len(fd.NewlyCoveredFuncs)
+	sd.NewlyUncoveredFuncs += len(fd.NewlyUncoveredFuncs)
+}
+
+func (d *CoverDiff) sort() {
+	sort.Slice(d.Subsystems, func(i, j int) bool {
+		return d.Subsystems[i].Name < d.Subsystems[j].Name
+	})
+	for _, sd := range d.Subsystems {
+		sort.Slice(sd.Files, func(i, j int) bool {
+			return sd.Files[i].Path < sd.Files[j].Path
+		})
+	}
+}
+
+func fileMapToFileCover(files fileMap) map[string]*fileCover {
+	res := make(map[string]*fileCover)
+	for name, f := range files {
+		fc := newFileCover()
+		for _, r := range f.uncovered {
+			fc.lines[r.StartLine] = false
+		}
+		for line, ln := range f.lines {
+			fc.lines[line] = len(ln.progCount) != 0
+		}
+		for _, fn := range f.functions {
+			fc.funcs[fn.name] = fn.covered != 0
+		}
+		res[name] = fc
+	}
+	return res
+}
+
+// rawCoverDiff compares the coverage of params.BaseProgs (base) and params.Progs (new)
+// for the same kernel binary.
+func (rg *ReportGenerator) rawCoverDiff(params HandlerParams) (*CoverDiff, error) {
+	baseProgs := fixUpPCs(params.BaseProgs, params.Filter)
+	progs := fixUpPCs(params.Progs, params.Filter)
+	// Symbolize both sides first, otherwise functions that are covered only by the new coverage
+	// would look uninstrumented in the base coverage.
+	if err := rg.symbolizePCs(uniquePCs(append(append([]Prog{}, baseProgs...), progs...)...)); err != nil {
+		return nil, err
+	}
+	baseFiles, err := rg.prepareFileMap(baseProgs, params.Force, params.Debug)
+	if err != nil {
+		return nil, fmt.Errorf("base coverage: %w", err)
+	}
+	baseStats := fileMapToStats(baseFiles)
+	base := fileMapToFileCover(baseFiles)
+	curFiles, err := rg.prepareFileMap(progs, params.Force, params.Debug)
+	if err != nil {
+		return nil, err
+	}
+	curStats := fileMapToStats(curFiles)
+	diffs := diffFileSets(base, fileMapToFileCover(curFiles))
+	baseSummary := groupCoverByFilePrefixes(baseStats, rg.subsystem)
+	curSummary := groupCoverByFilePrefixes(curStats, rg.subsystem)
+	res := new(CoverDiff)
+	for _, subsystem := range rg.subsystem {
+		sd := &SubsystemDiff{
+			Name: subsystem.Name,
+			Base: baseSummary[subsystem.Name],
+			New:  curSummary[subsystem.Name],
+		}
+		for path, fd := range diffs {
+			if inSubsystem(path, subsystem) {
+				sd.add(fd)
+			}
+		}
+		res.Subsystems = append(res.Subsystems, sd)
+	}
+	res.sort()
+	return res, nil
+}
+
+// DoCoverDiffHTML renders the coverage diff between params.BaseProgs and params.Progs.
+func (rg *ReportGenerator) DoCoverDiffHTML(w io.Writer, params HandlerParams) error {
+	d, err := rg.rawCoverDiff(params)
+	if err != nil {
+		return err
+	}
+	return d.WriteHTML(w)
+}
+
+// DoCoverDiffJSON is the same as DoCoverDiffHTML, but produces JSON.
+func (rg *ReportGenerator) DoCoverDiffJSON(w io.Writer, params HandlerParams) error {
+	d, err := rg.rawCoverDiff(params)
+	if err != nil {
+		return err
+	}
+	return d.WriteJSON(w)
+}
+
+// DBCoverDiff compares the coverage of the namespace in two coveragedb time periods.
+// Unlike the raw coverage diff, it works across kernel versions, but the lines are matched by
+// the file path and line number only. Files are grouped by the subsystems stored in the database.
+func DBCoverDiff(ctx context.Context, storage coveragedb.Storage, ns string,
+	base, cur coveragedb.TimePeriod) (*CoverDiff, error) {
+	baseFiles, baseSubsystems, err := readDBPeriod(ctx, storage, ns, base)
+	if err != nil {
+		return nil, fmt.Errorf("base period %v: %w", base.DateTo, err)
+	}
+	curFiles, subsystems, err := readDBPeriod(ctx, storage, ns, cur)
+	if err != nil {
+		return nil, fmt.Errorf("period %v: %w", cur.DateTo, err)
+	}
+	// Files that are present only in the base period are attributed to their old subsystems.
+	for path, names := range baseSubsystems {
+		if _, ok := subsystems[path]; !ok {
+			subsystems[path] = names
+		}
+	}
+	bySubsystem := make(map[string]*SubsystemDiff)
+	for path, fd := range diffFileSets(baseFiles, curFiles) {
+		for _, name := range subsystems[path] {
+			sd := bySubsystem[name]
+			if sd == nil {
+				sd = &SubsystemDiff{Name: name}
+				bySubsystem[name] = sd
+			}
+			sd.add(fd)
+		}
+	}
+	res := &CoverDiff{Subsystems: maps.Values(bySubsystem)}
+	res.sort()
+	return res, nil
+}
+
+func readDBPeriod(ctx context.Context, storage coveragedb.Storage, ns string, tp coveragedb.TimePeriod) (
+	map[string]*fileCover, map[string][]string, error) {
+	ff, err := storage.MakeFuncFinder(ctx, ns, tp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("coveragedb.MakeFuncFinder: %w", err)
+	}
+	covCh, errCh := storage.FilesCoverageStream(ctx, &coveragedb.SelectScope{
+		Ns:      ns,
+		Periods: []coveragedb.TimePeriod{tp},
+	})
+	files := make(map[string]*fileCover)
+	subsystems := make(map[string][]string)
+	for fileCov := range covCh {
+		fc := newFileCover()
+		for line, hitCount := range fileCov.CovMap() {
+			fc.lines[line] = hitCount != 0
+			if fn, err := ff.FileLineToFuncName(fileCov.Filepath, line); err == nil {
+				fc.funcs[fn] = fc.funcs[fn] || hitCount != 0
+			}
+		}
+		files[fileCov.Filepath] = fc
+		subsystems[fileCov.Filepath] = fileCov.Subsystems
+	}
+	if err := <-errCh; err != nil {
+		return nil, nil, fmt.Errorf("coveragedb.FilesCoverageStream: %w", err)
+	}
+	return files, subsystems, nil
+}
+
+func (d *CoverDiff) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(d)
+}
+
+func (d *CoverDiff) WriteHTML(w io.Writer) error {
+	return coverDiffTemplate.Execute(w, d)
+}
+
+// formatLines formats sorted line numbers as a list of ranges, e.g. "1-3, 7".
+func formatLines(lines []int) string {
+	var res []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if i == j {
+			res = append(res, fmt.Sprint(lines[i]))
+		} else {
+			res = append(res, fmt.Sprintf("%v-%v", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(res, ", ")
+}
+
+//go:embed templates/cover-diff.html
+var templatesCoverDiff string
+var coverDiffTemplate = template.Must(template.New("coverDiff").Funcs(template.FuncMap{
+	"formatLines": formatLines,
+}).Parse(templatesCoverDiff))
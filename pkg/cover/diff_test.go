@@ -0,0 +1,174 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/google/syzkaller/pkg/coveragedb"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/subsystem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffFileSets(t *testing.T) {
+	base := map[string]*fileCover{
+		"a.c": {
+			lines: map[int]bool{1: true, 2: true, 3: false, 4: false, 10: true},
+			funcs: map[string]bool{"foo": true, "bar": false, "removed": true},
+		},
+		"same.c": {
+			lines: map[int]bool{1: true, 2: false},
+			funcs: map[string]bool{"baz": true},
+		},
+		// The file is not built anymore, its coverage is lost.
+		"removed.c": {
+			lines: map[int]bool{1: true, 2: false},
+			funcs: map[string]bool{"qux": true},
+		},
+	}
+	cur := map[string]*fileCover{
+		"a.c": {
+			lines: map[int]bool{1: true, 2: false, 3: true, 4: true, 5: true},
+			funcs: map[string]bool{"foo": false, "bar": true, "added": true},
+		},
+		"same.c": {
+			lines: map[int]bool{1: true, 2: false},
+			funcs: map[string]bool{"baz": true},
+		},
+		"added.c": {
+			lines: map[int]bool{1: true, 2: false},
+		},
+	}
+	assert.Equal(t, map[string]*FileDiff{
+		"a.c": {
+			Path:                "a.c",
+			NewlyCoveredLines:   []int{3, 4},
+			NewlyUncoveredLines: []int{2},
+			NewlyCoveredFuncs:   []string{"bar"},
+			NewlyUncoveredFuncs: []string{"foo"},
+		},
+		"removed.c": {
+			Path:                "removed.c",
+			NewlyUncoveredLines: []int{1},
+			NewlyUncoveredFuncs: []string{"qux"},
+		},
+		"added.c": {
+			Path:              "added.c",
+			NewlyCoveredLines: []int{1},
+		},
+	}, diffFileSets(base, cur))
+}
+
+func TestDBCoverDiff(t *testing.T) {
+	ctx := context.Background()
+	storage, err := coveragedb.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	periods, err := coveragedb.GenNPeriodsTill(2, civil.Date{Year: 2025, Month: 1, Day: 2}, coveragedb.DayPeriod)
+	assert.NoError(t, err)
+	sss := []*subsystem.Subsystem{
+		{Name: "sound", PathRules: []subsystem.PathRule{{IncludeRegexp: "^sound/"}}},
+		{Name: "usb", PathRules: []subsystem.PathRule{{IncludeRegexp: "^drivers/usb/"}}},
+	}
+	save := func(tp coveragedb.TimePeriod, jsonl string) {
+		_, err := storage.SaveMergeResult(ctx, &coveragedb.HistoryRecord{
+			Namespace: "upstream",
+			Commit:    "commit",
+			Duration:  int64(tp.Days),
+			DateTo:    tp.DateTo,
+		}, json.NewDecoder(strings.NewReader(jsonl)), sss)
+		assert.NoError(t, err)
+	}
+	save(periods[0], `
+{"FL":{"FilePath":"drivers/usb/core.c","FuncName":"usb_foo","Lines":[1,2]}}
+{"MCR":{"Manager":"*","FilePath":"sound/core.c","FileData":{"LinesInstrumented":[1,2],"HitCounts":[1,0]}}}
+{"MCR":{"Manager":"*","FilePath":"drivers/usb/core.c","FileData":{"LinesInstrumented":[1,2],"HitCounts":[1,1]}}}
+`)
+	// drivers/usb/core.c is not built anymore.
+	save(periods[1], `
+{"MCR":{"Manager":"*","FilePath":"sound/core.c","FileData":{"LinesInstrumented":[1,2],"HitCounts":[1,1]}}}
+`)
+	diff, err := DBCoverDiff(ctx, storage, "upstream", periods[0], periods[1])
+	assert.NoError(t, err)
+	assert.Equal(t, &CoverDiff{Subsystems: []*SubsystemDiff{
+		{
+			Name:              "sound",
+			NewlyCoveredLines: 1,
+			Files: []*FileDiff{
+				{Path: "sound/core.c", NewlyCoveredLines: []int{2}},
+			},
+		},
+		{
+			Name:                "usb",
+			NewlyUncoveredLines: 2,
+			NewlyUncoveredFuncs: 1,
+			Files: []*FileDiff{
+				{
+					Path:                "drivers/usb/core.c",
+					NewlyUncoveredLines: []int{1, 2},
+					NewlyUncoveredFuncs: []string{"usb_foo"},
+				},
+			},
+		},
+	}}, diff)
+}
+
+func TestFormatLines(t *testing.T) {
+	assert.Equal(t, "", formatLines(nil))
+	assert.Equal(t, "1", formatLines([]int{1}))
+	assert.Equal(t, "1-3, 5, 7-8", formatLines([]int{1, 2, 3, 5, 7, 8}))
+}
+
+func TestInSubsystem(t *testing.T) {
+	subsystem := mgrconfig.Subsystem{
+		Name:  "test",
+		Paths: []string{"a", "b/c", "-a/2"},
+	}
+	for name, want := range map[string]bool{
+		"a/1.c":   true,
+		"a/2/1.c": false,
+		"b/c/1.c": true,
+		"b/1.c":   false,
+		"c/1.c":   false,
+	} {
+		assert.Equal(t, want, inSubsystem(name, subsystem), name)
+	}
+}
+
+func TestCoverDiffHTML(t *testing.T) {
+	d := &CoverDiff{Subsystems: []*SubsystemDiff{
+		{
+			Name: "sound",
+			Base: map[string]string{"lines": "1 / 10 / 10.00%"},
+			New:  map[string]string{"lines": "2 / 10 / 20.00%"},
+		},
+	}}
+	d.Subsystems[0].add(&FileDiff{
+		Path:                "sound/core.c",
+		NewlyCoveredLines:   []int{3, 4},
+		NewlyUncoveredFuncs: []string{"snd_foo"},
+	})
+	buf := new(bytes.Buffer)
+	if err := d.WriteHTML(buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"20.00%", "sound/core.c", "snd_foo", "3-4"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("no %q in the report:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestParseRawCover(t *testing.T) {
+	pcs, err := ParseRawCover(strings.NewReader("0xffffffff81000000\n\n 0x10 \n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{0xffffffff81000000, 0x10}, pcs)
+	_, err = ParseRawCover(strings.NewReader("foo\n"))
+	assert.Error(t, err)
+}
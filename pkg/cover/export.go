@@ -0,0 +1,259 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/maps"
+)
+
+// exportFile is per-line and per-function coverage of a source file in the form
+// that is common for the standard coverage formats (LCOV, Cobertura).
+// Hit counts are the number of programs that cover a line/function.
+type exportFile struct {
+	name      string // relative to the kernel source dir
+	path      string
+	lines     map[int]int
+	functions []exportFunction
+}
+
+type exportFunction struct {
+	name string
+	line int
+	hits int
+}
+
+func (rg *ReportGenerator) prepareExport(params HandlerParams) ([]*exportFile, error) {
+	progs := fixUpPCs(params.Progs, params.Filter)
+	files, err := rg.prepareFileMap(progs, params.Force, params.Debug)
+	if err != nil {
+		return nil, err
+	}
+	// Function start lines are not known from symbols, take the first line of the non-inlined frames.
+	funcLines := make(map[string]map[string]int)
+	for _, frame := range rg.Frames {
+		if frame.Inline || frame.StartLine <= 0 {
+			continue
+		}
+		if funcLines[frame.Name] == nil {
+			funcLines[frame.Name] = make(map[string]int)
+		}
+		if ln := funcLines[frame.Name][frame.FuncName]; ln == 0 || frame.StartLine < ln {
+			funcLines[frame.Name][frame.FuncName] = frame.StartLine
+		}
+	}
+	var res []*exportFile
+	for name, f := range files {
+		ef := &exportFile{
+			name:  name,
+			path:  f.filename,
+			lines: make(map[int]int),
+		}
+		for _, r := range f.uncovered {
+			ef.lines[r.StartLine] = 0
+		}
+		for i, ln := range f.lines {
+			ef.lines[i] = len(ln.progCount)
+		}
+		for _, fun := range f.functions {
+			line := funcLines[name][fun.name]
+			if line == 0 {
+				// Functions without line info can't be represented in the exported formats.
+				continue
+			}
+			ef.functions = append(ef.functions, exportFunction{
+				name: fun.name,
+				line: line,
+				hits: fun.progs,
+			})
+		}
+		if len(ef.lines) == 0 {
+			continue
+		}
+		res = append(res, ef)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name < res[j].name
+	})
+	return res, nil
+}
+
+func (ef *exportFile) sortedLines() []int {
+	lines := maps.Keys(ef.lines)
+	sort.Ints(lines)
+	return lines
+}
+
+func (ef *exportFile) coveredLines() int {
+	covered := 0
+	for _, hits := range ef.lines {
+		if hits != 0 {
+			covered++
+		}
+	}
+	return covered
+}
+
+// DoLCOV exports coverage in the LCOV tracefile format (see geninfo(1)).
+func (rg *ReportGenerator) DoLCOV(w io.Writer, params HandlerParams) error {
+	files, err := rg.prepareExport(params)
+	if err != nil {
+		return err
+	}
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, "TN:syzkaller\n")
+	for _, f := range files {
+		fmt.Fprintf(buf, "SF:%v\n", f.path)
+		coveredFuncs := 0
+		for _, fun := range f.functions {
+			fmt.Fprintf(buf, "FN:%v,%v\n", fun.line, fun.name)
+		}
+		for _, fun := range f.functions {
+			fmt.Fprintf(buf, "FNDA:%v,%v\n", fun.hits, fun.name)
+			if fun.hits != 0 {
+				coveredFuncs++
+			}
+		}
+		fmt.Fprintf(buf, "FNF:%v\nFNH:%v\n", len(f.functions), coveredFuncs)
+		for _, line := range f.sortedLines() {
+			fmt.Fprintf(buf, "DA:%v,%v\n", line, f.lines[line])
+		}
+		fmt.Fprintf(buf, "LF:%v\nLH:%v\nend_of_record\n", len(f.lines), f.coveredLines())
+	}
+	return buf.Flush()
+}
+
+type coberturaCoverage struct {
+	XMLName         xml.Name            `xml:"coverage"`
+	LineRate        string              `xml:"line-rate,attr"`
+	BranchRate      string              `xml:"branch-rate,attr"`
+	LinesCovered    int                 `xml:"lines-covered,attr"`
+	LinesValid      int                 `xml:"lines-valid,attr"`
+	BranchesCovered int                 `xml:"branches-covered,attr"`
+	BranchesValid   int                 `xml:"branches-valid,attr"`
+	Complexity      int                 `xml:"complexity,attr"`
+	Version         string              `xml:"version,attr"`
+	Timestamp       int64               `xml:"timestamp,attr"`
+	Sources         []string            `xml:"sources>source"`
+	Packages        []*coberturaPackage `xml:"packages>package"`
+}
+
+type coberturaPackage struct {
+	Name       string            `xml:"name,attr"`
+	LineRate   string            `xml:"line-rate,attr"`
+	BranchRate string            `xml:"branch-rate,attr"`
+	Complexity int               `xml:"complexity,attr"`
+	Classes    []*coberturaClass `xml:"classes>class"`
+	covered    int
+	total      int
+}
+
+type coberturaClass struct {
+	Name       string            `xml:"name,attr"`
+	Filename   string            `xml:"filename,attr"`
+	LineRate   string            `xml:"line-rate,attr"`
+	BranchRate string            `xml:"branch-rate,attr"`
+	Complexity int               `xml:"complexity,attr"`
+	Methods    []coberturaMethod `xml:"methods>method"`
+	Lines      []coberturaLine   `xml:"lines>line"`
+}
+
+type coberturaMethod struct {
+	Name       string          `xml:"name,attr"`
+	Signature  string          `xml:"signature,attr"`
+	LineRate   string          `xml:"line-rate,attr"`
+	BranchRate string          `xml:"branch-rate,attr"`
+	Complexity int             `xml:"complexity,attr"`
+	Lines      []coberturaLine `xml:"lines>line"`
+}
+
+type coberturaLine struct {
+	Number int    `xml:"number,attr"`
+	Hits   int    `xml:"hits,attr"`
+	Branch string `xml:"branch,attr"`
+}
+
+// DoCobertura exports coverage in the Cobertura XML format.
+// Each source directory is a package, and each file is a class.
+func (rg *ReportGenerator) DoCobertura(w io.Writer, params HandlerParams) error {
+	files, err := rg.prepareExport(params)
+	if err != nil {
+		return err
+	}
+	res := &coberturaCoverage{
+		BranchRate: "0",
+		Version:    "syzkaller",
+		Timestamp:  time.Now().Unix(),
+		Sources:    []string{rg.srcDir},
+	}
+	packages := make(map[string]*coberturaPackage)
+	for _, f := range files {
+		dir := filepath.Dir(f.name)
+		pkg := packages[dir]
+		if pkg == nil {
+			pkg = &coberturaPackage{Name: dir, BranchRate: "0"}
+			packages[dir] = pkg
+			res.Packages = append(res.Packages, pkg)
+		}
+		covered := f.coveredLines()
+		class := &coberturaClass{
+			Name:       f.name,
+			Filename:   f.name,
+			LineRate:   lineRate(covered, len(f.lines)),
+			BranchRate: "0",
+		}
+		for _, fun := range f.functions {
+			rate := "0"
+			if fun.hits != 0 {
+				rate = "1"
+			}
+			class.Methods = append(class.Methods, coberturaMethod{
+				Name:       fun.name,
+				LineRate:   rate,
+				BranchRate: "0",
+				Lines:      []coberturaLine{{Number: fun.line, Hits: fun.hits, Branch: "false"}},
+			})
+		}
+		for _, line := range f.sortedLines() {
+			class.Lines = append(class.Lines, coberturaLine{
+				Number: line,
+				Hits:   f.lines[line],
+				Branch: "false",
+			})
+		}
+		pkg.Classes = append(pkg.Classes, class)
+		pkg.covered += covered
+		pkg.total += len(f.lines)
+		res.LinesCovered += covered
+		res.LinesValid += len(f.lines)
+	}
+	for _, pkg := range res.Packages {
+		pkg.LineRate = lineRate(pkg.covered, pkg.total)
+	}
+	res.LineRate = lineRate(res.LinesCovered, res.LinesValid)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "\t")
+	if err := encoder.Encode(res); err != nil {
+		return fmt.Errorf("failed to encode cobertura report: %w", err)
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func lineRate(covered, total int) string {
+	if total == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("%.4f", float64(covered)/float64(total))
+}
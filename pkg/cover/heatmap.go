@@ -14,7 +14,6 @@ import (
 	"strings"
 
 	"github.com/google/syzkaller/pkg/coveragedb"
-	"github.com/google/syzkaller/pkg/coveragedb/spannerclient"
 	_ "github.com/google/syzkaller/pkg/subsystem/lists"
 	"golang.org/x/exp/maps"
 )
@@ -225,9 +224,9 @@ type Format struct {
 }
 
 func DoHeatMapStyleBodyJS(
-	ctx context.Context, client spannerclient.SpannerClient, scope *coveragedb.SelectScope, onlyUnique bool,
+	ctx context.Context, storage coveragedb.Storage, scope *coveragedb.SelectScope, onlyUnique bool,
 	sss, managers []string, dataFilters Format) (template.CSS, template.HTML, template.HTML, error) {
-	covAndDates, err := coveragedb.FilesCoverageWithDetails(ctx, client, scope, onlyUnique)
+	covAndDates, err := storage.FilesCoverageWithDetails(ctx, scope, onlyUnique)
 	if err != nil {
 		return "", "", "", fmt.Errorf("failed to FilesCoverageWithDetails: %w", err)
 	}
@@ -240,9 +239,9 @@ func DoHeatMapStyleBodyJS(
 }
 
 func DoSubsystemsHeatMapStyleBodyJS(
-	ctx context.Context, client spannerclient.SpannerClient, scope *coveragedb.SelectScope, onlyUnique bool,
+	ctx context.Context, storage coveragedb.Storage, scope *coveragedb.SelectScope, onlyUnique bool,
 	sss, managers []string, format Format) (template.CSS, template.HTML, template.HTML, error) {
-	covWithDetails, err := coveragedb.FilesCoverageWithDetails(ctx, client, scope, onlyUnique)
+	covWithDetails, err := storage.FilesCoverageWithDetails(ctx, scope, onlyUnique)
 	if err != nil {
 		panic(err)
 	}
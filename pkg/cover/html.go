@@ -29,6 +29,11 @@ type HandlerParams struct {
 	Filter map[uint64]struct{}
 	Debug  bool
 	Force  bool
+	// BaseProgs is the coverage to compare Progs with in the coverage diff handlers.
+	BaseProgs []Prog
+	// Hits is the number of times each PC was executed (optional).
+	// If present, DoHTML shows the counts as a heat map.
+	Hits map[uint64]uint64
 }
 
 func (rg *ReportGenerator) DoHTML(w io.Writer, params HandlerParams) error {
@@ -37,6 +42,7 @@ func (rg *ReportGenerator) DoHTML(w io.Writer, params HandlerParams) error {
 	if err != nil {
 		return err
 	}
+	maxHits := rg.addHitCounts(files, params.Hits, params.Filter)
 	d := &templateData{
 		Root:     new(templateDir),
 		RawCover: rg.rawCoverEnabled,
@@ -88,7 +94,7 @@ func (rg *ReportGenerator) DoHTML(w io.Writer, params HandlerParams) error {
 		contents := ""
 		lines, err := parseFile(file.filename)
 		if err == nil {
-			contents = fileContents(file, lines, haveProgs)
+			contents = fileContents(file, lines, haveProgs, maxHits)
 			fileOpenErr = nil
 		} else {
 			// We ignore individual errors of opening/locating source files
@@ -378,6 +384,24 @@ func (rg *ReportGenerator) DoRawCover(w io.Writer, params HandlerParams) error {
 	return nil
 }
 
+// ParseRawCover parses PCs in the DoRawCover format.
+func ParseRawCover(r io.Reader) ([]uint64, error) {
+	var pcs []uint64
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		pc, err := strconv.ParseUint(line, 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		pcs = append(pcs, pc)
+	}
+	return pcs, s.Err()
+}
+
 func (rg *ReportGenerator) DoFilterPCs(w io.Writer, params HandlerParams) error {
 	progs := fixUpPCs(params.Progs, params.Filter)
 	var pcs []uint64
@@ -437,7 +461,10 @@ func (rg *ReportGenerator) convertToStats(progs []Prog) ([]fileStats, error) {
 	if err != nil {
 		return nil, err
 	}
+	return fileMapToStats(files), nil
+}
 
+func fileMapToStats(files fileMap) []fileStats {
 	var data []fileStats
 	for fname, file := range files {
 		lines, err := parseFile(file.filename)
@@ -480,7 +507,7 @@ func (rg *ReportGenerator) convertToStats(progs []Prog) ([]fileStats, error) {
 		})
 	}
 
-	return data, nil
+	return data
 }
 
 func (rg *ReportGenerator) DoFileCover(w io.Writer, params HandlerParams) error {
@@ -537,25 +564,19 @@ func groupCoverByFilePrefixes(datas []fileStats, subsystems []mgrconfig.Subsyste
 		var percentInCoveredFunc float64
 		var percentCoveredFunc float64
 
-		for _, path := range subsystem.Paths {
-			if strings.HasPrefix(path, "-") {
+		for _, data := range datas {
+			if !inSubsystem(data.Name, subsystem) {
 				continue
 			}
-			excludes := buildExcludePaths(path, subsystem.Paths)
-			for _, data := range datas {
-				if !strings.HasPrefix(data.Name, path) || isExcluded(data.Name, excludes) {
-					continue
-				}
-				coveredLines += data.CoveredLines
-				totalLines += data.TotalLines
-				coveredPCsInFile += data.CoveredPCs
-				totalPCsInFile += data.TotalPCs
-				totalFuncs += data.TotalFunctions
-				coveredFuncs += data.CoveredFunctions
-				coveredPCsInFuncs += data.CoveredPCsInFunctions
-				pcsInFuncs += data.TotalPCsInFunctions
-				pcsInCoveredFuncs += data.TotalPCsInCoveredFunctions
-			}
+			coveredLines += data.CoveredLines
+			totalLines += data.TotalLines
+			coveredPCsInFile += data.CoveredPCs
+			totalPCsInFile += data.TotalPCs
+			totalFuncs += data.TotalFunctions
+			coveredFuncs += data.CoveredFunctions
+			coveredPCsInFuncs += data.CoveredPCsInFunctions
+			pcsInFuncs += data.TotalPCsInFunctions
+			pcsInCoveredFuncs += data.TotalPCsInCoveredFunctions
 		}
 
 		if totalLines != 0 {
@@ -587,6 +608,20 @@ func groupCoverByFilePrefixes(datas []fileStats, subsystems []mgrconfig.Subsyste
 	return d
 }
 
+// inSubsystem returns whether the file belongs to the subsystem, i.e. it matches one of the path prefixes
+// of the subsystem, and does not match any of the excluded ("-" prefixed) paths.
+func inSubsystem(name string, subsystem mgrconfig.Subsystem) bool {
+	for _, path := range subsystem.Paths {
+		if strings.HasPrefix(path, "-") {
+			continue
+		}
+		if strings.HasPrefix(name, path) && !isExcluded(name, buildExcludePaths(path, subsystem.Paths)) {
+			return true
+		}
+	}
+	return false
+}
+
 func buildExcludePaths(prefix string, paths []string) []string {
 	var excludes []string
 	for _, path := range paths {
@@ -749,7 +784,7 @@ func fixUpPCs(progs []Prog, coverFilter map[uint64]struct{}) []Prog {
 	return progs
 }
 
-func fileContents(file *file, lines [][]byte, haveProgs bool) string {
+func fileContents(file *file, lines [][]byte, haveProgs bool, maxHits uint64) string {
 	var buf bytes.Buffer
 	lineCover := perLineCoverage(file.covered, file.uncovered)
 	htmlReplacer := strings.NewReplacer(">", "&gt;", "<", "&lt;", "&", "&amp;", "\t", "        ")
@@ -765,6 +800,15 @@ func fileContents(file *file, lines [][]byte, haveProgs bool) string {
 			buf.WriteByte('\n')
 		}
 	}
+	if maxHits != 0 {
+		buf.WriteString("</td><td class='hits'>")
+		for i := range lines {
+			if line := file.lines[i+1]; len(line.progCount) != 0 {
+				buf.WriteString(hitsSpan(line.hits, maxHits))
+			}
+			buf.WriteByte('\n')
+		}
+	}
 	buf.WriteString("</td><td>")
 	for i := range lines {
 		buf.WriteString(fmt.Sprintf("%d\n", i+1))
@@ -805,6 +849,29 @@ type lineCoverChunk struct {
 	Uncovered bool
 }
 
+// hitsSpan renders the number of executions of a line colored from cold (blue) to hot (red).
+// The scale is logarithmic since the counts differ by orders of magnitude.
+func hitsSpan(hits, maxHits uint64) string {
+	heat := 0.0
+	if hits > 1 {
+		heat = math.Log(float64(hits)) / math.Log(float64(maxHits))
+	}
+	return fmt.Sprintf("<span style='background-color: hsl(%v, 100%%, 80%%)' title='executed %v times'>%6v</span>",
+		int(240*(1-heat)), hits, formatHits(hits))
+}
+
+func formatHits(hits uint64) string {
+	switch {
+	case hits >= 1e9:
+		return fmt.Sprintf("%.1fG", float64(hits)/1e9)
+	case hits >= 1e6:
+		return fmt.Sprintf("%.1fM", float64(hits)/1e6)
+	case hits >= 1e5:
+		return fmt.Sprintf("%.1fK", float64(hits)/1e3)
+	}
+	return strconv.FormatUint(hits, 10)
+}
+
 func perLineCoverage(covered, uncovered []backend.Range) map[int][]lineCoverChunk {
 	lines := make(map[int][]lineCoverChunk)
 	for _, r := range covered {
@@ -26,6 +26,8 @@ type ReportGenerator struct {
 type Prog struct {
 	Sig  string
 	Data string
+	// Call is the syscall the coverage is attributed to (optional).
+	Call string
 	PCs  []uint64
 }
 
@@ -68,12 +70,14 @@ type function struct {
 	name    string
 	pcs     int
 	covered int
+	progs   int // max number of programs that cover a single PC in the function
 }
 
 type line struct {
 	progCount   map[int]bool   // program indices that cover this line
 	progIndex   int            // example program index that covers this line
 	pcProgCount map[uint64]int // some lines have multiple BBs
+	hits        uint64         // max number of executions of the line BBs
 }
 
 type fileMap map[string]*file
@@ -129,6 +133,7 @@ func (rg *ReportGenerator) prepareFileMap(progs []Prog, force, debug bool) (file
 		for _, pc := range s.PCs {
 			if pcToProgs[pc] != nil {
 				fun.covered++
+				fun.progs = max(fun.progs, len(pcToProgs[pc]))
 			}
 		}
 		f := files[s.Unit.Name]
@@ -178,6 +183,29 @@ func (rg *ReportGenerator) frame2line(files fileMap, pcToProgs map[uint64]map[in
 	return nil
 }
 
+// addHitCounts adds the number of executions to the covered lines and returns the max number.
+func (rg *ReportGenerator) addHitCounts(files fileMap, hits map[uint64]uint64, filter map[uint64]struct{}) uint64 {
+	maxHits := uint64(0)
+	for _, frame := range rg.Frames {
+		count := hits[frame.PC]
+		if frame.StartLine < 0 || count == 0 {
+			continue
+		}
+		if _, ok := filter[frame.PC]; filter != nil && !ok {
+			continue
+		}
+		f := fileByFrame(files, frame)
+		ln := f.lines[frame.StartLine]
+		if ln.progCount == nil {
+			continue // the count column is shown only for the covered lines
+		}
+		ln.hits = max(ln.hits, count)
+		f.lines[frame.StartLine] = ln
+		maxHits = max(maxHits, ln.hits)
+	}
+	return maxHits
+}
+
 func contains(pcs []uint64, pc uint64) bool {
 	idx := sort.Search(len(pcs), func(i int) bool { return pcs[i] >= pc })
 	return idx < len(pcs) && pcs[idx] == pc
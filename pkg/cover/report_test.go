@@ -11,6 +11,7 @@ import (
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -178,6 +179,8 @@ func testReportGenerator(t *testing.T, target *targets.Target, test Test) {
 	checkCSVReport(t, reps.csv.Bytes())
 	checkJSONLReport(t, reps.jsonl.Bytes(), sampleCoverJSON)
 	checkJSONLReport(t, reps.jsonlPrograms.Bytes(), sampleJSONLlProgs)
+	checkLCOVReport(t, reps.lcov.Bytes())
+	checkCoberturaReport(t, reps.cobertura.Bytes())
 }
 
 const kcovCode = `
@@ -295,6 +298,8 @@ type reports struct {
 	csv           *bytes.Buffer
 	jsonl         *bytes.Buffer
 	jsonlPrograms *bytes.Buffer
+	lcov          *bytes.Buffer
+	cobertura     *bytes.Buffer
 }
 
 func generateReport(t *testing.T, target *targets.Target, test *Test) (*reports, error) {
@@ -386,16 +391,30 @@ func generateReport(t *testing.T, target *targets.Target, test *Test) (*reports,
 	if err := rg.DoHTML(new(bytes.Buffer), params); err != nil {
 		return nil, err
 	}
+	hits := make(map[uint64]uint64)
+	for i, pc := range progs[0].PCs {
+		hits[pc] = uint64(i + 1)
+	}
+	hitsHTML := new(bytes.Buffer)
+	assert.NoError(t, rg.DoHTML(hitsHTML, HandlerParams{Progs: progs, Hits: hits}))
+	if len(hits) != 0 {
+		assert.Contains(t, hitsHTML.String(), "<td class='hits'>")
+	}
 	assert.NoError(t, rg.DoSubsystemCover(new(bytes.Buffer), params))
 	assert.NoError(t, rg.DoFileCover(new(bytes.Buffer), params))
 	res := &reports{
 		csv:           new(bytes.Buffer),
 		jsonl:         new(bytes.Buffer),
 		jsonlPrograms: new(bytes.Buffer),
+		lcov:          new(bytes.Buffer),
+		cobertura:     new(bytes.Buffer),
 	}
 	assert.NoError(t, rg.DoFuncCover(res.csv, params))
 	assert.NoError(t, rg.DoCoverJSONL(res.jsonl, params))
 	assert.NoError(t, rg.DoCoverPrograms(res.jsonlPrograms, params))
+	assert.NoError(t, rg.DoLCOV(res.lcov, params))
+	assert.NoError(t, rg.DoCobertura(res.cobertura, params))
+	assert.NoError(t, rg.DoCoverDiffJSON(new(bytes.Buffer), HandlerParams{Progs: progs, BaseProgs: progs}))
 	return res, nil
 }
 
@@ -424,6 +443,42 @@ func checkCSVReport(t *testing.T, CSVReport []byte) {
 	}
 }
 
+func checkLCOVReport(t *testing.T, report []byte) {
+	for _, re := range []string{
+		`(?m)^SF:.*/main\.c\nFN:1,main\nFNDA:1,main\nFNF:1\nFNH:1\n`,
+		`(?m)^DA:1,1\n`,
+		`(?m)^LH:[1-9][0-9]*\nend_of_record$`,
+	} {
+		assert.Regexp(t, re, string(report))
+	}
+}
+
+func checkCoberturaReport(t *testing.T, report []byte) {
+	res := new(coberturaCoverage)
+	if err := xml.Unmarshal(report, res); err != nil {
+		t.Fatal(err)
+	}
+	var class *coberturaClass
+	for _, pkg := range res.Packages {
+		for _, c := range pkg.Classes {
+			if c.Filename == "main.c" {
+				class = c
+			}
+		}
+	}
+	if class == nil {
+		t.Fatalf("no main.c in the cobertura report:\n%s", report)
+	}
+	assert.Equal(t, []coberturaMethod{{
+		Name:       "main",
+		LineRate:   "1",
+		BranchRate: "0",
+		Lines:      []coberturaLine{{Number: 1, Hits: 1, Branch: "false"}},
+	}}, class.Methods)
+	assert.Contains(t, class.Lines, coberturaLine{Number: 1, Hits: 1, Branch: "false"})
+	assert.NotZero(t, res.LinesCovered)
+}
+
 func checkJSONLReport(t *testing.T, gotBytes, wantBytes []byte) {
 	compacted := new(bytes.Buffer)
 	if err := json.Compact(compacted, wantBytes); err != nil {
@@ -475,6 +530,17 @@ func makeFileStat(name string) fileStats {
 	}
 }
 
+func TestHitsSpan(t *testing.T) {
+	assert.Equal(t, "7", formatHits(7))
+	assert.Equal(t, "99999", formatHits(99999))
+	assert.Equal(t, "123.5K", formatHits(123456))
+	assert.Equal(t, "1.2M", formatHits(1234567))
+	assert.Equal(t, "5.0G", formatHits(5e9))
+	assert.Contains(t, hitsSpan(0, 1000), "hsl(240,")
+	assert.Contains(t, hitsSpan(1000, 1000), "hsl(0,")
+	assert.Contains(t, hitsSpan(10, 100), "hsl(120,")
+}
+
 func TestCoverByFilePrefixes(t *testing.T) {
 	datas := []fileStats{
 		makeFileStat("a"),
@@ -502,3 +568,30 @@ func TestCoverByFilePrefixes(t *testing.T) {
 		"PCsInCoveredFuncs": "3 / 6 / 50.00%",
 	})
 }
+
+func TestCoverByOverlappingFilePrefixes(t *testing.T) {
+	datas := []fileStats{
+		makeFileStat("a/1"),
+		makeFileStat("a/2"),
+		makeFileStat("b/1"),
+	}
+	subsystems := []mgrconfig.Subsystem{
+		{
+			Name: "test",
+			// a/1 matches both paths, but must be counted only once.
+			Paths: []string{
+				"a",
+				"a/1",
+			},
+		},
+	}
+	d := groupCoverByFilePrefixes(datas, subsystems)
+	assert.Equal(t, d["test"], map[string]string{
+		"name":              "test",
+		"lines":             "2 / 16 / 12.50%",
+		"PCsInFiles":        "2 / 8 / 25.00%",
+		"Funcs":             "2 / 4 / 50.00%",
+		"PCsInFuncs":        "2 / 4 / 50.00%",
+		"PCsInCoveredFuncs": "2 / 4 / 50.00%",
+	})
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+)
+
+// SyscallCover attributes the coverage to the syscalls that reach it (Prog.Call).
+type SyscallCover struct {
+	// Calls lists subsystems and files that each syscall reaches.
+	Calls []*SyscallReach `json:"calls"`
+	// Funcs lists syscalls that reach each covered kernel function.
+	Funcs []*FuncSyscalls `json:"funcs"`
+}
+
+type SyscallReach struct {
+	Call       string          `json:"call"`
+	Progs      int             `json:"progs"`
+	Subsystems []*ReachedFiles `json:"subsystems"`
+	Files      []*ReachedFiles `json:"files"`
+}
+
+// ReachedFiles is the coverage of a file or a subsystem by a single syscall.
+type ReachedFiles struct {
+	Name       string `json:"name"`
+	Funcs      int    `json:"funcs"`
+	CoveredPCs int    `json:"covered_pcs"`
+	TotalPCs   int    `json:"total_pcs"`
+}
+
+type FuncSyscalls struct {
+	Name  string         `json:"name"`
+	File  string         `json:"file"`
+	Calls []*FuncSyscall `json:"calls"`
+}
+
+type FuncSyscall struct {
+	Call  string `json:"call"`
+	Progs int    `json:"progs"`
+	// Examples are signatures of the shortest programs that reach the function with this syscall.
+	Examples []string `json:"examples"`
+}
+
+const maxSyscallCoverExamples = 5
+
+func (rg *ReportGenerator) syscallCover(params HandlerParams) (*SyscallCover, error) {
+	progs := fixUpPCs(params.Progs, params.Filter)
+	type funcCall struct {
+		progs    int
+		last     int
+		examples []int // the shortest programs
+	}
+	funcCalls := make(map[*backend.Symbol]map[string]*funcCall)
+	callPCs := make(map[string]map[uint64]*backend.Symbol)
+	callProgs := make(map[string]int)
+	for i, p := range progs {
+		if p.Call == "" {
+			return nil, fmt.Errorf("coverage of program %q is not attributed to a syscall", p.Sig)
+		}
+		callProgs[p.Call]++
+		if callPCs[p.Call] == nil {
+			callPCs[p.Call] = make(map[uint64]*backend.Symbol)
+		}
+		for _, pc := range p.PCs {
+			sym := rg.findSymbol(pc)
+			if sym == nil || sym.Unit == nil {
+				continue
+			}
+			callPCs[p.Call][pc] = sym
+			if funcCalls[sym] == nil {
+				funcCalls[sym] = make(map[string]*funcCall)
+			}
+			fc := funcCalls[sym][p.Call]
+			if fc == nil {
+				fc = new(funcCall)
+				funcCalls[sym][p.Call] = fc
+			}
+			if fc.progs != 0 && fc.last == i {
+				continue // the program has several PCs in the function
+			}
+			fc.progs++
+			fc.last = i
+			fc.examples = append(fc.examples, i)
+			sort.SliceStable(fc.examples, func(a, b int) bool {
+				return len(progs[fc.examples[a]].Data) < len(progs[fc.examples[b]].Data)
+			})
+			fc.examples = fc.examples[:min(len(fc.examples), maxSyscallCoverExamples)]
+		}
+	}
+	if len(callProgs) == 0 {
+		return nil, fmt.Errorf("no coverage collected so far")
+	}
+	res := new(SyscallCover)
+	for sym, calls := range funcCalls {
+		fs := &FuncSyscalls{
+			Name: sym.Name,
+			File: sym.Unit.Name,
+		}
+		for call, fc := range calls {
+			fcall := &FuncSyscall{
+				Call:  call,
+				Progs: fc.progs,
+			}
+			for _, idx := range fc.examples {
+				fcall.Examples = append(fcall.Examples, progs[idx].Sig)
+			}
+			fs.Calls = append(fs.Calls, fcall)
+		}
+		sort.Slice(fs.Calls, func(i, j int) bool {
+			if fs.Calls[i].Progs != fs.Calls[j].Progs {
+				return fs.Calls[i].Progs > fs.Calls[j].Progs
+			}
+			return fs.Calls[i].Call < fs.Calls[j].Call
+		})
+		res.Funcs = append(res.Funcs, fs)
+	}
+	sort.Slice(res.Funcs, func(i, j int) bool {
+		if res.Funcs[i].Name != res.Funcs[j].Name {
+			return res.Funcs[i].Name < res.Funcs[j].Name
+		}
+		return res.Funcs[i].File < res.Funcs[j].File
+	})
+	unitPCs := make(map[string]int)
+	for _, unit := range rg.Units {
+		unitPCs[unit.Name] += len(unit.PCs)
+	}
+	for call, pcs := range callPCs {
+		res.Calls = append(res.Calls, rg.syscallReach(call, callProgs[call], pcs, unitPCs))
+	}
+	sort.Slice(res.Calls, func(i, j int) bool {
+		return res.Calls[i].Call < res.Calls[j].Call
+	})
+	return res, nil
+}
+
+func (rg *ReportGenerator) syscallReach(call string, progs int, pcs map[uint64]*backend.Symbol,
+	unitPCs map[string]int) *SyscallReach {
+	files := make(map[string]*ReachedFiles)
+	funcs := make(map[*backend.Symbol]bool)
+	for _, sym := range pcs {
+		file := files[sym.Unit.Name]
+		if file == nil {
+			file = &ReachedFiles{
+				Name:     sym.Unit.Name,
+				TotalPCs: unitPCs[sym.Unit.Name],
+			}
+			files[sym.Unit.Name] = file
+		}
+		file.CoveredPCs++
+		if !funcs[sym] {
+			funcs[sym] = true
+			file.Funcs++
+		}
+	}
+	reach := &SyscallReach{
+		Call:  call,
+		Progs: progs,
+	}
+	for _, file := range files {
+		reach.Files = append(reach.Files, file)
+	}
+	sortReachedFiles(reach.Files)
+	for _, subsystem := range rg.subsystem {
+		sub := &ReachedFiles{Name: subsystem.Name}
+		for name, total := range unitPCs {
+			if inSubsystem(name, subsystem) {
+				sub.TotalPCs += total
+			}
+		}
+		for _, file := range reach.Files {
+			if inSubsystem(file.Name, subsystem) {
+				sub.Funcs += file.Funcs
+				sub.CoveredPCs += file.CoveredPCs
+			}
+		}
+		if sub.CoveredPCs != 0 {
+			reach.Subsystems = append(reach.Subsystems, sub)
+		}
+	}
+	sortReachedFiles(reach.Subsystems)
+	return reach
+}
+
+func sortReachedFiles(files []*ReachedFiles) {
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].CoveredPCs != files[j].CoveredPCs {
+			return files[i].CoveredPCs > files[j].CoveredPCs
+		}
+		return files[i].Name < files[j].Name
+	})
+}
+
+// DoSyscallCoverHTML renders the per-syscall coverage attribution of params.Progs.
+func (rg *ReportGenerator) DoSyscallCoverHTML(w io.Writer, params HandlerParams) error {
+	sc, err := rg.syscallCover(params)
+	if err != nil {
+		return err
+	}
+	return syscallCoverTemplate.Execute(w, sc)
+}
+
+// DoSyscallCoverJSON is the same as DoSyscallCoverHTML, but produces JSON.
+func (rg *ReportGenerator) DoSyscallCoverJSON(w io.Writer, params HandlerParams) error {
+	sc, err := rg.syscallCover(params)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(sc)
+}
+
+//go:embed templates/syscall-cover.html
+var templatesSyscallCover string
+var syscallCoverTemplate = template.Must(template.New("syscallCover").Funcs(template.FuncMap{
+	"percent": func(covered, total int) string {
+		if total == 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.2f%%", 100.0*float64(covered)/float64(total))
+	},
+}).Parse(templatesSyscallCover))
@@ -0,0 +1,106 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyscallCover(t *testing.T) {
+	fsUnit := &backend.CompileUnit{ObjectUnit: backend.ObjectUnit{Name: "fs/open.c",
+		PCs: []uint64{0x110, 0x120, 0x210, 0x220}}}
+	netUnit := &backend.CompileUnit{ObjectUnit: backend.ObjectUnit{Name: "net/socket.c",
+		PCs: []uint64{0x310, 0x320}}}
+	sym := func(name string, unit *backend.CompileUnit, start uint64) *backend.Symbol {
+		return &backend.Symbol{ObjectUnit: backend.ObjectUnit{Name: name}, Unit: unit,
+			Start: start, End: start + 0x100}
+	}
+	rg := &ReportGenerator{
+		subsystem: []mgrconfig.Subsystem{
+			{Name: "fs", Paths: []string{"fs"}},
+			{Name: "net", Paths: []string{"net"}},
+		},
+		Impl: &backend.Impl{
+			Units: []*backend.CompileUnit{fsUnit, netUnit},
+			Symbols: []*backend.Symbol{
+				sym("do_sys_open", fsUnit, 0x100),
+				sym("vfs_open", fsUnit, 0x200),
+				sym("sock_create", netUnit, 0x300),
+			},
+		},
+	}
+	progs := []Prog{
+		{Sig: "open1", Data: "long program", Call: "open", PCs: []uint64{0x110, 0x120, 0x210}},
+		{Sig: "open2", Data: "short", Call: "open", PCs: []uint64{0x110}},
+		{Sig: "socket1", Data: "socket", Call: "socket", PCs: []uint64{0x310, 0x210, 0x999}},
+	}
+	sc, err := rg.syscallCover(HandlerParams{Progs: progs})
+	require.NoError(t, err)
+	assert.Equal(t, &SyscallCover{
+		Calls: []*SyscallReach{
+			{
+				Call:  "open",
+				Progs: 2,
+				Subsystems: []*ReachedFiles{
+					{Name: "fs", Funcs: 2, CoveredPCs: 3, TotalPCs: 4},
+				},
+				Files: []*ReachedFiles{
+					{Name: "fs/open.c", Funcs: 2, CoveredPCs: 3, TotalPCs: 4},
+				},
+			},
+			{
+				Call:  "socket",
+				Progs: 1,
+				Subsystems: []*ReachedFiles{
+					{Name: "fs", Funcs: 1, CoveredPCs: 1, TotalPCs: 4},
+					{Name: "net", Funcs: 1, CoveredPCs: 1, TotalPCs: 2},
+				},
+				Files: []*ReachedFiles{
+					{Name: "fs/open.c", Funcs: 1, CoveredPCs: 1, TotalPCs: 4},
+					{Name: "net/socket.c", Funcs: 1, CoveredPCs: 1, TotalPCs: 2},
+				},
+			},
+		},
+		Funcs: []*FuncSyscalls{
+			{
+				Name: "do_sys_open",
+				File: "fs/open.c",
+				Calls: []*FuncSyscall{
+					{Call: "open", Progs: 2, Examples: []string{"open2", "open1"}},
+				},
+			},
+			{
+				Name: "sock_create",
+				File: "net/socket.c",
+				Calls: []*FuncSyscall{
+					{Call: "socket", Progs: 1, Examples: []string{"socket1"}},
+				},
+			},
+			{
+				Name: "vfs_open",
+				File: "fs/open.c",
+				Calls: []*FuncSyscall{
+					{Call: "open", Progs: 1, Examples: []string{"open1"}},
+					{Call: "socket", Progs: 1, Examples: []string{"socket1"}},
+				},
+			},
+		},
+	}, sc)
+
+	buf := new(bytes.Buffer)
+	require.NoError(t, rg.DoSyscallCoverHTML(buf, HandlerParams{Progs: progs}))
+	for _, want := range []string{"vfs_open", "net/socket.c", "75.00%", "/input?sig=open2"} {
+		assert.True(t, strings.Contains(buf.String(), want), want)
+	}
+
+	_, err = rg.syscallCover(HandlerParams{Progs: []Prog{{Sig: "raw", PCs: []uint64{0x110}}}})
+	assert.Error(t, err)
+}
@@ -302,6 +302,65 @@ func NsDataMerged(ctx context.Context, client spannerclient.SpannerClient, ns st
 	return periods, totalRows, nil
 }
 
+// PeriodCoverage is the coverage of all namespace files merged across all managers for the time period.
+type PeriodCoverage struct {
+	TimePeriod
+	Instrumented int64
+	Covered      int64
+}
+
+// NsCoverage returns the namespace coverage of all merged periods that are minDays-maxDays long.
+func NsCoverage(ctx context.Context, client spannerclient.SpannerClient, ns string, minDays, maxDays int,
+) ([]*PeriodCoverage, error) {
+	if client == nil {
+		return nil, fmt.Errorf("nil spannerclient")
+	}
+	stmt := spanner.Statement{
+		SQL: `
+select
+  dateto as targetdate,
+  duration as days,
+  cast(sum(instrumented) as INTEGER) as instrumented,
+  cast(sum(covered) as INTEGER) as covered
+from merge_history join files
+  on merge_history.session = files.session
+where namespace=$1 and duration>=$2 and duration<=$3 and manager='*'
+group by dateto, duration`,
+		Params: map[string]interface{}{
+			"p1": ns,
+			"p2": minDays,
+			"p3": maxDays,
+		},
+	}
+	iter := client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+	var res []*PeriodCoverage
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iter.Next() spanner DB: %w", err)
+		}
+		var r struct {
+			Targetdate   civil.Date
+			Days         int64
+			Instrumented int64
+			Covered      int64
+		}
+		if err = row.ToStruct(&r); err != nil {
+			return nil, fmt.Errorf("failed to row.ToStruct() spanner DB: %w", err)
+		}
+		res = append(res, &PeriodCoverage{
+			TimePeriod:   TimePeriod{DateTo: r.Targetdate, Days: int(r.Days)},
+			Instrumented: r.Instrumented,
+			Covered:      r.Covered,
+		})
+	}
+	return res, nil
+}
+
 // DeleteGarbage removes orphaned file entries from the database.
 //
 // It identifies files in the "files" table that are not referenced by any entries in the "merge_history" table,
@@ -411,8 +470,22 @@ type SelectScope struct {
 // It is expensive and better to be used for time insensitive operations.
 func FilesCoverageStream(ctx context.Context, client spannerclient.SpannerClient, scope *SelectScope,
 ) (<-chan *FileCoverageWithLineInfo, <-chan error) {
-	iter := client.Single().Query(ctx,
-		filesCoverageWithDetailsStmt(scope, true))
+	return filesCoverageStream(ctx, spannerFilesQuery(ctx, client), scope)
+}
+
+// filesQuery returns coverage records (FileCoverageWithDetails or FileCoverageWithLineInfo
+// if withLines is set) of the scope.Periods[0] ordered by the file path.
+type filesQuery func(scope *SelectScope, withLines bool) spannerclient.RowIterator
+
+func spannerFilesQuery(ctx context.Context, client spannerclient.SpannerClient) filesQuery {
+	return func(scope *SelectScope, withLines bool) spannerclient.RowIterator {
+		return client.Single().Query(ctx, filesCoverageWithDetailsStmt(scope, withLines))
+	}
+}
+
+func filesCoverageStream(ctx context.Context, query filesQuery, scope *SelectScope,
+) (<-chan *FileCoverageWithLineInfo, <-chan error) {
+	iter := query(scope, true)
 	resCh := make(chan *FileCoverageWithLineInfo)
 	errCh := make(chan error)
 	go func() {
@@ -430,29 +503,32 @@ func FilesCoverageStream(ctx context.Context, client spannerclient.SpannerClient
 // Flag onlyUnique is quite expensive.
 func FilesCoverageWithDetails(
 	ctx context.Context, client spannerclient.SpannerClient, scope *SelectScope, onlyUnique bool,
+) ([]*FileCoverageWithDetails, error) {
+	return filesCoverageWithDetails(ctx, spannerFilesQuery(ctx, client), scope, onlyUnique)
+}
+
+func filesCoverageWithDetails(ctx context.Context, query filesQuery, scope *SelectScope, onlyUnique bool,
 ) ([]*FileCoverageWithDetails, error) {
 	var res []*FileCoverageWithDetails
 	for _, timePeriod := range scope.Periods {
 		needLinesDetails := onlyUnique
-		iterManager := client.Single().Query(ctx,
-			filesCoverageWithDetailsStmt(&SelectScope{
-				Ns:        scope.Ns,
-				Subsystem: scope.Subsystem,
-				Manager:   scope.Manager,
-				Periods:   []TimePeriod{timePeriod},
-			}, needLinesDetails))
+		iterManager := query(&SelectScope{
+			Ns:        scope.Ns,
+			Subsystem: scope.Subsystem,
+			Manager:   scope.Manager,
+			Periods:   []TimePeriod{timePeriod},
+		}, needLinesDetails)
 		defer iterManager.Stop()
 
 		var err error
 		var periodRes []*FileCoverageWithDetails
 		if onlyUnique {
-			iterAll := client.Single().Query(ctx,
-				filesCoverageWithDetailsStmt(&SelectScope{
-					Ns:        scope.Ns,
-					Subsystem: scope.Subsystem,
-					Manager:   "",
-					Periods:   []TimePeriod{timePeriod},
-				}, needLinesDetails))
+			iterAll := query(&SelectScope{
+				Ns:        scope.Ns,
+				Subsystem: scope.Subsystem,
+				Manager:   "",
+				Periods:   []TimePeriod{timePeriod},
+			}, needLinesDetails)
 			defer iterAll.Stop()
 			periodRes, err = readCoverageUniq(iterAll, iterManager)
 			if err != nil {
@@ -0,0 +1,139 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package coveragedb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// FuncsHistory is the per-function coverage of the ordered time periods.
+// The periods don't have to be consecutive, e.g. only some days of a window may be sampled.
+type FuncsHistory struct {
+	Periods []TimePeriod
+	// Commits are the kernel commits the coverage of the periods was merged at.
+	// The commit is empty if there is no data for the period.
+	Commits []string
+	// Funcs are sorted by the file path and the function name.
+	Funcs []*FuncCoverage
+}
+
+type FuncCoverage struct {
+	FilePath string
+	FuncName string
+	// Instrumented and Covered are the numbers of the function lines in every period.
+	Instrumented []int64
+	Covered      []int64
+}
+
+// FuncRegression is a function that was covered, but then lost all coverage.
+type FuncRegression struct {
+	*FuncCoverage
+	// LastCovered is the last period where the function was covered.
+	LastCovered TimePeriod
+	// The coverage was lost in the CommitFrom..CommitTo kernel commit range.
+	CommitFrom string
+	CommitTo   string
+	// NotInstrumented is set if the function is not instrumented in the last period at all,
+	// e.g. the file is not built anymore or the config option was disabled.
+	NotInstrumented bool
+}
+
+// FuncsCoverageHistory returns the coverage of the functions of the namespace collected from all managers.
+func FuncsCoverageHistory(ctx context.Context, storage Storage, ns string, periods []TimePeriod,
+) (*FuncsHistory, error) {
+	res := &FuncsHistory{
+		Periods: periods,
+		Commits: make([]string, len(periods)),
+	}
+	funcs := make(map[[2]string]*FuncCoverage)
+	for i, tp := range periods {
+		ff, err := storage.MakeFuncFinder(ctx, ns, tp)
+		if err != nil {
+			return nil, fmt.Errorf("MakeFuncFinder: %w", err)
+		}
+		covCh, errCh := storage.FilesCoverageStream(ctx, &SelectScope{
+			Ns:      ns,
+			Periods: []TimePeriod{tp},
+		})
+		for fileCov := range covCh {
+			res.Commits[i] = fileCov.Commit
+			for line, hitCount := range fileCov.CovMap() {
+				name, err := ff.FileLineToFuncName(fileCov.Filepath, line)
+				if err != nil {
+					continue
+				}
+				key := [2]string{fileCov.Filepath, name}
+				fc := funcs[key]
+				if fc == nil {
+					fc = &FuncCoverage{
+						FilePath:     fileCov.Filepath,
+						FuncName:     name,
+						Instrumented: make([]int64, len(periods)),
+						Covered:      make([]int64, len(periods)),
+					}
+					funcs[key] = fc
+				}
+				fc.Instrumented[i]++
+				if hitCount != 0 {
+					fc.Covered[i]++
+				}
+			}
+		}
+		if err := <-errCh; err != nil {
+			return nil, fmt.Errorf("FilesCoverageStream: %w", err)
+		}
+	}
+	for _, fc := range funcs {
+		res.Funcs = append(res.Funcs, fc)
+	}
+	sort.Slice(res.Funcs, func(i, j int) bool {
+		a, b := res.Funcs[i], res.Funcs[j]
+		if a.FilePath != b.FilePath {
+			return a.FilePath < b.FilePath
+		}
+		return a.FuncName < b.FuncName
+	})
+	return res, nil
+}
+
+// Regressions returns functions that were covered in the period preceding the last n periods,
+// but are not covered in any of the last n periods. Functions that are not instrumented
+// in the last period are returned with NotInstrumented set.
+// Since the function must be covered right before the last n periods, every regression is returned
+// only once when the history is checked after every new period.
+// Nothing is returned if some of the periods have no data.
+func (h *FuncsHistory) Regressions(n int) []*FuncRegression {
+	last := len(h.Periods) - n - 1
+	if n <= 0 || last < 0 {
+		return nil
+	}
+	for _, commit := range h.Commits[last:] {
+		if commit == "" {
+			return nil
+		}
+	}
+	var res []*FuncRegression
+	for _, fc := range h.Funcs {
+		if fc.Covered[last] == 0 {
+			continue
+		}
+		lost := true
+		for _, covered := range fc.Covered[last+1:] {
+			lost = lost && covered == 0
+		}
+		if !lost {
+			continue
+		}
+		res = append(res, &FuncRegression{
+			FuncCoverage:    fc,
+			LastCovered:     h.Periods[last],
+			CommitFrom:      h.Commits[last],
+			CommitTo:        h.Commits[last+1],
+			NotInstrumented: fc.Instrumented[len(h.Periods)-1] == 0,
+		})
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package coveragedb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFuncsCoverageHistory(t *testing.T) {
+	ctx := context.Background()
+	storage, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	periods, err := GenNPeriodsTill(4, civil.Date{Year: 2025, Month: 1, Day: 4}, DayPeriod)
+	assert.NoError(t, err)
+	save := func(tp TimePeriod, commit, jsonl string) {
+		_, err := storage.SaveMergeResult(ctx, &HistoryRecord{
+			Namespace: "upstream",
+			Commit:    commit,
+			Duration:  int64(tp.Days),
+			DateTo:    tp.DateTo,
+		}, json.NewDecoder(strings.NewReader(jsonl)), nil)
+		assert.NoError(t, err)
+	}
+	const funcs = `
+{"FL":{"FilePath":"a.c","FuncName":"foo","Lines":[1,2]}}
+{"FL":{"FilePath":"a.c","FuncName":"bar","Lines":[3]}}
+{"FL":{"FilePath":"b.c","FuncName":"baz","Lines":[1]}}
+`
+	save(periods[1], "commit1", funcs+`
+{"MCR":{"Manager":"*","FilePath":"a.c","FileData":{"LinesInstrumented":[1,2,3],"HitCounts":[1,0,1]}}}
+{"MCR":{"Manager":"*","FilePath":"b.c","FileData":{"LinesInstrumented":[1],"HitCounts":[1]}}}
+`)
+	// b.c is not built anymore.
+	save(periods[2], "commit2", funcs+`
+{"MCR":{"Manager":"*","FilePath":"a.c","FileData":{"LinesInstrumented":[1,2,3],"HitCounts":[0,0,1]}}}
+`)
+	save(periods[3], "commit3", funcs+`
+{"MCR":{"Manager":"*","FilePath":"a.c","FileData":{"LinesInstrumented":[1,2,3],"HitCounts":[0,0,1]}}}
+{"MCR":{"Manager":"mgr","FilePath":"a.c","FileData":{"LinesInstrumented":[1,2,3],"HitCounts":[0,0,1]}}}
+`)
+
+	history, err := FuncsCoverageHistory(ctx, storage, "upstream", periods)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "commit1", "commit2", "commit3"}, history.Commits)
+	foo := &FuncCoverage{
+		FilePath:     "a.c",
+		FuncName:     "foo",
+		Instrumented: []int64{0, 2, 2, 2},
+		Covered:      []int64{0, 1, 0, 0},
+	}
+	baz := &FuncCoverage{
+		FilePath:     "b.c",
+		FuncName:     "baz",
+		Instrumented: []int64{0, 1, 0, 0},
+		Covered:      []int64{0, 1, 0, 0},
+	}
+	assert.Equal(t, []*FuncCoverage{
+		{
+			FilePath:     "a.c",
+			FuncName:     "bar",
+			Instrumented: []int64{0, 1, 1, 1},
+			Covered:      []int64{0, 1, 1, 1},
+		},
+		foo,
+		baz,
+	}, history.Funcs)
+
+	assert.Equal(t, []*FuncRegression{
+		{
+			FuncCoverage: foo,
+			LastCovered:  periods[1],
+			CommitFrom:   "commit1",
+			CommitTo:     "commit2",
+		},
+		{
+			FuncCoverage:    baz,
+			LastCovered:     periods[1],
+			CommitFrom:      "commit1",
+			CommitTo:        "commit2",
+			NotInstrumented: true,
+		},
+	}, history.Regressions(2))
+	// The regressions were already reported a period ago.
+	assert.Empty(t, history.Regressions(1))
+	// There is no data for the first period.
+	assert.Empty(t, history.Regressions(3))
+	assert.Empty(t, history.Regressions(4))
+
+	// Only the period before the window, the first and the last periods of the window are read.
+	sampled, err := FuncsCoverageHistory(ctx, storage, "upstream", []TimePeriod{periods[1], periods[2], periods[3]})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"commit1", "commit2", "commit3"}, sampled.Commits)
+	var names []string
+	for _, reg := range sampled.Regressions(2) {
+		names = append(names, fmt.Sprintf("%v:%v:%v", reg.FilePath, reg.FuncName, reg.NotInstrumented))
+	}
+	assert.Equal(t, []string{"a.c:foo:false", "b.c:baz:true"}, names)
+}
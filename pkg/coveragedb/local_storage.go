@@ -0,0 +1,483 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package coveragedb
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/pkg/coveragedb/spannerclient"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/subsystem"
+	"github.com/google/uuid"
+	"google.golang.org/api/iterator"
+)
+
+// NewLocalStorage opens the coverage database stored in dir (it's created if it does not exist).
+// It does not need Google Cloud and is meant for self-hosted deployments and hermetic tests.
+//
+// The directory contains:
+//   - merge_history.json with the merge_history table,
+//   - file_subsystems.json with the file_subsystems table,
+//   - sessions/SESSION.jsonl with the files and functions tables records of the merge session
+//     in the JSONLWrapper format.
+//
+// The history and the subsystems are kept in memory, sessions are read on demand.
+// Only one process may use the database at a time.
+func NewLocalStorage(dir string) (Storage, error) {
+	s := &localStorage{
+		dir:        dir,
+		subsystems: make(map[fileSubsystemsKey][]string),
+	}
+	if err := osutil.MkdirAll(s.sessionsDir()); err != nil {
+		return nil, err
+	}
+	if err := readJSONFile(s.historyFile(), &s.history); err != nil {
+		return nil, err
+	}
+	var subsystems []*fileSubsystems
+	if err := readJSONFile(s.subsystemsFile(), &subsystems); err != nil {
+		return nil, err
+	}
+	for _, rec := range subsystems {
+		s.subsystems[fileSubsystemsKey{rec.Namespace, rec.FilePath}] = rec.Subsystems
+	}
+	return s, nil
+}
+
+type localStorage struct {
+	dir        string
+	mu         sync.Mutex
+	history    []*HistoryRecord
+	subsystems map[fileSubsystemsKey][]string
+}
+
+type fileSubsystemsKey struct {
+	ns       string
+	filePath string
+}
+
+func (s *localStorage) historyFile() string {
+	return filepath.Join(s.dir, "merge_history.json")
+}
+
+func (s *localStorage) subsystemsFile() string {
+	return filepath.Join(s.dir, "file_subsystems.json")
+}
+
+func (s *localStorage) sessionsDir() string {
+	return filepath.Join(s.dir, "sessions")
+}
+
+func (s *localStorage) sessionFile(session string) string {
+	return filepath.Join(s.sessionsDir(), session+".jsonl")
+}
+
+func (s *localStorage) SaveMergeResult(ctx context.Context, descr *HistoryRecord, dec *json.Decoder,
+	sss []*subsystem.Subsystem) (int, error) {
+	if descr == nil {
+		return 0, errors.New("nil HistoryRecord")
+	}
+	ssMatcher := subsystem.MakePathMatcher(sss)
+	ssCache := make(map[string][]string)
+	session := uuid.New().String()
+	file := s.sessionFile(session)
+	tmpFile := file + ".tmp"
+	f, err := os.Create(tmpFile)
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmpFile)
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	// The number of rows is counted the same way SaveMergeResult does for Spanner.
+	rowsCreated := 0
+	subsystems := make(map[fileSubsystemsKey][]string)
+	for {
+		var wr JSONLWrapper
+		err := dec.Decode(&wr)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("dec.Decode(MergedCoverageRecord): %w", err)
+		}
+		if mcr := wr.MCR; mcr != nil {
+			if mcr.FileData == nil {
+				return 0, errors.New("MergedCoverageRecord without FileData")
+			}
+			key := fileSubsystemsKey{descr.Namespace, mcr.FilePath}
+			subsystems[key] = getFileSubsystems(mcr.FilePath, ssMatcher, ssCache)
+			rowsCreated += 2
+		} else if wr.FL != nil {
+			rowsCreated++
+		} else {
+			return 0, errors.New("JSONLWrapper can't be empty")
+		}
+		if err := enc.Encode(&wr); err != nil {
+			return 0, err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	if err := f.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmpFile, file); err != nil {
+		return 0, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, sss := range subsystems {
+		s.subsystems[key] = sss
+	}
+	var allSubsystems []*fileSubsystems
+	for key, sss := range s.subsystems {
+		allSubsystems = append(allSubsystems, &fileSubsystems{key.ns, key.filePath, sss})
+	}
+	sort.Slice(allSubsystems, func(i, j int) bool {
+		a, b := allSubsystems[i], allSubsystems[j]
+		return a.Namespace < b.Namespace || a.Namespace == b.Namespace && a.FilePath < b.FilePath
+	})
+	if err := writeJSONFile(s.subsystemsFile(), allSubsystems); err != nil {
+		return 0, err
+	}
+	// The merge_history primary key is (namespace, repo, duration, dateto),
+	// the previous session for the same key becomes garbage (see DeleteGarbage).
+	record := *descr
+	record.Session = session
+	record.Time = time.Now()
+	history := slices.DeleteFunc(slices.Clone(s.history), func(hr *HistoryRecord) bool {
+		return hr.Namespace == record.Namespace && hr.Repo == record.Repo &&
+			hr.Duration == record.Duration && hr.DateTo == record.DateTo
+	})
+	history = append(history, &record)
+	if err := writeJSONFile(s.historyFile(), history); err != nil {
+		return 0, err
+	}
+	s.history = history
+	return rowsCreated + 1, nil
+}
+
+// sessions returns the sessions of the namespace merged for the time period.
+func (s *localStorage) sessions(ns string, tp TimePeriod) []*HistoryRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var res []*HistoryRecord
+	for _, hr := range s.history {
+		if hr.Namespace == ns && hr.DateTo == tp.DateTo && hr.Duration == int64(tp.Days) {
+			res = append(res, hr)
+		}
+	}
+	return res
+}
+
+type localSession struct {
+	files map[localFileKey]*MergedCoverageRecord
+	funcs map[localFuncKey]*FuncLines
+}
+
+type localFileKey struct {
+	manager  string
+	filePath string
+}
+
+type localFuncKey struct {
+	filePath string
+	funcName string
+}
+
+// readSession reads the session records. Later records replace earlier ones with the same key,
+// the same way InsertOrUpdate mutations do.
+func (s *localStorage) readSession(session string) (*localSession, error) {
+	f, err := os.Open(s.sessionFile(session))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	res := &localSession{
+		files: make(map[localFileKey]*MergedCoverageRecord),
+		funcs: make(map[localFuncKey]*FuncLines),
+	}
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for {
+		var wr JSONLWrapper
+		err := dec.Decode(&wr)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("session %v: %w", session, err)
+		}
+		if mcr := wr.MCR; mcr != nil {
+			res.files[localFileKey{mcr.Manager, mcr.FilePath}] = mcr
+		} else if fl := wr.FL; fl != nil {
+			res.funcs[localFuncKey{fl.FilePath, fl.FuncName}] = fl
+		}
+	}
+	return res, nil
+}
+
+func (s *localStorage) ReadLinesHitCount(ctx context.Context, ns, commit, file, manager string, tp TimePeriod,
+) ([]int64, []int64, error) {
+	if manager == "" {
+		manager = "*"
+	}
+	var res []*Coverage
+	for _, hr := range s.sessions(ns, tp) {
+		if hr.Commit != commit {
+			continue
+		}
+		sess, err := s.readSession(hr.Session)
+		if err != nil {
+			return nil, nil, err
+		}
+		if mcr := sess.files[localFileKey{manager, file}]; mcr != nil {
+			res = append(res, mcr.FileData)
+		}
+	}
+	if len(res) == 0 {
+		return nil, nil, nil
+	}
+	if len(res) > 1 {
+		return nil, nil, fmt.Errorf("more than 1 line is available")
+	}
+	return res[0].LinesInstrumented, res[0].HitCounts, nil
+}
+
+func (s *localStorage) NsDataMerged(ctx context.Context, ns string) ([]TimePeriod, []int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var periods []TimePeriod
+	var totalRows []int64
+	for _, hr := range s.history {
+		if hr.Namespace == ns {
+			periods = append(periods, TimePeriod{DateTo: hr.DateTo, Days: int(hr.Duration)})
+			totalRows = append(totalRows, hr.TotalRows)
+		}
+	}
+	return periods, totalRows, nil
+}
+
+func (s *localStorage) NsCoverage(ctx context.Context, ns string, minDays, maxDays int,
+) ([]*PeriodCoverage, error) {
+	s.mu.Lock()
+	var history []*HistoryRecord
+	for _, hr := range s.history {
+		if hr.Namespace == ns && hr.Duration >= int64(minDays) && hr.Duration <= int64(maxDays) {
+			history = append(history, hr)
+		}
+	}
+	s.mu.Unlock()
+	var res []*PeriodCoverage
+	periods := make(map[TimePeriod]*PeriodCoverage)
+	for _, hr := range history {
+		sess, err := s.readSession(hr.Session)
+		if err != nil {
+			return nil, err
+		}
+		tp := TimePeriod{DateTo: hr.DateTo, Days: int(hr.Duration)}
+		pc := periods[tp]
+		if pc == nil {
+			pc = &PeriodCoverage{TimePeriod: tp}
+			periods[tp] = pc
+			res = append(res, pc)
+		}
+		for key, mcr := range sess.files {
+			if key.manager == "*" {
+				pc.Instrumented += mcr.FileData.Instrumented
+				pc.Covered += mcr.FileData.Covered
+			}
+		}
+	}
+	return res, nil
+}
+
+// DeleteGarbage removes sessions that are not referenced by merge_history.
+// Returns the number of deleted files records.
+func (s *localStorage) DeleteGarbage(ctx context.Context) (int64, error) {
+	s.mu.Lock()
+	used := make(map[string]bool)
+	for _, hr := range s.history {
+		used[hr.Session] = true
+	}
+	s.mu.Unlock()
+	names, err := osutil.ListDir(s.sessionsDir())
+	if err != nil {
+		return 0, err
+	}
+	var deleted int64
+	for _, name := range names {
+		// Note: .tmp files belong to merges that are in progress.
+		session, ok := strings.CutSuffix(name, ".jsonl")
+		if !ok || used[session] {
+			continue
+		}
+		sess, err := s.readSession(session)
+		if err != nil {
+			return deleted, err
+		}
+		if err := os.Remove(s.sessionFile(session)); err != nil {
+			return deleted, err
+		}
+		deleted += int64(len(sess.files))
+	}
+	return deleted, nil
+}
+
+func (s *localStorage) FilesCoverageStream(ctx context.Context, scope *SelectScope,
+) (<-chan *FileCoverageWithLineInfo, <-chan error) {
+	return filesCoverageStream(ctx, s.filesQuery, scope)
+}
+
+func (s *localStorage) FilesCoverageWithDetails(ctx context.Context, scope *SelectScope, onlyUnique bool,
+) ([]*FileCoverageWithDetails, error) {
+	return filesCoverageWithDetails(ctx, s.filesQuery, scope, onlyUnique)
+}
+
+// filesQuery is the local equivalent of filesCoverageWithDetailsStmt.
+func (s *localStorage) filesQuery(scope *SelectScope, withLines bool) spannerclient.RowIterator {
+	rows, err := s.queryFiles(scope, withLines)
+	return &localRowIterator{rows: rows, err: err}
+}
+
+func (s *localStorage) queryFiles(scope *SelectScope, withLines bool) ([]*FileCoverageWithLineInfo, error) {
+	if len(scope.Periods) == 0 {
+		return nil, errors.New("no periods in the scope")
+	}
+	manager := scope.Manager
+	if manager == "" {
+		manager = "*"
+	}
+	var res []*FileCoverageWithLineInfo
+	for _, hr := range s.sessions(scope.Ns, scope.Periods[0]) {
+		sess, err := s.readSession(hr.Session)
+		if err != nil {
+			return nil, err
+		}
+		s.mu.Lock()
+		for key, mcr := range sess.files {
+			if key.manager != manager {
+				continue
+			}
+			subsystems, ok := s.subsystems[fileSubsystemsKey{scope.Ns, key.filePath}]
+			if !ok || scope.Subsystem != "" && !slices.Contains(subsystems, scope.Subsystem) {
+				continue
+			}
+			fc := &FileCoverageWithLineInfo{
+				FileCoverageWithDetails: FileCoverageWithDetails{
+					Filepath:     key.filePath,
+					Instrumented: mcr.FileData.Instrumented,
+					Covered:      mcr.FileData.Covered,
+					Commit:       hr.Commit,
+					Subsystems:   subsystems,
+				},
+			}
+			if withLines {
+				fc.LinesInstrumented = mcr.FileData.LinesInstrumented
+				fc.HitCounts = mcr.FileData.HitCounts
+			}
+			res = append(res, fc)
+		}
+		s.mu.Unlock()
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Filepath < res[j].Filepath
+	})
+	return res, nil
+}
+
+func (s *localStorage) MakeFuncFinder(ctx context.Context, ns string, tp TimePeriod) (*FunctionFinder, error) {
+	ff := &FunctionFinder{}
+	for _, hr := range s.sessions(ns, tp) {
+		sess, err := s.readSession(hr.Session)
+		if err != nil {
+			return nil, err
+		}
+		for _, fl := range sess.funcs {
+			for _, val := range fl.Lines {
+				ff.addLine(fl.FilePath, fl.FuncName, int(val))
+			}
+		}
+	}
+	return ff, nil
+}
+
+func (s *localStorage) Close() {
+}
+
+// localRowIterator implements spannerclient.RowIterator over the query results,
+// so that the query post-processing is shared with the Spanner implementation.
+type localRowIterator struct {
+	rows []*FileCoverageWithLineInfo
+	err  error
+}
+
+func (it *localRowIterator) Next() (spannerclient.Row, error) {
+	if it.err != nil {
+		return nil, it.err
+	}
+	if len(it.rows) == 0 {
+		return nil, iterator.Done
+	}
+	row := it.rows[0]
+	it.rows = it.rows[1:]
+	return localRow{row}, nil
+}
+
+func (it *localRowIterator) Stop() {
+}
+
+type localRow struct {
+	fc *FileCoverageWithLineInfo
+}
+
+func (row localRow) ToStruct(p interface{}) error {
+	switch res := p.(type) {
+	case *FileCoverageWithLineInfo:
+		*res = *row.fc
+	case *FileCoverageWithDetails:
+		*res = row.fc.FileCoverageWithDetails
+	default:
+		return fmt.Errorf("unsupported row type %T", p)
+	}
+	return nil
+}
+
+func readJSONFile(file string, v any) error {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %v: %w", file, err)
+	}
+	return nil
+}
+
+func writeJSONFile(file string, v any) error {
+	data, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return err
+	}
+	return osutil.WriteFileAtomically(file, data)
+}
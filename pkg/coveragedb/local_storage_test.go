@@ -0,0 +1,141 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package coveragedb
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/google/syzkaller/pkg/subsystem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	storage, err := NewLocalStorage(dir)
+	assert.NoError(t, err)
+
+	day := TimePeriod{DateTo: civil.Date{Year: 2025, Month: 1, Day: 1}, Days: 1}
+	sss := []*subsystem.Subsystem{
+		{Name: "mm", PathRules: []subsystem.PathRule{{IncludeRegexp: "^mm/"}}},
+		{Name: "net", PathRules: []subsystem.PathRule{{IncludeRegexp: "^net/"}}},
+	}
+	save := func(commit, jsonl string) int {
+		rows, err := storage.SaveMergeResult(ctx, &HistoryRecord{
+			Namespace: "upstream",
+			Repo:      "repo",
+			Commit:    commit,
+			Duration:  int64(day.Days),
+			DateTo:    day.DateTo,
+			TotalRows: 100,
+		}, json.NewDecoder(strings.NewReader(jsonl)), sss)
+		assert.NoError(t, err)
+		return rows
+	}
+	// The first merge is replaced by the second one for the same period.
+	assert.Equal(t, 3, save("commit0", `{"MCR":{"Manager":"*","FilePath":"mm/a.c","FileData":{}}}`))
+	rows := save("commit1", `
+{"MCR":{"Manager":"*","FilePath":"net/b.c",
+	"FileData":{"Instrumented":2,"Covered":1,"LinesInstrumented":[1,2],"HitCounts":[0,5]}}}
+{"MCR":{"Manager":"*","FilePath":"mm/a.c",
+	"FileData":{"Instrumented":3,"Covered":3,"LinesInstrumented":[1,2,3],"HitCounts":[1,2,3]}}}
+{"MCR":{"Manager":"mgr","FilePath":"mm/a.c",
+	"FileData":{"Instrumented":3,"Covered":2,"LinesInstrumented":[1,2,3],"HitCounts":[1,2,0]}}}
+{"FL":{"FilePath":"mm/a.c","FuncName":"foo","Lines":[1,2]}}
+`)
+	assert.Equal(t, 8, rows)
+
+	// Reopen the database to check that everything is persisted.
+	storage.Close()
+	storage, err = NewLocalStorage(dir)
+	assert.NoError(t, err)
+
+	periods, totalRows, err := storage.NsDataMerged(ctx, "upstream")
+	assert.NoError(t, err)
+	assert.Equal(t, []TimePeriod{day}, periods)
+	assert.Equal(t, []int64{100}, totalRows)
+
+	nsCov, err := storage.NsCoverage(ctx, "upstream", 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*PeriodCoverage{{TimePeriod: day, Instrumented: 5, Covered: 4}}, nsCov)
+	nsCov, err = storage.NsCoverage(ctx, "upstream", 28, 31)
+	assert.NoError(t, err)
+	assert.Empty(t, nsCov)
+
+	lines, hits, err := storage.ReadLinesHitCount(ctx, "upstream", "commit1", "mm/a.c", "mgr", day)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, lines)
+	assert.Equal(t, []int64{1, 2, 0}, hits)
+	lines, _, err = storage.ReadLinesHitCount(ctx, "upstream", "commit0", "mm/a.c", "", day)
+	assert.NoError(t, err)
+	assert.Nil(t, lines)
+
+	details, err := storage.FilesCoverageWithDetails(ctx, &SelectScope{
+		Ns:      "upstream",
+		Periods: []TimePeriod{day},
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []*FileCoverageWithDetails{
+		{
+			Filepath:     "mm/a.c",
+			Instrumented: 3,
+			Covered:      3,
+			TimePeriod:   day,
+			Commit:       "commit1",
+			Subsystems:   []string{"mm"},
+		},
+		{
+			Filepath:     "net/b.c",
+			Instrumented: 2,
+			Covered:      1,
+			TimePeriod:   day,
+			Commit:       "commit1",
+			Subsystems:   []string{"net"},
+		},
+	}, details)
+
+	// Only lines 1 and 2 of mm/a.c are covered by mgr alone.
+	details, err = storage.FilesCoverageWithDetails(ctx, &SelectScope{
+		Ns:        "upstream",
+		Subsystem: "mm",
+		Manager:   "mgr",
+		Periods:   []TimePeriod{day},
+	}, true)
+	assert.NoError(t, err)
+	assert.Len(t, details, 1)
+	assert.Equal(t, int64(2), details[0].Covered)
+
+	covCh, errCh := storage.FilesCoverageStream(ctx, &SelectScope{
+		Ns:        "upstream",
+		Subsystem: "net",
+		Periods:   []TimePeriod{day},
+	})
+	var streamed []*FileCoverageWithLineInfo
+	for fc := range covCh {
+		streamed = append(streamed, fc)
+	}
+	assert.NoError(t, <-errCh)
+	assert.Len(t, streamed, 1)
+	assert.Equal(t, map[int]int64{1: 0, 2: 5}, streamed[0].CovMap())
+
+	ff, err := storage.MakeFuncFinder(ctx, "upstream", day)
+	assert.NoError(t, err)
+	name, err := ff.FileLineToFuncName("mm/a.c", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo", name)
+
+	deleted, err := storage.DeleteGarbage(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	deleted, err = storage.DeleteGarbage(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	_, err = storage.SaveMergeResult(ctx, &HistoryRecord{}, json.NewDecoder(strings.NewReader(`{}`)), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,79 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package coveragedb
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/syzkaller/pkg/coveragedb/spannerclient"
+	"github.com/google/syzkaller/pkg/subsystem"
+)
+
+// Storage is the coverage database.
+// NewSpannerStorage returns the Spanner-backed implementation used by syzbot,
+// NewLocalStorage returns the implementation that keeps the data in a local directory.
+// Both have the same merge and query semantics, see the package functions with the same names.
+type Storage interface {
+	SaveMergeResult(ctx context.Context, descr *HistoryRecord, dec *json.Decoder,
+		sss []*subsystem.Subsystem) (int, error)
+	ReadLinesHitCount(ctx context.Context, ns, commit, file, manager string, tp TimePeriod) ([]int64, []int64, error)
+	NsDataMerged(ctx context.Context, ns string) ([]TimePeriod, []int64, error)
+	NsCoverage(ctx context.Context, ns string, minDays, maxDays int) ([]*PeriodCoverage, error)
+	DeleteGarbage(ctx context.Context) (int64, error)
+	FilesCoverageStream(ctx context.Context, scope *SelectScope) (<-chan *FileCoverageWithLineInfo, <-chan error)
+	FilesCoverageWithDetails(ctx context.Context, scope *SelectScope, onlyUnique bool) (
+		[]*FileCoverageWithDetails, error)
+	MakeFuncFinder(ctx context.Context, ns string, tp TimePeriod) (*FunctionFinder, error)
+	Close()
+}
+
+func NewSpannerStorage(client spannerclient.SpannerClient) Storage {
+	return &spannerStorage{client}
+}
+
+type spannerStorage struct {
+	client spannerclient.SpannerClient
+}
+
+func (s *spannerStorage) SaveMergeResult(ctx context.Context, descr *HistoryRecord, dec *json.Decoder,
+	sss []*subsystem.Subsystem) (int, error) {
+	return SaveMergeResult(ctx, s.client, descr, dec, sss)
+}
+
+func (s *spannerStorage) ReadLinesHitCount(ctx context.Context, ns, commit, file, manager string, tp TimePeriod,
+) ([]int64, []int64, error) {
+	return ReadLinesHitCount(ctx, s.client, ns, commit, file, manager, tp)
+}
+
+func (s *spannerStorage) NsDataMerged(ctx context.Context, ns string) ([]TimePeriod, []int64, error) {
+	return NsDataMerged(ctx, s.client, ns)
+}
+
+func (s *spannerStorage) NsCoverage(ctx context.Context, ns string, minDays, maxDays int,
+) ([]*PeriodCoverage, error) {
+	return NsCoverage(ctx, s.client, ns, minDays, maxDays)
+}
+
+func (s *spannerStorage) DeleteGarbage(ctx context.Context) (int64, error) {
+	return DeleteGarbage(ctx, s.client)
+}
+
+func (s *spannerStorage) FilesCoverageStream(ctx context.Context, scope *SelectScope,
+) (<-chan *FileCoverageWithLineInfo, <-chan error) {
+	return FilesCoverageStream(ctx, s.client, scope)
+}
+
+func (s *spannerStorage) FilesCoverageWithDetails(ctx context.Context, scope *SelectScope, onlyUnique bool,
+) ([]*FileCoverageWithDetails, error) {
+	return FilesCoverageWithDetails(ctx, s.client, scope, onlyUnique)
+}
+
+func (s *spannerStorage) MakeFuncFinder(ctx context.Context, ns string, tp TimePeriod) (*FunctionFinder, error) {
+	return MakeFuncFinder(ctx, s.client, ns, tp)
+}
+
+func (s *spannerStorage) Close() {
+	s.client.Close()
+}
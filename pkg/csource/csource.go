@@ -52,12 +52,55 @@ func Write(p *prog.Prog, opts Options) ([]byte, error) {
 	return ctx.generateSource()
 }
 
+// WriteParallel generates C source that executes several programs concurrently,
+// each program is executed by a separate process (the program is selected by procid).
+// It's used for bugs that are triggered only by an interaction of several programs.
+// opts.Procs is increased to the number of programs if necessary (so opts.Repeat is required).
+func WriteParallel(progs []*prog.Prog, opts Options) ([]byte, error) {
+	if len(progs) == 0 {
+		return nil, fmt.Errorf("csource: no programs")
+	}
+	if len(progs) == 1 {
+		return Write(progs[0], opts)
+	}
+	opts.Procs = max(opts.Procs, len(progs))
+	target := progs[0].Target
+	if err := opts.Check(target.OS); err != nil {
+		return nil, fmt.Errorf("csource: invalid opts: %w", err)
+	}
+	p := &prog.Prog{
+		Target: target,
+	}
+	var owners []int
+	for i, p1 := range progs {
+		// Resources are not shared between the programs, so the concatenated program
+		// can be serialized as a whole, but each process executes only its own calls.
+		p.Calls = append(p.Calls, p1.Clone().Calls...)
+		for range p1.Calls {
+			owners = append(owners, i)
+		}
+	}
+	ctx := &context{
+		p:         p,
+		opts:      opts,
+		target:    target,
+		sysTarget: targets.Get(target.OS, target.Arch),
+		calls:     make(map[string]uint64),
+		owners:    owners,
+		parallel:  len(progs),
+	}
+	return ctx.generateSource()
+}
+
 type context struct {
 	p         *prog.Prog
 	opts      Options
 	target    *prog.Target
 	sysTarget *targets.Target
 	calls     map[string]uint64 // CallName -> NR
+	// For WriteParallel: index of the program each call belongs to, and the number of programs.
+	owners   []int
+	parallel int
 }
 
 func generateSandboxFunctionSignature(sandboxName string, sandboxArg int) string {
@@ -177,6 +220,9 @@ func (ctx *context) filterCalls() {
 			p = ctx.p.Clone()
 		}
 		p.RemoveCall(i)
+		if ctx.owners != nil {
+			ctx.owners = append(ctx.owners[:i:i], ctx.owners[i+1:]...)
+		}
 	}
 	ctx.p = p
 }
@@ -192,8 +238,21 @@ func (ctx *context) generateSyscalls(calls []string, hasVars bool) string {
 		if opts.Trace {
 			fmt.Fprintf(buf, "\tfprintf(stderr, \"### start\\n\");\n")
 		}
-		for _, c := range calls {
-			fmt.Fprintf(buf, "%s", c)
+		for i, c := range calls {
+			if ctx.owners == nil {
+				fmt.Fprintf(buf, "%s", c)
+				continue
+			}
+			if i == 0 || ctx.owners[i] != ctx.owners[i-1] {
+				if i != 0 {
+					fmt.Fprintf(buf, "\t}\n")
+				}
+				fmt.Fprintf(buf, "\tif (procid %% %v == %v) {\n", ctx.parallel, ctx.owners[i])
+			}
+			fmt.Fprintf(buf, "%s", strings.ReplaceAll(c, "\t", "\t\t"))
+		}
+		if ctx.owners != nil && len(calls) != 0 {
+			fmt.Fprintf(buf, "\t}\n")
 		}
 	} else if len(calls) > 0 {
 		if hasVars || opts.Trace {
@@ -202,6 +261,9 @@ func (ctx *context) generateSyscalls(calls []string, hasVars bool) string {
 		fmt.Fprintf(buf, "\tswitch (call) {\n")
 		for i, c := range calls {
 			fmt.Fprintf(buf, "\tcase %v:\n", i)
+			if ctx.owners != nil {
+				fmt.Fprintf(buf, "\t\tif (procid %% %v != %v)\n\t\t\tbreak;\n", ctx.parallel, ctx.owners[i])
+			}
 			fmt.Fprintf(buf, "%s", strings.ReplaceAll(c, "\t", "\t\t"))
 			fmt.Fprintf(buf, "\t\tbreak;\n")
 		}
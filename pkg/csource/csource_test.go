@@ -297,6 +297,45 @@ syscall(SYS_csource8, /*num=*/(intptr_t)-1);
 	}
 }
 
+func TestWriteParallel(t *testing.T) {
+	t.Parallel()
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS != targets.Get(target.OS, target.Arch).BuildOS {
+		t.Skip("can't build the test target")
+	}
+	var progs []*prog.Prog
+	for _, text := range []string{"r0 = csource0(0x1)\ncsource1(r0)\n", "csource0(0x2)\n"} {
+		p, err := target.Deserialize([]byte(text), prog.Strict)
+		if err != nil {
+			t.Fatal(err)
+		}
+		progs = append(progs, p)
+	}
+	for i, opts := range []Options{
+		{Repeat: true, Slowdown: 1},
+		{Threaded: true, Repeat: true, Procs: 1, Sandbox: "none", Slowdown: 1},
+		{Repeat: true, Procs: 4, Sandbox: "none", Slowdown: 1},
+	} {
+		t.Run(fmt.Sprint(i), func(t *testing.T) {
+			src, err := WriteParallel(progs, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !regexp.MustCompile(`procid % 2 [!=]= 1`).Match(src) {
+				t.Fatalf("no per-program dispatch in the source:\n%s", src)
+			}
+			bin, err := Build(target, src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			os.Remove(bin)
+		})
+	}
+}
+
 func generateSandboxFunctionSignatureTestCase(t *testing.T, sandbox string, sandboxArg int, expected, message string) {
 	actual := generateSandboxFunctionSignature(sandbox, sandboxArg)
 	assert.Equal(t, actual, expected, message)
@@ -225,7 +225,8 @@ func verifyExecutorMessage(raw *ExecutorMessageRaw, rawSize int) error {
 	switch typ := raw.MsgType(); typ {
 	case ExecutorMessagesRawExecResult,
 		ExecutorMessagesRawExecuting,
-		ExecutorMessagesRawState:
+		ExecutorMessagesRawState,
+		ExecutorMessagesRawHeartbeat:
 	default:
 		return fmt.Errorf("bad executor message type %v", typ)
 	}
@@ -233,11 +234,21 @@ func verifyExecutorMessage(raw *ExecutorMessageRaw, rawSize int) error {
 	if !raw.Msg(&tab) {
 		return errors.New("received no message")
 	}
-	// Only ExecResult has arrays.
-	if raw.MsgType() == ExecutorMessagesRawExecResult {
+	// Only ExecResult and Heartbeat have arrays.
+	switch raw.MsgType() {
+	case ExecutorMessagesRawExecResult:
 		var res ExecResultRaw
 		res.Init(tab.Bytes, tab.Pos)
 		return verifyExecResult(&res, rawSize)
+	case ExecutorMessagesRawHeartbeat:
+		var heartbeat HeartbeatRaw
+		heartbeat.Init(tab.Bytes, tab.Pos)
+		// Calls are structs that are stored inline, so they can't be larger than the message.
+		const callSize = 24
+		if heartbeat.CallsLength() > rawSize/callSize {
+			return fmt.Errorf("corrupted message: total size %v, heartbeat calls %v",
+				rawSize, heartbeat.CallsLength())
+		}
 	}
 	return nil
 }
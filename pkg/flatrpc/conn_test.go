@@ -114,6 +114,31 @@ func TestConn(t *testing.T) {
 	}
 }
 
+func TestHeartbeat(t *testing.T) {
+	msg := &ExecutorMessage{
+		Msg: &ExecutorMessages{
+			Type: ExecutorMessagesRawHeartbeat,
+			Value: &Heartbeat{
+				Calls: []*ExecutingCall{
+					{Id: 1, Proc: 2, Call: 3, Duration: 4},
+					{Id: 5, Proc: 6, Call: 7, Duration: 8},
+				},
+			},
+		},
+	}
+	builder := flatbuffers.NewBuilder(0)
+	builder.Finish(msg.Pack(builder))
+	raw := GetRootAsExecutorMessageRaw(builder.FinishedBytes(), 0)
+	assert.NoError(t, verify(raw, len(builder.FinishedBytes())))
+	assert.Equal(t, msg, raw.UnPack())
+	// Idle executors send heartbeats without calls.
+	msg.Msg.Value = &Heartbeat{}
+	builder.Reset()
+	builder.Finish(msg.Pack(builder))
+	raw = GetRootAsExecutorMessageRaw(builder.FinishedBytes(), 0)
+	assert.NoError(t, verify(raw, len(builder.FinishedBytes())))
+}
+
 func BenchmarkConn(b *testing.B) {
 	connectHello := &ConnectHello{
 		Cookie: 1,
@@ -199,6 +199,7 @@ const (
 	ExecutorMessagesRawExecResult ExecutorMessagesRaw = 1
 	ExecutorMessagesRawExecuting  ExecutorMessagesRaw = 2
 	ExecutorMessagesRawState      ExecutorMessagesRaw = 3
+	ExecutorMessagesRawHeartbeat  ExecutorMessagesRaw = 4
 )
 
 var EnumNamesExecutorMessagesRaw = map[ExecutorMessagesRaw]string{
@@ -206,6 +207,7 @@ var EnumNamesExecutorMessagesRaw = map[ExecutorMessagesRaw]string{
 	ExecutorMessagesRawExecResult: "ExecResult",
 	ExecutorMessagesRawExecuting:  "Executing",
 	ExecutorMessagesRawState:      "State",
+	ExecutorMessagesRawHeartbeat:  "Heartbeat",
 }
 
 var EnumValuesExecutorMessagesRaw = map[string]ExecutorMessagesRaw{
@@ -213,6 +215,7 @@ var EnumValuesExecutorMessagesRaw = map[string]ExecutorMessagesRaw{
 	"ExecResult": ExecutorMessagesRawExecResult,
 	"Executing":  ExecutorMessagesRawExecuting,
 	"State":      ExecutorMessagesRawState,
+	"Heartbeat":  ExecutorMessagesRawHeartbeat,
 }
 
 func (v ExecutorMessagesRaw) String() string {
@@ -238,6 +241,8 @@ func (t *ExecutorMessagesRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UO
 		return t.Value.(*ExecutingMessageRawT).Pack(builder)
 	case ExecutorMessagesRawState:
 		return t.Value.(*StateResultRawT).Pack(builder)
+	case ExecutorMessagesRawHeartbeat:
+		return t.Value.(*HeartbeatRawT).Pack(builder)
 	}
 	return 0
 }
@@ -253,6 +258,9 @@ func (rcv ExecutorMessagesRaw) UnPack(table flatbuffers.Table) *ExecutorMessages
 	case ExecutorMessagesRawState:
 		x := StateResultRaw{_tab: table}
 		return &ExecutorMessagesRawT{Type: ExecutorMessagesRawState, Value: x.UnPack()}
+	case ExecutorMessagesRawHeartbeat:
+		x := HeartbeatRaw{_tab: table}
+		return &ExecutorMessagesRawT{Type: ExecutorMessagesRawHeartbeat, Value: x.UnPack()}
 	}
 	return nil
 }
@@ -388,6 +396,7 @@ const (
 	ExecFlagDedupCover    ExecFlag = 4
 	ExecFlagCollectComps  ExecFlag = 8
 	ExecFlagThreaded      ExecFlag = 16
+	ExecFlagCollectTrace  ExecFlag = 32
 )
 
 var EnumNamesExecFlag = map[ExecFlag]string{
@@ -396,6 +405,7 @@ var EnumNamesExecFlag = map[ExecFlag]string{
 	ExecFlagDedupCover:    "DedupCover",
 	ExecFlagCollectComps:  "CollectComps",
 	ExecFlagThreaded:      "Threaded",
+	ExecFlagCollectTrace:  "CollectTrace",
 }
 
 var EnumValuesExecFlag = map[string]ExecFlag{
@@ -404,6 +414,7 @@ var EnumValuesExecFlag = map[string]ExecFlag{
 	"DedupCover":    ExecFlagDedupCover,
 	"CollectComps":  ExecFlagCollectComps,
 	"Threaded":      ExecFlagThreaded,
+	"CollectTrace":  ExecFlagCollectTrace,
 }
 
 func (v ExecFlag) String() string {
@@ -2551,6 +2562,7 @@ type CallInfoRawT struct {
 	Signal []uint64          `json:"signal"`
 	Cover  []uint64          `json:"cover"`
 	Comps  []*ComparisonRawT `json:"comps"`
+	Trace  *CallTraceRawT    `json:"trace"`
 }
 
 func (t *CallInfoRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -2584,12 +2596,14 @@ func (t *CallInfoRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 		}
 		compsOffset = builder.EndVector(compsLength)
 	}
+	traceOffset := t.Trace.Pack(builder)
 	CallInfoRawStart(builder)
 	CallInfoRawAddFlags(builder, t.Flags)
 	CallInfoRawAddError(builder, t.Error)
 	CallInfoRawAddSignal(builder, signalOffset)
 	CallInfoRawAddCover(builder, coverOffset)
 	CallInfoRawAddComps(builder, compsOffset)
+	CallInfoRawAddTrace(builder, traceOffset)
 	return CallInfoRawEnd(builder)
 }
 
@@ -2613,6 +2627,7 @@ func (rcv *CallInfoRaw) UnPackTo(t *CallInfoRawT) {
 		rcv.Comps(&x, j)
 		t.Comps[j] = x.UnPack()
 	}
+	t.Trace = rcv.Trace(nil).UnPack()
 }
 
 func (rcv *CallInfoRaw) UnPack() *CallInfoRawT {
@@ -2746,8 +2761,21 @@ func (rcv *CallInfoRaw) CompsLength() int {
 	return 0
 }
 
+func (rcv *CallInfoRaw) Trace(obj *CallTraceRaw) *CallTraceRaw {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		x := rcv._tab.Indirect(o + rcv._tab.Pos)
+		if obj == nil {
+			obj = new(CallTraceRaw)
+		}
+		obj.Init(rcv._tab.Bytes, x)
+		return obj
+	}
+	return nil
+}
+
 func CallInfoRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(5)
+	builder.StartObject(6)
 }
 func CallInfoRawAddFlags(builder *flatbuffers.Builder, flags CallFlag) {
 	builder.PrependByteSlot(0, byte(flags), 0)
@@ -2773,6 +2801,9 @@ func CallInfoRawAddComps(builder *flatbuffers.Builder, comps flatbuffers.UOffset
 func CallInfoRawStartCompsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
 	return builder.StartVector(32, numElems, 8)
 }
+func CallInfoRawAddTrace(builder *flatbuffers.Builder, trace flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(5, flatbuffers.UOffsetT(trace), 0)
+}
 func CallInfoRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -2857,12 +2888,357 @@ func CreateComparisonRaw(builder *flatbuffers.Builder, pc uint64, op1 uint64, op
 	return builder.Offset()
 }
 
+type CallTraceRawT struct {
+	Args    []uint64          `json:"args"`
+	Res     uint64            `json:"res"`
+	Buffers []*CallBufferRawT `json:"buffers"`
+}
+
+func (t *CallTraceRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	argsOffset := flatbuffers.UOffsetT(0)
+	if t.Args != nil {
+		argsLength := len(t.Args)
+		CallTraceRawStartArgsVector(builder, argsLength)
+		for j := argsLength - 1; j >= 0; j-- {
+			builder.PrependUint64(t.Args[j])
+		}
+		argsOffset = builder.EndVector(argsLength)
+	}
+	buffersOffset := flatbuffers.UOffsetT(0)
+	if t.Buffers != nil {
+		buffersLength := len(t.Buffers)
+		buffersOffsets := make([]flatbuffers.UOffsetT, buffersLength)
+		for j := 0; j < buffersLength; j++ {
+			buffersOffsets[j] = t.Buffers[j].Pack(builder)
+		}
+		CallTraceRawStartBuffersVector(builder, buffersLength)
+		for j := buffersLength - 1; j >= 0; j-- {
+			builder.PrependUOffsetT(buffersOffsets[j])
+		}
+		buffersOffset = builder.EndVector(buffersLength)
+	}
+	CallTraceRawStart(builder)
+	CallTraceRawAddArgs(builder, argsOffset)
+	CallTraceRawAddRes(builder, t.Res)
+	CallTraceRawAddBuffers(builder, buffersOffset)
+	return CallTraceRawEnd(builder)
+}
+
+func (rcv *CallTraceRaw) UnPackTo(t *CallTraceRawT) {
+	argsLength := rcv.ArgsLength()
+	t.Args = make([]uint64, argsLength)
+	for j := 0; j < argsLength; j++ {
+		t.Args[j] = rcv.Args(j)
+	}
+	t.Res = rcv.Res()
+	buffersLength := rcv.BuffersLength()
+	t.Buffers = make([]*CallBufferRawT, buffersLength)
+	for j := 0; j < buffersLength; j++ {
+		x := CallBufferRaw{}
+		rcv.Buffers(&x, j)
+		t.Buffers[j] = x.UnPack()
+	}
+}
+
+func (rcv *CallTraceRaw) UnPack() *CallTraceRawT {
+	if rcv == nil {
+		return nil
+	}
+	t := &CallTraceRawT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
+type CallTraceRaw struct {
+	_tab flatbuffers.Table
+}
+
+func GetRootAsCallTraceRaw(buf []byte, offset flatbuffers.UOffsetT) *CallTraceRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	x := &CallTraceRaw{}
+	x.Init(buf, n+offset)
+	return x
+}
+
+func GetSizePrefixedRootAsCallTraceRaw(buf []byte, offset flatbuffers.UOffsetT) *CallTraceRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset+flatbuffers.SizeUint32:])
+	x := &CallTraceRaw{}
+	x.Init(buf, n+offset+flatbuffers.SizeUint32)
+	return x
+}
+
+func (rcv *CallTraceRaw) Init(buf []byte, i flatbuffers.UOffsetT) {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+}
+
+func (rcv *CallTraceRaw) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *CallTraceRaw) Args(j int) uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetUint64(a + flatbuffers.UOffsetT(j*8))
+	}
+	return 0
+}
+
+func (rcv *CallTraceRaw) ArgsLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *CallTraceRaw) MutateArgs(j int, n uint64) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateUint64(a+flatbuffers.UOffsetT(j*8), n)
+	}
+	return false
+}
+
+func (rcv *CallTraceRaw) Res() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *CallTraceRaw) MutateRes(n uint64) bool {
+	return rcv._tab.MutateUint64Slot(6, n)
+}
+
+func (rcv *CallTraceRaw) Buffers(obj *CallBufferRaw, j int) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 4
+		x = rcv._tab.Indirect(x)
+		obj.Init(rcv._tab.Bytes, x)
+		return true
+	}
+	return false
+}
+
+func (rcv *CallTraceRaw) BuffersLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func CallTraceRawStart(builder *flatbuffers.Builder) {
+	builder.StartObject(3)
+}
+func CallTraceRawAddArgs(builder *flatbuffers.Builder, args flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(args), 0)
+}
+func CallTraceRawStartArgsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(8, numElems, 8)
+}
+func CallTraceRawAddRes(builder *flatbuffers.Builder, res uint64) {
+	builder.PrependUint64Slot(1, res, 0)
+}
+func CallTraceRawAddBuffers(builder *flatbuffers.Builder, buffers flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(buffers), 0)
+}
+func CallTraceRawStartBuffersVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(4, numElems, 4)
+}
+func CallTraceRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
+type CallBufferRawT struct {
+	Arg    int32  `json:"arg"`
+	Before []byte `json:"before"`
+	After  []byte `json:"after"`
+}
+
+func (t *CallBufferRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	beforeOffset := flatbuffers.UOffsetT(0)
+	if t.Before != nil {
+		beforeOffset = builder.CreateByteString(t.Before)
+	}
+	afterOffset := flatbuffers.UOffsetT(0)
+	if t.After != nil {
+		afterOffset = builder.CreateByteString(t.After)
+	}
+	CallBufferRawStart(builder)
+	CallBufferRawAddArg(builder, t.Arg)
+	CallBufferRawAddBefore(builder, beforeOffset)
+	CallBufferRawAddAfter(builder, afterOffset)
+	return CallBufferRawEnd(builder)
+}
+
+func (rcv *CallBufferRaw) UnPackTo(t *CallBufferRawT) {
+	t.Arg = rcv.Arg()
+	t.Before = rcv.BeforeBytes()
+	t.After = rcv.AfterBytes()
+}
+
+func (rcv *CallBufferRaw) UnPack() *CallBufferRawT {
+	if rcv == nil {
+		return nil
+	}
+	t := &CallBufferRawT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
+type CallBufferRaw struct {
+	_tab flatbuffers.Table
+}
+
+func GetRootAsCallBufferRaw(buf []byte, offset flatbuffers.UOffsetT) *CallBufferRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	x := &CallBufferRaw{}
+	x.Init(buf, n+offset)
+	return x
+}
+
+func GetSizePrefixedRootAsCallBufferRaw(buf []byte, offset flatbuffers.UOffsetT) *CallBufferRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset+flatbuffers.SizeUint32:])
+	x := &CallBufferRaw{}
+	x.Init(buf, n+offset+flatbuffers.SizeUint32)
+	return x
+}
+
+func (rcv *CallBufferRaw) Init(buf []byte, i flatbuffers.UOffsetT) {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+}
+
+func (rcv *CallBufferRaw) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *CallBufferRaw) Arg() int32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.GetInt32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *CallBufferRaw) MutateArg(n int32) bool {
+	return rcv._tab.MutateInt32Slot(4, n)
+}
+
+func (rcv *CallBufferRaw) Before(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *CallBufferRaw) BeforeLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *CallBufferRaw) BeforeBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CallBufferRaw) MutateBefore(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(6))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+func (rcv *CallBufferRaw) After(j int) byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.GetByte(a + flatbuffers.UOffsetT(j*1))
+	}
+	return 0
+}
+
+func (rcv *CallBufferRaw) AfterLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func (rcv *CallBufferRaw) AfterBytes() []byte {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		return rcv._tab.ByteVector(o + rcv._tab.Pos)
+	}
+	return nil
+}
+
+func (rcv *CallBufferRaw) MutateAfter(j int, n byte) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(8))
+	if o != 0 {
+		a := rcv._tab.Vector(o)
+		return rcv._tab.MutateByte(a+flatbuffers.UOffsetT(j*1), n)
+	}
+	return false
+}
+
+func CallBufferRawStart(builder *flatbuffers.Builder) {
+	builder.StartObject(3)
+}
+func CallBufferRawAddArg(builder *flatbuffers.Builder, arg int32) {
+	builder.PrependInt32Slot(0, arg, 0)
+}
+func CallBufferRawAddBefore(builder *flatbuffers.Builder, before flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(1, flatbuffers.UOffsetT(before), 0)
+}
+func CallBufferRawStartBeforeVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
+func CallBufferRawAddAfter(builder *flatbuffers.Builder, after flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(2, flatbuffers.UOffsetT(after), 0)
+}
+func CallBufferRawStartAfterVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(1, numElems, 1)
+}
+func CallBufferRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
 type ProgInfoRawT struct {
-	Calls     []*CallInfoRawT `json:"calls"`
-	ExtraRaw  []*CallInfoRawT `json:"extra_raw"`
-	Extra     *CallInfoRawT   `json:"extra"`
-	Elapsed   uint64          `json:"elapsed"`
-	Freshness uint64          `json:"freshness"`
+	Calls      []*CallInfoRawT `json:"calls"`
+	ExtraRaw   []*CallInfoRawT `json:"extra_raw"`
+	Extra      *CallInfoRawT   `json:"extra"`
+	Elapsed    uint64          `json:"elapsed"`
+	Freshness  uint64          `json:"freshness"`
+	PeakRss    uint64          `json:"peak_rss"`
+	OpenFds    uint32          `json:"open_fds"`
+	UserTime   uint64          `json:"user_time"`
+	SystemTime uint64          `json:"system_time"`
+	PageFaults uint64          `json:"page_faults"`
 }
 
 func (t *ProgInfoRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
@@ -2902,6 +3278,11 @@ func (t *ProgInfoRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	ProgInfoRawAddExtra(builder, extraOffset)
 	ProgInfoRawAddElapsed(builder, t.Elapsed)
 	ProgInfoRawAddFreshness(builder, t.Freshness)
+	ProgInfoRawAddPeakRss(builder, t.PeakRss)
+	ProgInfoRawAddOpenFds(builder, t.OpenFds)
+	ProgInfoRawAddUserTime(builder, t.UserTime)
+	ProgInfoRawAddSystemTime(builder, t.SystemTime)
+	ProgInfoRawAddPageFaults(builder, t.PageFaults)
 	return ProgInfoRawEnd(builder)
 }
 
@@ -2923,6 +3304,11 @@ func (rcv *ProgInfoRaw) UnPackTo(t *ProgInfoRawT) {
 	t.Extra = rcv.Extra(nil).UnPack()
 	t.Elapsed = rcv.Elapsed()
 	t.Freshness = rcv.Freshness()
+	t.PeakRss = rcv.PeakRss()
+	t.OpenFds = rcv.OpenFds()
+	t.UserTime = rcv.UserTime()
+	t.SystemTime = rcv.SystemTime()
+	t.PageFaults = rcv.PageFaults()
 }
 
 func (rcv *ProgInfoRaw) UnPack() *ProgInfoRawT {
@@ -3038,8 +3424,68 @@ func (rcv *ProgInfoRaw) MutateFreshness(n uint64) bool {
 	return rcv._tab.MutateUint64Slot(12, n)
 }
 
+func (rcv *ProgInfoRaw) PeakRss() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(14))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ProgInfoRaw) MutatePeakRss(n uint64) bool {
+	return rcv._tab.MutateUint64Slot(14, n)
+}
+
+func (rcv *ProgInfoRaw) OpenFds() uint32 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(16))
+	if o != 0 {
+		return rcv._tab.GetUint32(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ProgInfoRaw) MutateOpenFds(n uint32) bool {
+	return rcv._tab.MutateUint32Slot(16, n)
+}
+
+func (rcv *ProgInfoRaw) UserTime() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(18))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ProgInfoRaw) MutateUserTime(n uint64) bool {
+	return rcv._tab.MutateUint64Slot(18, n)
+}
+
+func (rcv *ProgInfoRaw) SystemTime() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(20))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ProgInfoRaw) MutateSystemTime(n uint64) bool {
+	return rcv._tab.MutateUint64Slot(20, n)
+}
+
+func (rcv *ProgInfoRaw) PageFaults() uint64 {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(22))
+	if o != 0 {
+		return rcv._tab.GetUint64(o + rcv._tab.Pos)
+	}
+	return 0
+}
+
+func (rcv *ProgInfoRaw) MutatePageFaults(n uint64) bool {
+	return rcv._tab.MutateUint64Slot(22, n)
+}
+
 func ProgInfoRawStart(builder *flatbuffers.Builder) {
-	builder.StartObject(5)
+	builder.StartObject(10)
 }
 func ProgInfoRawAddCalls(builder *flatbuffers.Builder, calls flatbuffers.UOffsetT) {
 	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(calls), 0)
@@ -3062,6 +3508,21 @@ func ProgInfoRawAddElapsed(builder *flatbuffers.Builder, elapsed uint64) {
 func ProgInfoRawAddFreshness(builder *flatbuffers.Builder, freshness uint64) {
 	builder.PrependUint64Slot(4, freshness, 0)
 }
+func ProgInfoRawAddPeakRss(builder *flatbuffers.Builder, peakRss uint64) {
+	builder.PrependUint64Slot(5, peakRss, 0)
+}
+func ProgInfoRawAddOpenFds(builder *flatbuffers.Builder, openFds uint32) {
+	builder.PrependUint32Slot(6, openFds, 0)
+}
+func ProgInfoRawAddUserTime(builder *flatbuffers.Builder, userTime uint64) {
+	builder.PrependUint64Slot(7, userTime, 0)
+}
+func ProgInfoRawAddSystemTime(builder *flatbuffers.Builder, systemTime uint64) {
+	builder.PrependUint64Slot(8, systemTime, 0)
+}
+func ProgInfoRawAddPageFaults(builder *flatbuffers.Builder, pageFaults uint64) {
+	builder.PrependUint64Slot(9, pageFaults, 0)
+}
 func ProgInfoRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
@@ -3363,6 +3824,185 @@ func StateResultRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
 	return builder.EndObject()
 }
 
+type HeartbeatRawT struct {
+	Calls []*ExecutingCallRawT `json:"calls"`
+}
+
+func (t *HeartbeatRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	callsOffset := flatbuffers.UOffsetT(0)
+	if t.Calls != nil {
+		callsLength := len(t.Calls)
+		HeartbeatRawStartCallsVector(builder, callsLength)
+		for j := callsLength - 1; j >= 0; j-- {
+			t.Calls[j].Pack(builder)
+		}
+		callsOffset = builder.EndVector(callsLength)
+	}
+	HeartbeatRawStart(builder)
+	HeartbeatRawAddCalls(builder, callsOffset)
+	return HeartbeatRawEnd(builder)
+}
+
+func (rcv *HeartbeatRaw) UnPackTo(t *HeartbeatRawT) {
+	callsLength := rcv.CallsLength()
+	t.Calls = make([]*ExecutingCallRawT, callsLength)
+	for j := 0; j < callsLength; j++ {
+		x := ExecutingCallRaw{}
+		rcv.Calls(&x, j)
+		t.Calls[j] = x.UnPack()
+	}
+}
+
+func (rcv *HeartbeatRaw) UnPack() *HeartbeatRawT {
+	if rcv == nil {
+		return nil
+	}
+	t := &HeartbeatRawT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
+type HeartbeatRaw struct {
+	_tab flatbuffers.Table
+}
+
+func GetRootAsHeartbeatRaw(buf []byte, offset flatbuffers.UOffsetT) *HeartbeatRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset:])
+	x := &HeartbeatRaw{}
+	x.Init(buf, n+offset)
+	return x
+}
+
+func GetSizePrefixedRootAsHeartbeatRaw(buf []byte, offset flatbuffers.UOffsetT) *HeartbeatRaw {
+	n := flatbuffers.GetUOffsetT(buf[offset+flatbuffers.SizeUint32:])
+	x := &HeartbeatRaw{}
+	x.Init(buf, n+offset+flatbuffers.SizeUint32)
+	return x
+}
+
+func (rcv *HeartbeatRaw) Init(buf []byte, i flatbuffers.UOffsetT) {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+}
+
+func (rcv *HeartbeatRaw) Table() flatbuffers.Table {
+	return rcv._tab
+}
+
+func (rcv *HeartbeatRaw) Calls(obj *ExecutingCallRaw, j int) bool {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		x := rcv._tab.Vector(o)
+		x += flatbuffers.UOffsetT(j) * 24
+		obj.Init(rcv._tab.Bytes, x)
+		return true
+	}
+	return false
+}
+
+func (rcv *HeartbeatRaw) CallsLength() int {
+	o := flatbuffers.UOffsetT(rcv._tab.Offset(4))
+	if o != 0 {
+		return rcv._tab.VectorLen(o)
+	}
+	return 0
+}
+
+func HeartbeatRawStart(builder *flatbuffers.Builder) {
+	builder.StartObject(1)
+}
+func HeartbeatRawAddCalls(builder *flatbuffers.Builder, calls flatbuffers.UOffsetT) {
+	builder.PrependUOffsetTSlot(0, flatbuffers.UOffsetT(calls), 0)
+}
+func HeartbeatRawStartCallsVector(builder *flatbuffers.Builder, numElems int) flatbuffers.UOffsetT {
+	return builder.StartVector(24, numElems, 8)
+}
+func HeartbeatRawEnd(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	return builder.EndObject()
+}
+
+type ExecutingCallRawT struct {
+	Id       int64  `json:"id"`
+	Proc     int32  `json:"proc"`
+	Call     int32  `json:"call"`
+	Duration uint64 `json:"duration"`
+}
+
+func (t *ExecutingCallRawT) Pack(builder *flatbuffers.Builder) flatbuffers.UOffsetT {
+	if t == nil {
+		return 0
+	}
+	return CreateExecutingCallRaw(builder, t.Id, t.Proc, t.Call, t.Duration)
+}
+func (rcv *ExecutingCallRaw) UnPackTo(t *ExecutingCallRawT) {
+	t.Id = rcv.Id()
+	t.Proc = rcv.Proc()
+	t.Call = rcv.Call()
+	t.Duration = rcv.Duration()
+}
+
+func (rcv *ExecutingCallRaw) UnPack() *ExecutingCallRawT {
+	if rcv == nil {
+		return nil
+	}
+	t := &ExecutingCallRawT{}
+	rcv.UnPackTo(t)
+	return t
+}
+
+type ExecutingCallRaw struct {
+	_tab flatbuffers.Struct
+}
+
+func (rcv *ExecutingCallRaw) Init(buf []byte, i flatbuffers.UOffsetT) {
+	rcv._tab.Bytes = buf
+	rcv._tab.Pos = i
+}
+
+func (rcv *ExecutingCallRaw) Table() flatbuffers.Table {
+	return rcv._tab.Table
+}
+
+func (rcv *ExecutingCallRaw) Id() int64 {
+	return rcv._tab.GetInt64(rcv._tab.Pos + flatbuffers.UOffsetT(0))
+}
+func (rcv *ExecutingCallRaw) MutateId(n int64) bool {
+	return rcv._tab.MutateInt64(rcv._tab.Pos+flatbuffers.UOffsetT(0), n)
+}
+
+func (rcv *ExecutingCallRaw) Proc() int32 {
+	return rcv._tab.GetInt32(rcv._tab.Pos + flatbuffers.UOffsetT(8))
+}
+func (rcv *ExecutingCallRaw) MutateProc(n int32) bool {
+	return rcv._tab.MutateInt32(rcv._tab.Pos+flatbuffers.UOffsetT(8), n)
+}
+
+func (rcv *ExecutingCallRaw) Call() int32 {
+	return rcv._tab.GetInt32(rcv._tab.Pos + flatbuffers.UOffsetT(12))
+}
+func (rcv *ExecutingCallRaw) MutateCall(n int32) bool {
+	return rcv._tab.MutateInt32(rcv._tab.Pos+flatbuffers.UOffsetT(12), n)
+}
+
+func (rcv *ExecutingCallRaw) Duration() uint64 {
+	return rcv._tab.GetUint64(rcv._tab.Pos + flatbuffers.UOffsetT(16))
+}
+func (rcv *ExecutingCallRaw) MutateDuration(n uint64) bool {
+	return rcv._tab.MutateUint64(rcv._tab.Pos+flatbuffers.UOffsetT(16), n)
+}
+
+func CreateExecutingCallRaw(builder *flatbuffers.Builder, id int64, proc int32, call int32, duration uint64) flatbuffers.UOffsetT {
+	builder.Prep(8, 24)
+	builder.PrependUint64(duration)
+	builder.PrependInt32(call)
+	builder.PrependInt32(proc)
+	builder.PrependInt64(id)
+	return builder.Offset()
+}
+
 type SnapshotHeaderT struct {
 	State        SnapshotState `json:"state"`
 	OutputOffset uint32        `json:"output_offset"`
@@ -83,6 +83,14 @@ struct CallInfoRawT;
 
 struct ComparisonRaw;
 
+struct CallTraceRaw;
+struct CallTraceRawBuilder;
+struct CallTraceRawT;
+
+struct CallBufferRaw;
+struct CallBufferRawBuilder;
+struct CallBufferRawT;
+
 struct ProgInfoRaw;
 struct ProgInfoRawBuilder;
 struct ProgInfoRawT;
@@ -95,6 +103,12 @@ struct StateResultRaw;
 struct StateResultRawBuilder;
 struct StateResultRawT;
 
+struct HeartbeatRaw;
+struct HeartbeatRawBuilder;
+struct HeartbeatRawT;
+
+struct ExecutingCallRaw;
+
 struct SnapshotHeader;
 struct SnapshotHeaderBuilder;
 struct SnapshotHeaderT;
@@ -368,33 +382,36 @@ enum class ExecutorMessagesRaw : uint8_t {
   ExecResult = 1,
   Executing = 2,
   State = 3,
+  Heartbeat = 4,
   MIN = NONE,
-  MAX = State
+  MAX = Heartbeat
 };
 
-inline const ExecutorMessagesRaw (&EnumValuesExecutorMessagesRaw())[4] {
+inline const ExecutorMessagesRaw (&EnumValuesExecutorMessagesRaw())[5] {
   static const ExecutorMessagesRaw values[] = {
     ExecutorMessagesRaw::NONE,
     ExecutorMessagesRaw::ExecResult,
     ExecutorMessagesRaw::Executing,
-    ExecutorMessagesRaw::State
+    ExecutorMessagesRaw::State,
+    ExecutorMessagesRaw::Heartbeat
   };
   return values;
 }
 
 inline const char * const *EnumNamesExecutorMessagesRaw() {
-  static const char * const names[5] = {
+  static const char * const names[6] = {
     "NONE",
     "ExecResult",
     "Executing",
     "State",
+    "Heartbeat",
     nullptr
   };
   return names;
 }
 
 inline const char *EnumNameExecutorMessagesRaw(ExecutorMessagesRaw e) {
-  if (flatbuffers::IsOutRange(e, ExecutorMessagesRaw::NONE, ExecutorMessagesRaw::State)) return "";
+  if (flatbuffers::IsOutRange(e, ExecutorMessagesRaw::NONE, ExecutorMessagesRaw::Heartbeat)) return "";
   const size_t index = static_cast<size_t>(e);
   return EnumNamesExecutorMessagesRaw()[index];
 }
@@ -415,6 +432,10 @@ template<> struct ExecutorMessagesRawTraits<rpc::StateResultRaw> {
   static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::State;
 };
 
+template<> struct ExecutorMessagesRawTraits<rpc::HeartbeatRaw> {
+  static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::Heartbeat;
+};
+
 template<typename T> struct ExecutorMessagesRawUnionTraits {
   static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::NONE;
 };
@@ -431,6 +452,10 @@ template<> struct ExecutorMessagesRawUnionTraits<rpc::StateResultRawT> {
   static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::State;
 };
 
+template<> struct ExecutorMessagesRawUnionTraits<rpc::HeartbeatRawT> {
+  static const ExecutorMessagesRaw enum_value = ExecutorMessagesRaw::Heartbeat;
+};
+
 struct ExecutorMessagesRawUnion {
   ExecutorMessagesRaw type;
   void *value;
@@ -485,6 +510,14 @@ struct ExecutorMessagesRawUnion {
     return type == ExecutorMessagesRaw::State ?
       reinterpret_cast<const rpc::StateResultRawT *>(value) : nullptr;
   }
+  rpc::HeartbeatRawT *AsHeartbeat() {
+    return type == ExecutorMessagesRaw::Heartbeat ?
+      reinterpret_cast<rpc::HeartbeatRawT *>(value) : nullptr;
+  }
+  const rpc::HeartbeatRawT *AsHeartbeat() const {
+    return type == ExecutorMessagesRaw::Heartbeat ?
+      reinterpret_cast<const rpc::HeartbeatRawT *>(value) : nullptr;
+  }
 };
 
 bool VerifyExecutorMessagesRaw(flatbuffers::Verifier &verifier, const void *obj, ExecutorMessagesRaw type);
@@ -632,24 +665,26 @@ enum class ExecFlag : uint64_t {
   DedupCover = 4ULL,
   CollectComps = 8ULL,
   Threaded = 16ULL,
+  CollectTrace = 32ULL,
   NONE = 0,
-  ANY = 31ULL
+  ANY = 63ULL
 };
 FLATBUFFERS_DEFINE_BITMASK_OPERATORS(ExecFlag, uint64_t)
 
-inline const ExecFlag (&EnumValuesExecFlag())[5] {
+inline const ExecFlag (&EnumValuesExecFlag())[6] {
   static const ExecFlag values[] = {
     ExecFlag::CollectSignal,
     ExecFlag::CollectCover,
     ExecFlag::DedupCover,
     ExecFlag::CollectComps,
-    ExecFlag::Threaded
+    ExecFlag::Threaded,
+    ExecFlag::CollectTrace
   };
   return values;
 }
 
 inline const char * const *EnumNamesExecFlag() {
-  static const char * const names[17] = {
+  static const char * const names[33] = {
     "CollectSignal",
     "CollectCover",
     "",
@@ -666,13 +701,29 @@ inline const char * const *EnumNamesExecFlag() {
     "",
     "",
     "Threaded",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "",
+    "CollectTrace",
     nullptr
   };
   return names;
 }
 
 inline const char *EnumNameExecFlag(ExecFlag e) {
-  if (flatbuffers::IsOutRange(e, ExecFlag::CollectSignal, ExecFlag::Threaded)) return "";
+  if (flatbuffers::IsOutRange(e, ExecFlag::CollectSignal, ExecFlag::CollectTrace)) return "";
   const size_t index = static_cast<size_t>(e) - static_cast<size_t>(ExecFlag::CollectSignal);
   return EnumNamesExecFlag()[index];
 }
@@ -850,6 +901,41 @@ FLATBUFFERS_MANUALLY_ALIGNED_STRUCT(8) ComparisonRaw FLATBUFFERS_FINAL_CLASS {
 };
 FLATBUFFERS_STRUCT_END(ComparisonRaw, 32);
 
+FLATBUFFERS_MANUALLY_ALIGNED_STRUCT(8) ExecutingCallRaw FLATBUFFERS_FINAL_CLASS {
+ private:
+  int64_t id_;
+  int32_t proc_;
+  int32_t call_;
+  uint64_t duration_;
+
+ public:
+  ExecutingCallRaw()
+      : id_(0),
+        proc_(0),
+        call_(0),
+        duration_(0) {
+  }
+  ExecutingCallRaw(int64_t _id, int32_t _proc, int32_t _call, uint64_t _duration)
+      : id_(flatbuffers::EndianScalar(_id)),
+        proc_(flatbuffers::EndianScalar(_proc)),
+        call_(flatbuffers::EndianScalar(_call)),
+        duration_(flatbuffers::EndianScalar(_duration)) {
+  }
+  int64_t id() const {
+    return flatbuffers::EndianScalar(id_);
+  }
+  int32_t proc() const {
+    return flatbuffers::EndianScalar(proc_);
+  }
+  int32_t call() const {
+    return flatbuffers::EndianScalar(call_);
+  }
+  uint64_t duration() const {
+    return flatbuffers::EndianScalar(duration_);
+  }
+};
+FLATBUFFERS_STRUCT_END(ExecutingCallRaw, 24);
+
 struct ConnectHelloRawT : public flatbuffers::NativeTable {
   typedef ConnectHelloRaw TableType;
   uint64_t cookie = 0;
@@ -1783,6 +1869,9 @@ struct ExecutorMessageRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const rpc::StateResultRaw *msg_as_State() const {
     return msg_type() == rpc::ExecutorMessagesRaw::State ? static_cast<const rpc::StateResultRaw *>(msg()) : nullptr;
   }
+  const rpc::HeartbeatRaw *msg_as_Heartbeat() const {
+    return msg_type() == rpc::ExecutorMessagesRaw::Heartbeat ? static_cast<const rpc::HeartbeatRaw *>(msg()) : nullptr;
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint8_t>(verifier, VT_MSG_TYPE, 1) &&
@@ -1807,6 +1896,10 @@ template<> inline const rpc::StateResultRaw *ExecutorMessageRaw::msg_as<rpc::Sta
   return msg_as_State();
 }
 
+template<> inline const rpc::HeartbeatRaw *ExecutorMessageRaw::msg_as<rpc::HeartbeatRaw>() const {
+  return msg_as_Heartbeat();
+}
+
 struct ExecutorMessageRawBuilder {
   typedef ExecutorMessageRaw Table;
   flatbuffers::FlatBufferBuilder &fbb_;
@@ -2218,6 +2311,11 @@ struct CallInfoRawT : public flatbuffers::NativeTable {
   std::vector<uint64_t> signal{};
   std::vector<uint64_t> cover{};
   std::vector<rpc::ComparisonRaw> comps{};
+  std::unique_ptr<rpc::CallTraceRawT> trace{};
+  CallInfoRawT() = default;
+  CallInfoRawT(const CallInfoRawT &o);
+  CallInfoRawT(CallInfoRawT&&) FLATBUFFERS_NOEXCEPT = default;
+  CallInfoRawT &operator=(CallInfoRawT o) FLATBUFFERS_NOEXCEPT;
 };
 
 struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
@@ -2228,7 +2326,8 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
     VT_ERROR = 6,
     VT_SIGNAL = 8,
     VT_COVER = 10,
-    VT_COMPS = 12
+    VT_COMPS = 12,
+    VT_TRACE = 14
   };
   rpc::CallFlag flags() const {
     return static_cast<rpc::CallFlag>(GetField<uint8_t>(VT_FLAGS, 0));
@@ -2245,6 +2344,9 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   const flatbuffers::Vector<const rpc::ComparisonRaw *> *comps() const {
     return GetPointer<const flatbuffers::Vector<const rpc::ComparisonRaw *> *>(VT_COMPS);
   }
+  const rpc::CallTraceRaw *trace() const {
+    return GetPointer<const rpc::CallTraceRaw *>(VT_TRACE);
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyField<uint8_t>(verifier, VT_FLAGS, 1) &&
@@ -2255,6 +2357,8 @@ struct CallInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
            verifier.VerifyVector(cover()) &&
            VerifyOffset(verifier, VT_COMPS) &&
            verifier.VerifyVector(comps()) &&
+           VerifyOffset(verifier, VT_TRACE) &&
+           verifier.VerifyTable(trace()) &&
            verifier.EndTable();
   }
   CallInfoRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -2281,6 +2385,9 @@ struct CallInfoRawBuilder {
   void add_comps(flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps) {
     fbb_.AddOffset(CallInfoRaw::VT_COMPS, comps);
   }
+  void add_trace(flatbuffers::Offset<rpc::CallTraceRaw> trace) {
+    fbb_.AddOffset(CallInfoRaw::VT_TRACE, trace);
+  }
   explicit CallInfoRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -2298,8 +2405,10 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(
     int32_t error = 0,
     flatbuffers::Offset<flatbuffers::Vector<uint64_t>> signal = 0,
     flatbuffers::Offset<flatbuffers::Vector<uint64_t>> cover = 0,
-    flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps = 0) {
+    flatbuffers::Offset<flatbuffers::Vector<const rpc::ComparisonRaw *>> comps = 0,
+    flatbuffers::Offset<rpc::CallTraceRaw> trace = 0) {
   CallInfoRawBuilder builder_(_fbb);
+  builder_.add_trace(trace);
   builder_.add_comps(comps);
   builder_.add_cover(cover);
   builder_.add_signal(signal);
@@ -2314,7 +2423,8 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRawDirect(
     int32_t error = 0,
     const std::vector<uint64_t> *signal = nullptr,
     const std::vector<uint64_t> *cover = nullptr,
-    const std::vector<rpc::ComparisonRaw> *comps = nullptr) {
+    const std::vector<rpc::ComparisonRaw> *comps = nullptr,
+    flatbuffers::Offset<rpc::CallTraceRaw> trace = 0) {
   auto signal__ = signal ? _fbb.CreateVector<uint64_t>(*signal) : 0;
   auto cover__ = cover ? _fbb.CreateVector<uint64_t>(*cover) : 0;
   auto comps__ = comps ? _fbb.CreateVectorOfStructs<rpc::ComparisonRaw>(*comps) : 0;
@@ -2324,11 +2434,197 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRawDirect(
       error,
       signal__,
       cover__,
-      comps__);
+      comps__,
+      trace);
 }
 
 flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(flatbuffers::FlatBufferBuilder &_fbb, const CallInfoRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
 
+struct CallTraceRawT : public flatbuffers::NativeTable {
+  typedef CallTraceRaw TableType;
+  std::vector<uint64_t> args{};
+  uint64_t res = 0;
+  std::vector<std::unique_ptr<rpc::CallBufferRawT>> buffers{};
+  CallTraceRawT() = default;
+  CallTraceRawT(const CallTraceRawT &o);
+  CallTraceRawT(CallTraceRawT&&) FLATBUFFERS_NOEXCEPT = default;
+  CallTraceRawT &operator=(CallTraceRawT o) FLATBUFFERS_NOEXCEPT;
+};
+
+struct CallTraceRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
+  typedef CallTraceRawT NativeTableType;
+  typedef CallTraceRawBuilder Builder;
+  enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
+    VT_ARGS = 4,
+    VT_RES = 6,
+    VT_BUFFERS = 8
+  };
+  const flatbuffers::Vector<uint64_t> *args() const {
+    return GetPointer<const flatbuffers::Vector<uint64_t> *>(VT_ARGS);
+  }
+  uint64_t res() const {
+    return GetField<uint64_t>(VT_RES, 0);
+  }
+  const flatbuffers::Vector<flatbuffers::Offset<rpc::CallBufferRaw>> *buffers() const {
+    return GetPointer<const flatbuffers::Vector<flatbuffers::Offset<rpc::CallBufferRaw>> *>(VT_BUFFERS);
+  }
+  bool Verify(flatbuffers::Verifier &verifier) const {
+    return VerifyTableStart(verifier) &&
+           VerifyOffset(verifier, VT_ARGS) &&
+           verifier.VerifyVector(args()) &&
+           VerifyField<uint64_t>(verifier, VT_RES, 8) &&
+           VerifyOffset(verifier, VT_BUFFERS) &&
+           verifier.VerifyVector(buffers()) &&
+           verifier.VerifyVectorOfTables(buffers()) &&
+           verifier.EndTable();
+  }
+  CallTraceRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  void UnPackTo(CallTraceRawT *_o, const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  static flatbuffers::Offset<CallTraceRaw> Pack(flatbuffers::FlatBufferBuilder &_fbb, const CallTraceRawT* _o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+};
+
+struct CallTraceRawBuilder {
+  typedef CallTraceRaw Table;
+  flatbuffers::FlatBufferBuilder &fbb_;
+  flatbuffers::uoffset_t start_;
+  void add_args(flatbuffers::Offset<flatbuffers::Vector<uint64_t>> args) {
+    fbb_.AddOffset(CallTraceRaw::VT_ARGS, args);
+  }
+  void add_res(uint64_t res) {
+    fbb_.AddElement<uint64_t>(CallTraceRaw::VT_RES, res, 0);
+  }
+  void add_buffers(flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::CallBufferRaw>>> buffers) {
+    fbb_.AddOffset(CallTraceRaw::VT_BUFFERS, buffers);
+  }
+  explicit CallTraceRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
+        : fbb_(_fbb) {
+    start_ = fbb_.StartTable();
+  }
+  flatbuffers::Offset<CallTraceRaw> Finish() {
+    const auto end = fbb_.EndTable(start_);
+    auto o = flatbuffers::Offset<CallTraceRaw>(end);
+    return o;
+  }
+};
+
+inline flatbuffers::Offset<CallTraceRaw> CreateCallTraceRaw(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    flatbuffers::Offset<flatbuffers::Vector<uint64_t>> args = 0,
+    uint64_t res = 0,
+    flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::CallBufferRaw>>> buffers = 0) {
+  CallTraceRawBuilder builder_(_fbb);
+  builder_.add_res(res);
+  builder_.add_buffers(buffers);
+  builder_.add_args(args);
+  return builder_.Finish();
+}
+
+inline flatbuffers::Offset<CallTraceRaw> CreateCallTraceRawDirect(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    const std::vector<uint64_t> *args = nullptr,
+    uint64_t res = 0,
+    const std::vector<flatbuffers::Offset<rpc::CallBufferRaw>> *buffers = nullptr) {
+  auto args__ = args ? _fbb.CreateVector<uint64_t>(*args) : 0;
+  auto buffers__ = buffers ? _fbb.CreateVector<flatbuffers::Offset<rpc::CallBufferRaw>>(*buffers) : 0;
+  return rpc::CreateCallTraceRaw(
+      _fbb,
+      args__,
+      res,
+      buffers__);
+}
+
+flatbuffers::Offset<CallTraceRaw> CreateCallTraceRaw(flatbuffers::FlatBufferBuilder &_fbb, const CallTraceRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+
+struct CallBufferRawT : public flatbuffers::NativeTable {
+  typedef CallBufferRaw TableType;
+  int32_t arg = 0;
+  std::vector<uint8_t> before{};
+  std::vector<uint8_t> after{};
+};
+
+struct CallBufferRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
+  typedef CallBufferRawT NativeTableType;
+  typedef CallBufferRawBuilder Builder;
+  enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
+    VT_ARG = 4,
+    VT_BEFORE = 6,
+    VT_AFTER = 8
+  };
+  int32_t arg() const {
+    return GetField<int32_t>(VT_ARG, 0);
+  }
+  const flatbuffers::Vector<uint8_t> *before() const {
+    return GetPointer<const flatbuffers::Vector<uint8_t> *>(VT_BEFORE);
+  }
+  const flatbuffers::Vector<uint8_t> *after() const {
+    return GetPointer<const flatbuffers::Vector<uint8_t> *>(VT_AFTER);
+  }
+  bool Verify(flatbuffers::Verifier &verifier) const {
+    return VerifyTableStart(verifier) &&
+           VerifyField<int32_t>(verifier, VT_ARG, 4) &&
+           VerifyOffset(verifier, VT_BEFORE) &&
+           verifier.VerifyVector(before()) &&
+           VerifyOffset(verifier, VT_AFTER) &&
+           verifier.VerifyVector(after()) &&
+           verifier.EndTable();
+  }
+  CallBufferRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  void UnPackTo(CallBufferRawT *_o, const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  static flatbuffers::Offset<CallBufferRaw> Pack(flatbuffers::FlatBufferBuilder &_fbb, const CallBufferRawT* _o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+};
+
+struct CallBufferRawBuilder {
+  typedef CallBufferRaw Table;
+  flatbuffers::FlatBufferBuilder &fbb_;
+  flatbuffers::uoffset_t start_;
+  void add_arg(int32_t arg) {
+    fbb_.AddElement<int32_t>(CallBufferRaw::VT_ARG, arg, 0);
+  }
+  void add_before(flatbuffers::Offset<flatbuffers::Vector<uint8_t>> before) {
+    fbb_.AddOffset(CallBufferRaw::VT_BEFORE, before);
+  }
+  void add_after(flatbuffers::Offset<flatbuffers::Vector<uint8_t>> after) {
+    fbb_.AddOffset(CallBufferRaw::VT_AFTER, after);
+  }
+  explicit CallBufferRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
+        : fbb_(_fbb) {
+    start_ = fbb_.StartTable();
+  }
+  flatbuffers::Offset<CallBufferRaw> Finish() {
+    const auto end = fbb_.EndTable(start_);
+    auto o = flatbuffers::Offset<CallBufferRaw>(end);
+    return o;
+  }
+};
+
+inline flatbuffers::Offset<CallBufferRaw> CreateCallBufferRaw(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    int32_t arg = 0,
+    flatbuffers::Offset<flatbuffers::Vector<uint8_t>> before = 0,
+    flatbuffers::Offset<flatbuffers::Vector<uint8_t>> after = 0) {
+  CallBufferRawBuilder builder_(_fbb);
+  builder_.add_after(after);
+  builder_.add_before(before);
+  builder_.add_arg(arg);
+  return builder_.Finish();
+}
+
+inline flatbuffers::Offset<CallBufferRaw> CreateCallBufferRawDirect(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    int32_t arg = 0,
+    const std::vector<uint8_t> *before = nullptr,
+    const std::vector<uint8_t> *after = nullptr) {
+  auto before__ = before ? _fbb.CreateVector<uint8_t>(*before) : 0;
+  auto after__ = after ? _fbb.CreateVector<uint8_t>(*after) : 0;
+  return rpc::CreateCallBufferRaw(
+      _fbb,
+      arg,
+      before__,
+      after__);
+}
+
+flatbuffers::Offset<CallBufferRaw> CreateCallBufferRaw(flatbuffers::FlatBufferBuilder &_fbb, const CallBufferRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+
 struct ProgInfoRawT : public flatbuffers::NativeTable {
   typedef ProgInfoRaw TableType;
   std::vector<std::unique_ptr<rpc::CallInfoRawT>> calls{};
@@ -2336,6 +2632,11 @@ struct ProgInfoRawT : public flatbuffers::NativeTable {
   std::unique_ptr<rpc::CallInfoRawT> extra{};
   uint64_t elapsed = 0;
   uint64_t freshness = 0;
+  uint64_t peak_rss = 0;
+  uint32_t open_fds = 0;
+  uint64_t user_time = 0;
+  uint64_t system_time = 0;
+  uint64_t page_faults = 0;
   ProgInfoRawT() = default;
   ProgInfoRawT(const ProgInfoRawT &o);
   ProgInfoRawT(ProgInfoRawT&&) FLATBUFFERS_NOEXCEPT = default;
@@ -2350,7 +2651,12 @@ struct ProgInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
     VT_EXTRA_RAW = 6,
     VT_EXTRA = 8,
     VT_ELAPSED = 10,
-    VT_FRESHNESS = 12
+    VT_FRESHNESS = 12,
+    VT_PEAK_RSS = 14,
+    VT_OPEN_FDS = 16,
+    VT_USER_TIME = 18,
+    VT_SYSTEM_TIME = 20,
+    VT_PAGE_FAULTS = 22
   };
   const flatbuffers::Vector<flatbuffers::Offset<rpc::CallInfoRaw>> *calls() const {
     return GetPointer<const flatbuffers::Vector<flatbuffers::Offset<rpc::CallInfoRaw>> *>(VT_CALLS);
@@ -2367,6 +2673,21 @@ struct ProgInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
   uint64_t freshness() const {
     return GetField<uint64_t>(VT_FRESHNESS, 0);
   }
+  uint64_t peak_rss() const {
+    return GetField<uint64_t>(VT_PEAK_RSS, 0);
+  }
+  uint32_t open_fds() const {
+    return GetField<uint32_t>(VT_OPEN_FDS, 0);
+  }
+  uint64_t user_time() const {
+    return GetField<uint64_t>(VT_USER_TIME, 0);
+  }
+  uint64_t system_time() const {
+    return GetField<uint64_t>(VT_SYSTEM_TIME, 0);
+  }
+  uint64_t page_faults() const {
+    return GetField<uint64_t>(VT_PAGE_FAULTS, 0);
+  }
   bool Verify(flatbuffers::Verifier &verifier) const {
     return VerifyTableStart(verifier) &&
            VerifyOffset(verifier, VT_CALLS) &&
@@ -2379,6 +2700,11 @@ struct ProgInfoRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
            verifier.VerifyTable(extra()) &&
            VerifyField<uint64_t>(verifier, VT_ELAPSED, 8) &&
            VerifyField<uint64_t>(verifier, VT_FRESHNESS, 8) &&
+           VerifyField<uint64_t>(verifier, VT_PEAK_RSS, 8) &&
+           VerifyField<uint32_t>(verifier, VT_OPEN_FDS, 4) &&
+           VerifyField<uint64_t>(verifier, VT_USER_TIME, 8) &&
+           VerifyField<uint64_t>(verifier, VT_SYSTEM_TIME, 8) &&
+           VerifyField<uint64_t>(verifier, VT_PAGE_FAULTS, 8) &&
            verifier.EndTable();
   }
   ProgInfoRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
@@ -2405,6 +2731,21 @@ struct ProgInfoRawBuilder {
   void add_freshness(uint64_t freshness) {
     fbb_.AddElement<uint64_t>(ProgInfoRaw::VT_FRESHNESS, freshness, 0);
   }
+  void add_peak_rss(uint64_t peak_rss) {
+    fbb_.AddElement<uint64_t>(ProgInfoRaw::VT_PEAK_RSS, peak_rss, 0);
+  }
+  void add_open_fds(uint32_t open_fds) {
+    fbb_.AddElement<uint32_t>(ProgInfoRaw::VT_OPEN_FDS, open_fds, 0);
+  }
+  void add_user_time(uint64_t user_time) {
+    fbb_.AddElement<uint64_t>(ProgInfoRaw::VT_USER_TIME, user_time, 0);
+  }
+  void add_system_time(uint64_t system_time) {
+    fbb_.AddElement<uint64_t>(ProgInfoRaw::VT_SYSTEM_TIME, system_time, 0);
+  }
+  void add_page_faults(uint64_t page_faults) {
+    fbb_.AddElement<uint64_t>(ProgInfoRaw::VT_PAGE_FAULTS, page_faults, 0);
+  }
   explicit ProgInfoRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
         : fbb_(_fbb) {
     start_ = fbb_.StartTable();
@@ -2422,10 +2763,20 @@ inline flatbuffers::Offset<ProgInfoRaw> CreateProgInfoRaw(
     flatbuffers::Offset<flatbuffers::Vector<flatbuffers::Offset<rpc::CallInfoRaw>>> extra_raw = 0,
     flatbuffers::Offset<rpc::CallInfoRaw> extra = 0,
     uint64_t elapsed = 0,
-    uint64_t freshness = 0) {
+    uint64_t freshness = 0,
+    uint64_t peak_rss = 0,
+    uint32_t open_fds = 0,
+    uint64_t user_time = 0,
+    uint64_t system_time = 0,
+    uint64_t page_faults = 0) {
   ProgInfoRawBuilder builder_(_fbb);
+  builder_.add_page_faults(page_faults);
+  builder_.add_system_time(system_time);
+  builder_.add_user_time(user_time);
+  builder_.add_peak_rss(peak_rss);
   builder_.add_freshness(freshness);
   builder_.add_elapsed(elapsed);
+  builder_.add_open_fds(open_fds);
   builder_.add_extra(extra);
   builder_.add_extra_raw(extra_raw);
   builder_.add_calls(calls);
@@ -2438,7 +2789,12 @@ inline flatbuffers::Offset<ProgInfoRaw> CreateProgInfoRawDirect(
     const std::vector<flatbuffers::Offset<rpc::CallInfoRaw>> *extra_raw = nullptr,
     flatbuffers::Offset<rpc::CallInfoRaw> extra = 0,
     uint64_t elapsed = 0,
-    uint64_t freshness = 0) {
+    uint64_t freshness = 0,
+    uint64_t peak_rss = 0,
+    uint32_t open_fds = 0,
+    uint64_t user_time = 0,
+    uint64_t system_time = 0,
+    uint64_t page_faults = 0) {
   auto calls__ = calls ? _fbb.CreateVector<flatbuffers::Offset<rpc::CallInfoRaw>>(*calls) : 0;
   auto extra_raw__ = extra_raw ? _fbb.CreateVector<flatbuffers::Offset<rpc::CallInfoRaw>>(*extra_raw) : 0;
   return rpc::CreateProgInfoRaw(
@@ -2447,7 +2803,12 @@ inline flatbuffers::Offset<ProgInfoRaw> CreateProgInfoRawDirect(
       extra_raw__,
       extra,
       elapsed,
-      freshness);
+      freshness,
+      peak_rss,
+      open_fds,
+      user_time,
+      system_time,
+      page_faults);
 }
 
 flatbuffers::Offset<ProgInfoRaw> CreateProgInfoRaw(flatbuffers::FlatBufferBuilder &_fbb, const ProgInfoRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
@@ -2648,6 +3009,68 @@ inline flatbuffers::Offset<StateResultRaw> CreateStateResultRawDirect(
 
 flatbuffers::Offset<StateResultRaw> CreateStateResultRaw(flatbuffers::FlatBufferBuilder &_fbb, const StateResultRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
 
+struct HeartbeatRawT : public flatbuffers::NativeTable {
+  typedef HeartbeatRaw TableType;
+  std::vector<rpc::ExecutingCallRaw> calls{};
+};
+
+struct HeartbeatRaw FLATBUFFERS_FINAL_CLASS : private flatbuffers::Table {
+  typedef HeartbeatRawT NativeTableType;
+  typedef HeartbeatRawBuilder Builder;
+  enum FlatBuffersVTableOffset FLATBUFFERS_VTABLE_UNDERLYING_TYPE {
+    VT_CALLS = 4
+  };
+  const flatbuffers::Vector<const rpc::ExecutingCallRaw *> *calls() const {
+    return GetPointer<const flatbuffers::Vector<const rpc::ExecutingCallRaw *> *>(VT_CALLS);
+  }
+  bool Verify(flatbuffers::Verifier &verifier) const {
+    return VerifyTableStart(verifier) &&
+           VerifyOffset(verifier, VT_CALLS) &&
+           verifier.VerifyVector(calls()) &&
+           verifier.EndTable();
+  }
+  HeartbeatRawT *UnPack(const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  void UnPackTo(HeartbeatRawT *_o, const flatbuffers::resolver_function_t *_resolver = nullptr) const;
+  static flatbuffers::Offset<HeartbeatRaw> Pack(flatbuffers::FlatBufferBuilder &_fbb, const HeartbeatRawT* _o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+};
+
+struct HeartbeatRawBuilder {
+  typedef HeartbeatRaw Table;
+  flatbuffers::FlatBufferBuilder &fbb_;
+  flatbuffers::uoffset_t start_;
+  void add_calls(flatbuffers::Offset<flatbuffers::Vector<const rpc::ExecutingCallRaw *>> calls) {
+    fbb_.AddOffset(HeartbeatRaw::VT_CALLS, calls);
+  }
+  explicit HeartbeatRawBuilder(flatbuffers::FlatBufferBuilder &_fbb)
+        : fbb_(_fbb) {
+    start_ = fbb_.StartTable();
+  }
+  flatbuffers::Offset<HeartbeatRaw> Finish() {
+    const auto end = fbb_.EndTable(start_);
+    auto o = flatbuffers::Offset<HeartbeatRaw>(end);
+    return o;
+  }
+};
+
+inline flatbuffers::Offset<HeartbeatRaw> CreateHeartbeatRaw(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    flatbuffers::Offset<flatbuffers::Vector<const rpc::ExecutingCallRaw *>> calls = 0) {
+  HeartbeatRawBuilder builder_(_fbb);
+  builder_.add_calls(calls);
+  return builder_.Finish();
+}
+
+inline flatbuffers::Offset<HeartbeatRaw> CreateHeartbeatRawDirect(
+    flatbuffers::FlatBufferBuilder &_fbb,
+    const std::vector<rpc::ExecutingCallRaw> *calls = nullptr) {
+  auto calls__ = calls ? _fbb.CreateVectorOfStructs<rpc::ExecutingCallRaw>(*calls) : 0;
+  return rpc::CreateHeartbeatRaw(
+      _fbb,
+      calls__);
+}
+
+flatbuffers::Offset<HeartbeatRaw> CreateHeartbeatRaw(flatbuffers::FlatBufferBuilder &_fbb, const HeartbeatRawT *_o, const flatbuffers::rehasher_function_t *_rehasher = nullptr);
+
 struct SnapshotHeaderT : public flatbuffers::NativeTable {
   typedef SnapshotHeader TableType;
   rpc::SnapshotState state = rpc::SnapshotState::Initial;
@@ -3487,6 +3910,25 @@ inline flatbuffers::Offset<ExecutingMessageRaw> CreateExecutingMessageRaw(flatbu
       _wait_duration);
 }
 
+inline CallInfoRawT::CallInfoRawT(const CallInfoRawT &o)
+      : flags(o.flags),
+        error(o.error),
+        signal(o.signal),
+        cover(o.cover),
+        comps(o.comps),
+        trace((o.trace) ? new rpc::CallTraceRawT(*o.trace) : nullptr) {
+}
+
+inline CallInfoRawT &CallInfoRawT::operator=(CallInfoRawT o) FLATBUFFERS_NOEXCEPT {
+  std::swap(flags, o.flags);
+  std::swap(error, o.error);
+  std::swap(signal, o.signal);
+  std::swap(cover, o.cover);
+  std::swap(comps, o.comps);
+  std::swap(trace, o.trace);
+  return *this;
+}
+
 inline CallInfoRawT *CallInfoRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
   auto _o = std::unique_ptr<CallInfoRawT>(new CallInfoRawT());
   UnPackTo(_o.get(), _resolver);
@@ -3501,6 +3943,7 @@ inline void CallInfoRaw::UnPackTo(CallInfoRawT *_o, const flatbuffers::resolver_
   { auto _e = signal(); if (_e) { _o->signal.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->signal[_i] = _e->Get(_i); } } }
   { auto _e = cover(); if (_e) { _o->cover.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->cover[_i] = _e->Get(_i); } } }
   { auto _e = comps(); if (_e) { _o->comps.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->comps[_i] = *_e->Get(_i); } } }
+  { auto _e = trace(); if (_e) _o->trace = std::unique_ptr<rpc::CallTraceRawT>(_e->UnPack(_resolver)); }
 }
 
 inline flatbuffers::Offset<CallInfoRaw> CallInfoRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const CallInfoRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3516,19 +3959,104 @@ inline flatbuffers::Offset<CallInfoRaw> CreateCallInfoRaw(flatbuffers::FlatBuffe
   auto _signal = _o->signal.size() ? _fbb.CreateVector(_o->signal) : 0;
   auto _cover = _o->cover.size() ? _fbb.CreateVector(_o->cover) : 0;
   auto _comps = _o->comps.size() ? _fbb.CreateVectorOfStructs(_o->comps) : 0;
+  auto _trace = _o->trace ? CreateCallTraceRaw(_fbb, _o->trace.get(), _rehasher) : 0;
   return rpc::CreateCallInfoRaw(
       _fbb,
       _flags,
       _error,
       _signal,
       _cover,
-      _comps);
+      _comps,
+      _trace);
+}
+
+inline CallTraceRawT::CallTraceRawT(const CallTraceRawT &o)
+      : args(o.args),
+        res(o.res) {
+  buffers.reserve(o.buffers.size());
+  for (const auto &buffers_ : o.buffers) { buffers.emplace_back((buffers_) ? new rpc::CallBufferRawT(*buffers_) : nullptr); }
+}
+
+inline CallTraceRawT &CallTraceRawT::operator=(CallTraceRawT o) FLATBUFFERS_NOEXCEPT {
+  std::swap(args, o.args);
+  std::swap(res, o.res);
+  std::swap(buffers, o.buffers);
+  return *this;
+}
+
+inline CallTraceRawT *CallTraceRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
+  auto _o = std::unique_ptr<CallTraceRawT>(new CallTraceRawT());
+  UnPackTo(_o.get(), _resolver);
+  return _o.release();
+}
+
+inline void CallTraceRaw::UnPackTo(CallTraceRawT *_o, const flatbuffers::resolver_function_t *_resolver) const {
+  (void)_o;
+  (void)_resolver;
+  { auto _e = args(); if (_e) { _o->args.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->args[_i] = _e->Get(_i); } } }
+  { auto _e = res(); _o->res = _e; }
+  { auto _e = buffers(); if (_e) { _o->buffers.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->buffers[_i] = std::unique_ptr<rpc::CallBufferRawT>(_e->Get(_i)->UnPack(_resolver)); } } }
+}
+
+inline flatbuffers::Offset<CallTraceRaw> CallTraceRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const CallTraceRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
+  return CreateCallTraceRaw(_fbb, _o, _rehasher);
+}
+
+inline flatbuffers::Offset<CallTraceRaw> CreateCallTraceRaw(flatbuffers::FlatBufferBuilder &_fbb, const CallTraceRawT *_o, const flatbuffers::rehasher_function_t *_rehasher) {
+  (void)_rehasher;
+  (void)_o;
+  struct _VectorArgs { flatbuffers::FlatBufferBuilder *__fbb; const CallTraceRawT* __o; const flatbuffers::rehasher_function_t *__rehasher; } _va = { &_fbb, _o, _rehasher}; (void)_va;
+  auto _args = _o->args.size() ? _fbb.CreateVector(_o->args) : 0;
+  auto _res = _o->res;
+  auto _buffers = _o->buffers.size() ? _fbb.CreateVector<flatbuffers::Offset<rpc::CallBufferRaw>> (_o->buffers.size(), [](size_t i, _VectorArgs *__va) { return CreateCallBufferRaw(*__va->__fbb, __va->__o->buffers[i].get(), __va->__rehasher); }, &_va ) : 0;
+  return rpc::CreateCallTraceRaw(
+      _fbb,
+      _args,
+      _res,
+      _buffers);
+}
+
+inline CallBufferRawT *CallBufferRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
+  auto _o = std::unique_ptr<CallBufferRawT>(new CallBufferRawT());
+  UnPackTo(_o.get(), _resolver);
+  return _o.release();
+}
+
+inline void CallBufferRaw::UnPackTo(CallBufferRawT *_o, const flatbuffers::resolver_function_t *_resolver) const {
+  (void)_o;
+  (void)_resolver;
+  { auto _e = arg(); _o->arg = _e; }
+  { auto _e = before(); if (_e) { _o->before.resize(_e->size()); std::copy(_e->begin(), _e->end(), _o->before.begin()); } }
+  { auto _e = after(); if (_e) { _o->after.resize(_e->size()); std::copy(_e->begin(), _e->end(), _o->after.begin()); } }
+}
+
+inline flatbuffers::Offset<CallBufferRaw> CallBufferRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const CallBufferRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
+  return CreateCallBufferRaw(_fbb, _o, _rehasher);
+}
+
+inline flatbuffers::Offset<CallBufferRaw> CreateCallBufferRaw(flatbuffers::FlatBufferBuilder &_fbb, const CallBufferRawT *_o, const flatbuffers::rehasher_function_t *_rehasher) {
+  (void)_rehasher;
+  (void)_o;
+  struct _VectorArgs { flatbuffers::FlatBufferBuilder *__fbb; const CallBufferRawT* __o; const flatbuffers::rehasher_function_t *__rehasher; } _va = { &_fbb, _o, _rehasher}; (void)_va;
+  auto _arg = _o->arg;
+  auto _before = _o->before.size() ? _fbb.CreateVector(_o->before) : 0;
+  auto _after = _o->after.size() ? _fbb.CreateVector(_o->after) : 0;
+  return rpc::CreateCallBufferRaw(
+      _fbb,
+      _arg,
+      _before,
+      _after);
 }
 
 inline ProgInfoRawT::ProgInfoRawT(const ProgInfoRawT &o)
       : extra((o.extra) ? new rpc::CallInfoRawT(*o.extra) : nullptr),
         elapsed(o.elapsed),
-        freshness(o.freshness) {
+        freshness(o.freshness),
+        peak_rss(o.peak_rss),
+        open_fds(o.open_fds),
+        user_time(o.user_time),
+        system_time(o.system_time),
+        page_faults(o.page_faults) {
   calls.reserve(o.calls.size());
   for (const auto &calls_ : o.calls) { calls.emplace_back((calls_) ? new rpc::CallInfoRawT(*calls_) : nullptr); }
   extra_raw.reserve(o.extra_raw.size());
@@ -3541,6 +4069,11 @@ inline ProgInfoRawT &ProgInfoRawT::operator=(ProgInfoRawT o) FLATBUFFERS_NOEXCEP
   std::swap(extra, o.extra);
   std::swap(elapsed, o.elapsed);
   std::swap(freshness, o.freshness);
+  std::swap(peak_rss, o.peak_rss);
+  std::swap(open_fds, o.open_fds);
+  std::swap(user_time, o.user_time);
+  std::swap(system_time, o.system_time);
+  std::swap(page_faults, o.page_faults);
   return *this;
 }
 
@@ -3558,6 +4091,11 @@ inline void ProgInfoRaw::UnPackTo(ProgInfoRawT *_o, const flatbuffers::resolver_
   { auto _e = extra(); if (_e) _o->extra = std::unique_ptr<rpc::CallInfoRawT>(_e->UnPack(_resolver)); }
   { auto _e = elapsed(); _o->elapsed = _e; }
   { auto _e = freshness(); _o->freshness = _e; }
+  { auto _e = peak_rss(); _o->peak_rss = _e; }
+  { auto _e = open_fds(); _o->open_fds = _e; }
+  { auto _e = user_time(); _o->user_time = _e; }
+  { auto _e = system_time(); _o->system_time = _e; }
+  { auto _e = page_faults(); _o->page_faults = _e; }
 }
 
 inline flatbuffers::Offset<ProgInfoRaw> ProgInfoRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const ProgInfoRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
@@ -3573,13 +4111,23 @@ inline flatbuffers::Offset<ProgInfoRaw> CreateProgInfoRaw(flatbuffers::FlatBuffe
   auto _extra = _o->extra ? CreateCallInfoRaw(_fbb, _o->extra.get(), _rehasher) : 0;
   auto _elapsed = _o->elapsed;
   auto _freshness = _o->freshness;
+  auto _peak_rss = _o->peak_rss;
+  auto _open_fds = _o->open_fds;
+  auto _user_time = _o->user_time;
+  auto _system_time = _o->system_time;
+  auto _page_faults = _o->page_faults;
   return rpc::CreateProgInfoRaw(
       _fbb,
       _calls,
       _extra_raw,
       _extra,
       _elapsed,
-      _freshness);
+      _freshness,
+      _peak_rss,
+      _open_fds,
+      _user_time,
+      _system_time,
+      _page_faults);
 }
 
 inline ExecResultRawT::ExecResultRawT(const ExecResultRawT &o)
@@ -3668,6 +4216,32 @@ inline flatbuffers::Offset<StateResultRaw> CreateStateResultRaw(flatbuffers::Fla
       _data);
 }
 
+inline HeartbeatRawT *HeartbeatRaw::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
+  auto _o = std::unique_ptr<HeartbeatRawT>(new HeartbeatRawT());
+  UnPackTo(_o.get(), _resolver);
+  return _o.release();
+}
+
+inline void HeartbeatRaw::UnPackTo(HeartbeatRawT *_o, const flatbuffers::resolver_function_t *_resolver) const {
+  (void)_o;
+  (void)_resolver;
+  { auto _e = calls(); if (_e) { _o->calls.resize(_e->size()); for (flatbuffers::uoffset_t _i = 0; _i < _e->size(); _i++) { _o->calls[_i] = *_e->Get(_i); } } }
+}
+
+inline flatbuffers::Offset<HeartbeatRaw> HeartbeatRaw::Pack(flatbuffers::FlatBufferBuilder &_fbb, const HeartbeatRawT* _o, const flatbuffers::rehasher_function_t *_rehasher) {
+  return CreateHeartbeatRaw(_fbb, _o, _rehasher);
+}
+
+inline flatbuffers::Offset<HeartbeatRaw> CreateHeartbeatRaw(flatbuffers::FlatBufferBuilder &_fbb, const HeartbeatRawT *_o, const flatbuffers::rehasher_function_t *_rehasher) {
+  (void)_rehasher;
+  (void)_o;
+  struct _VectorArgs { flatbuffers::FlatBufferBuilder *__fbb; const HeartbeatRawT* __o; const flatbuffers::rehasher_function_t *__rehasher; } _va = { &_fbb, _o, _rehasher}; (void)_va;
+  auto _calls = _o->calls.size() ? _fbb.CreateVectorOfStructs(_o->calls) : 0;
+  return rpc::CreateHeartbeatRaw(
+      _fbb,
+      _calls);
+}
+
 inline SnapshotHeaderT *SnapshotHeader::UnPack(const flatbuffers::resolver_function_t *_resolver) const {
   auto _o = std::unique_ptr<SnapshotHeaderT>(new SnapshotHeaderT());
   UnPackTo(_o.get(), _resolver);
@@ -3936,6 +4510,10 @@ inline bool VerifyExecutorMessagesRaw(flatbuffers::Verifier &verifier, const voi
       auto ptr = reinterpret_cast<const rpc::StateResultRaw *>(obj);
       return verifier.VerifyTable(ptr);
     }
+    case ExecutorMessagesRaw::Heartbeat: {
+      auto ptr = reinterpret_cast<const rpc::HeartbeatRaw *>(obj);
+      return verifier.VerifyTable(ptr);
+    }
     default: return true;
   }
 }
@@ -3967,6 +4545,10 @@ inline void *ExecutorMessagesRawUnion::UnPack(const void *obj, ExecutorMessagesR
       auto ptr = reinterpret_cast<const rpc::StateResultRaw *>(obj);
       return ptr->UnPack(resolver);
     }
+    case ExecutorMessagesRaw::Heartbeat: {
+      auto ptr = reinterpret_cast<const rpc::HeartbeatRaw *>(obj);
+      return ptr->UnPack(resolver);
+    }
     default: return nullptr;
   }
 }
@@ -3986,6 +4568,10 @@ inline flatbuffers::Offset<void> ExecutorMessagesRawUnion::Pack(flatbuffers::Fla
       auto ptr = reinterpret_cast<const rpc::StateResultRawT *>(value);
       return CreateStateResultRaw(_fbb, ptr, _rehasher).Union();
     }
+    case ExecutorMessagesRaw::Heartbeat: {
+      auto ptr = reinterpret_cast<const rpc::HeartbeatRawT *>(value);
+      return CreateHeartbeatRaw(_fbb, ptr, _rehasher).Union();
+    }
     default: return 0;
   }
 }
@@ -4004,6 +4590,10 @@ inline ExecutorMessagesRawUnion::ExecutorMessagesRawUnion(const ExecutorMessages
       value = new rpc::StateResultRawT(*reinterpret_cast<rpc::StateResultRawT *>(u.value));
       break;
     }
+    case ExecutorMessagesRaw::Heartbeat: {
+      value = new rpc::HeartbeatRawT(*reinterpret_cast<rpc::HeartbeatRawT *>(u.value));
+      break;
+    }
     default:
       break;
   }
@@ -4026,6 +4616,11 @@ inline void ExecutorMessagesRawUnion::Reset() {
       delete ptr;
       break;
     }
+    case ExecutorMessagesRaw::Heartbeat: {
+      auto ptr = reinterpret_cast<rpc::HeartbeatRawT *>(value);
+      delete ptr;
+      break;
+    }
     default: break;
   }
   value = nullptr;
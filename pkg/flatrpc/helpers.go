@@ -37,10 +37,14 @@ type CorpusTriaged = CorpusTriagedRawT
 type ExecutingMessage = ExecutingMessageRawT
 type CallInfo = CallInfoRawT
 type Comparison = ComparisonRawT
+type CallTrace = CallTraceRawT
+type CallBuffer = CallBufferRawT
 type ExecOpts = ExecOptsRawT
 type ProgInfo = ProgInfoRawT
 type ExecResult = ExecResultRawT
 type StateResult = StateResultRawT
+type Heartbeat = HeartbeatRawT
+type ExecutingCall = ExecutingCallRawT
 
 func init() {
 	var req ExecRequest
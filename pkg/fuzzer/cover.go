@@ -5,7 +5,9 @@ package fuzzer
 
 import (
 	"sync"
+	"sync/atomic"
 
+	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/signal"
 	"github.com/google/syzkaller/pkg/stat"
 )
@@ -49,3 +51,60 @@ func (cover *Cover) GrabSignalDelta() signal.Signal {
 	cover.newSignal = nil
 	return plus
 }
+
+// HitCounts accumulates the number of times coverage PCs were executed.
+// Since deduplicated coverage does not contain the number of executions, the fuzzer samples
+// a fraction of executions with raw coverage (see Config.HitCountSampling).
+type HitCounts struct {
+	sampling int
+	requests atomic.Uint64
+	mu       sync.Mutex
+	execs    int
+	pcs      map[uint64]uint64
+}
+
+func newHitCounts(sampling int) *HitCounts {
+	hc := &HitCounts{
+		sampling: sampling,
+		pcs:      make(map[uint64]uint64),
+	}
+	if sampling != 0 {
+		stat.New("hit count execs", "Executions sampled for coverage hit counts",
+			stat.Simple, stat.NoGraph, func() int {
+				hc.mu.Lock()
+				defer hc.mu.Unlock()
+				return hc.execs
+			})
+	}
+	return hc
+}
+
+// sample returns true for 1 out of sampling requests.
+func (hc *HitCounts) sample() bool {
+	return hc.sampling != 0 && hc.requests.Add(1)%uint64(hc.sampling) == 0
+}
+
+func (hc *HitCounts) add(info *flatrpc.ProgInfo) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	hc.execs++
+	for _, call := range append(info.Calls, info.Extra) {
+		if call == nil {
+			continue
+		}
+		for _, pc := range call.Cover {
+			hc.pcs[pc]++
+		}
+	}
+}
+
+// Get returns the number of times each PC was executed and the number of the sampled executions.
+func (hc *HitCounts) Get() (map[uint64]uint64, int) {
+	hc.mu.Lock()
+	defer hc.mu.Unlock()
+	res := make(map[uint64]uint64, len(hc.pcs))
+	for pc, hits := range hc.pcs {
+		res[pc] = hits
+	}
+	return res, hc.execs
+}
@@ -24,8 +24,9 @@ import (
 
 type Fuzzer struct {
 	Stats
-	Config *Config
-	Cover  *Cover
+	Config    *Config
+	Cover     *Cover
+	HitCounts *HitCounts
 
 	ctx          context.Context
 	mu           sync.Mutex
@@ -50,9 +51,10 @@ func NewFuzzer(ctx context.Context, cfg *Config, rnd *rand.Rand,
 		}
 	}
 	f := &Fuzzer{
-		Stats:  newStats(target),
-		Config: cfg,
-		Cover:  newCover(),
+		Stats:     newStats(target),
+		Config:    cfg,
+		Cover:     newCover(),
+		HitCounts: newHitCounts(cfg.HitCountSampling),
 
 		ctx:         ctx,
 		rnd:         rnd,
@@ -73,6 +75,7 @@ func NewFuzzer(ctx context.Context, cfg *Config, rnd *rand.Rand,
 }
 
 type execQueues struct {
+	traceQueue           *queue.PlainQueue
 	triageCandidateQueue *queue.DynamicOrderer
 	candidateQueue       *queue.PlainQueue
 	triageQueue          *queue.DynamicOrderer
@@ -82,6 +85,7 @@ type execQueues struct {
 
 func newExecQueues(fuzzer *Fuzzer) execQueues {
 	ret := execQueues{
+		traceQueue:           queue.Plain(),
 		triageCandidateQueue: queue.DynamicOrder(),
 		candidateQueue:       queue.Plain(),
 		triageQueue:          queue.DynamicOrder(),
@@ -97,6 +101,7 @@ func newExecQueues(fuzzer *Fuzzer) execQueues {
 	}
 	// Sources are listed in the order, in which they will be polled.
 	ret.source = queue.Order(
+		ret.traceQueue,
 		ret.triageCandidateQueue,
 		ret.candidateQueue,
 		ret.triageQueue,
@@ -124,7 +129,22 @@ func (fuzzer *Fuzzer) executeWithFlags(executor queue.Executor, req *queue.Reque
 }
 
 func (fuzzer *Fuzzer) prepare(req *queue.Request, flags ProgFlags, attempt int) {
+	// Requests that already collect coverage or comparisons need deduplicated coverage.
+	countHits := req.ExecOpts.ExecFlags&(flatrpc.ExecFlagCollectCover|flatrpc.ExecFlagCollectComps) == 0 &&
+		fuzzer.HitCounts.sample()
+	if countHits {
+		// Raw coverage contains a PC for each execution of a basic block.
+		req.ExecOpts.ExecFlags |= flatrpc.ExecFlagCollectCover
+		req.ExecOpts.ExecFlags &^= flatrpc.ExecFlagDedupCover
+	}
+	if fuzzer.Config.TargetDistances != nil && req.ExecOpts.ExecFlags&flatrpc.ExecFlagCollectSignal != 0 {
+		// Signal priority depends on the covered PCs.
+		req.ExecOpts.ExecFlags |= flatrpc.ExecFlagCollectCover
+	}
 	req.OnDone(func(req *queue.Request, res *queue.Result) bool {
+		if countHits && res.Info != nil {
+			fuzzer.HitCounts.add(res.Info)
+		}
 		return fuzzer.processResult(req, res, flags, attempt)
 	})
 }
@@ -137,7 +157,10 @@ func (fuzzer *Fuzzer) enqueue(executor queue.Executor, req *queue.Request, flags
 func (fuzzer *Fuzzer) processResult(req *queue.Request, res *queue.Result, flags ProgFlags, attempt int) bool {
 	// If we are already triaging this exact prog, this is flaky coverage.
 	// Hanged programs are harmful as they consume executor procs.
-	dontTriage := flags&progInTriage > 0 || res.Status == queue.Hanged
+	// Programs that exhaust resources of the test machine are harmful as they lead to OOMs
+	// and other failures that are hard to attribute to a particular program.
+	resourceHog := res.Info != nil && fuzzer.Config.resourceExhausting(res.Info)
+	dontTriage := flags&progInTriage > 0 || res.Status == queue.Hanged || resourceHog
 	// Triage the program.
 	// We do it before unblocking the waiting threads because
 	// it may result it concurrent modification of req.Prog.
@@ -174,6 +197,7 @@ func (fuzzer *Fuzzer) processResult(req *queue.Request, res *queue.Result, flags
 
 	if res.Info != nil {
 		fuzzer.statExecTime.Add(int(res.Info.Elapsed / 1e6))
+		fuzzer.handleResourceUsage(res.Info, resourceHog)
 		for call, info := range res.Info.Calls {
 			fuzzer.handleCallInfo(req, info, call)
 		}
@@ -214,13 +238,45 @@ type Config struct {
 	FetchRawCover  bool
 	NewInputFilter func(call string) bool
 	PatchTest      bool
+	// TargetDistances enables directed fuzzing: it maps coverage PCs to their distance
+	// to the target functions (see backend.TargetDistances).
+	// Calls that get closer to the targets get higher signal priority.
+	TargetDistances map[uint64]float64
+	// HitCountSampling enables collection of coverage hit counts (see HitCounts):
+	// 1 out of HitCountSampling executions is done with raw (not deduplicated) coverage.
+	HitCountSampling int
+	// Programs whose test process has peak RSS of at least MaxProgPeakRSS bytes or that leave
+	// at least MaxProgOpenFds fds open are not triaged. 0 means no limit.
+	// Note: peak RSS includes the memory inherited from the executor.
+	MaxProgPeakRSS uint64
+	MaxProgOpenFds uint32
+}
+
+func (cfg *Config) resourceExhausting(info *flatrpc.ProgInfo) bool {
+	return cfg.MaxProgPeakRSS != 0 && info.PeakRss >= cfg.MaxProgPeakRSS ||
+		cfg.MaxProgOpenFds != 0 && info.OpenFds >= cfg.MaxProgOpenFds
+}
+
+func (fuzzer *Fuzzer) handleResourceUsage(info *flatrpc.ProgInfo, resourceHog bool) {
+	if info.PeakRss == 0 {
+		// The executor did not report resource usage (the OS does not support it).
+		return
+	}
+	fuzzer.statProgPeakRSS.Add(int(info.PeakRss >> 20))
+	fuzzer.statProgOpenFds.Add(int(info.OpenFds))
+	fuzzer.statProgUserTime.Add(int(info.UserTime / 1e6))
+	fuzzer.statProgSystemTime.Add(int(info.SystemTime / 1e6))
+	fuzzer.statProgPageFaults.Add(int(info.PageFaults))
+	if resourceHog {
+		fuzzer.statProgResourceHogs.Add(1)
+	}
 }
 
 func (fuzzer *Fuzzer) triageProgCall(p *prog.Prog, info *flatrpc.CallInfo, call int, triage *map[int]*triageCall) {
 	if info == nil {
 		return
 	}
-	prio := signalPrio(p, info, call)
+	prio := signalPrio(p, info, call, fuzzer.Config.TargetDistances)
 	newMaxSignal := fuzzer.Cover.addRawMaxSignal(info.Signal, prio)
 	if newMaxSignal.Empty() {
 		return
@@ -243,22 +299,26 @@ func (fuzzer *Fuzzer) handleCallInfo(req *queue.Request, info *flatrpc.CallInfo,
 	if info == nil || info.Flags&flatrpc.CallFlagCoverageOverflow == 0 {
 		return
 	}
-	syscallIdx := len(fuzzer.Syscalls) - 1
+	syscallIdx, syscallName := len(fuzzer.Syscalls)-1, "extra"
 	if call != -1 {
-		syscallIdx = req.Prog.Calls[call].Meta.ID
+		syscallIdx, syscallName = req.Prog.Calls[call].Meta.ID, req.Prog.Calls[call].Meta.Name
 	}
 	stat := &fuzzer.Syscalls[syscallIdx]
 	if req.ExecOpts.ExecFlags&flatrpc.ExecFlagCollectComps != 0 {
 		stat.CompsOverflows.Add(1)
+		fuzzer.statCompsOverflows.With(syscallName).Add(1)
 	} else {
 		stat.CoverOverflows.Add(1)
+		fuzzer.statCoverOverflows.With(syscallName).Add(1)
 	}
 }
 
-func signalPrio(p *prog.Prog, info *flatrpc.CallInfo, call int) (prio uint8) {
+func signalPrio(p *prog.Prog, info *flatrpc.CallInfo, call int, distances map[uint64]float64) (prio uint8) {
 	if call == -1 {
 		return 0
 	}
+	// Proximity to the targets is more important than the rest.
+	prio |= targetProximity(info.Cover, distances) << 2
 	if info.Error == 0 {
 		prio |= 1 << 1
 	}
@@ -268,6 +328,22 @@ func signalPrio(p *prog.Prog, info *flatrpc.CallInfo, call int) (prio uint8) {
 	return
 }
 
+// maxProximity corresponds to the coverage of the target functions.
+const maxProximity = 7
+
+// targetProximity returns a value in [0, maxProximity] that shows how close the coverage gets to the targets.
+func targetProximity(cover []uint64, distances map[uint64]float64) uint8 {
+	res := uint8(0)
+	for _, pc := range cover {
+		dist, ok := distances[pc]
+		if !ok || dist >= maxProximity {
+			continue
+		}
+		res = max(res, maxProximity-uint8(dist))
+	}
+	return res
+}
+
 func (fuzzer *Fuzzer) genFuzz() *queue.Request {
 	// Either generate a new input or mutate an existing one.
 	mutateRate := 0.95
@@ -365,6 +441,17 @@ func (fuzzer *Fuzzer) AddCandidates(candidates []Candidate) {
 	}
 }
 
+// TraceProg executes the program with call tracing enabled and waits for the result.
+// The result is not used for fuzzing, it's only meant for debugging of the program.
+func (fuzzer *Fuzzer) TraceProg(ctx context.Context, p *prog.Prog) *queue.Result {
+	req := &queue.Request{
+		Prog:     p.Clone(),
+		ExecOpts: setFlags(flatrpc.ExecFlagCollectTrace),
+	}
+	fuzzer.traceQueue.Submit(req)
+	return req.Wait(ctx)
+}
+
 func (fuzzer *Fuzzer) rand() *rand.Rand {
 	fuzzer.mu.Lock()
 	defer fuzzer.mu.Unlock()
@@ -92,6 +92,83 @@ func TestFuzz(t *testing.T) {
 	}
 }
 
+func TestTargetProximity(t *testing.T) {
+	distances := map[uint64]float64{
+		0x10: 0,
+		0x20: 1.5,
+		0x30: 7,
+		0x40: 100,
+	}
+	assert.Equal(t, uint8(0), targetProximity(nil, distances))
+	assert.Equal(t, uint8(0), targetProximity([]uint64{0x1, 0x30, 0x40}, distances))
+	assert.Equal(t, uint8(6), targetProximity([]uint64{0x1, 0x20, 0x40}, distances))
+	assert.Equal(t, uint8(7), targetProximity([]uint64{0x20, 0x10}, distances))
+	assert.Equal(t, uint8(0), targetProximity([]uint64{0x10}, nil))
+}
+
+func TestHitCounts(t *testing.T) {
+	hc := newHitCounts(3)
+	var sampled []bool
+	for i := 0; i < 6; i++ {
+		sampled = append(sampled, hc.sample())
+	}
+	assert.Equal(t, []bool{false, false, true, false, false, true}, sampled)
+	hc.add(&flatrpc.ProgInfo{
+		Calls: []*flatrpc.CallInfo{
+			{Cover: []uint64{0x10, 0x20, 0x10, 0x20, 0x10}},
+			nil,
+			{Cover: []uint64{0x30}},
+		},
+		Extra: &flatrpc.CallInfo{Cover: []uint64{0x30, 0x40}},
+	})
+	hc.add(&flatrpc.ProgInfo{
+		Calls: []*flatrpc.CallInfo{{Cover: []uint64{0x10}}},
+	})
+	hits, execs := hc.Get()
+	assert.Equal(t, 2, execs)
+	assert.Equal(t, map[uint64]uint64{0x10: 4, 0x20: 2, 0x30: 2, 0x40: 1}, hits)
+	assert.False(t, newHitCounts(0).sample())
+}
+
+func TestResourceExhausting(t *testing.T) {
+	info := &flatrpc.ProgInfo{PeakRss: 100 << 20, OpenFds: 10}
+	assert.False(t, (&Config{}).resourceExhausting(info))
+	assert.False(t, (&Config{MaxProgPeakRSS: 200 << 20, MaxProgOpenFds: 20}).resourceExhausting(info))
+	assert.True(t, (&Config{MaxProgPeakRSS: 100 << 20}).resourceExhausting(info))
+	assert.True(t, (&Config{MaxProgPeakRSS: 200 << 20, MaxProgOpenFds: 10}).resourceExhausting(info))
+}
+
+func TestHandleResourceUsage(t *testing.T) {
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64Fuzz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fuzzer := &Fuzzer{Stats: newStats(target)}
+	// No resource usage reported.
+	fuzzer.handleResourceUsage(&flatrpc.ProgInfo{Elapsed: 1e9}, false)
+	assert.Equal(t, 0, fuzzer.statProgPeakRSS.Val())
+	fuzzer.handleResourceUsage(&flatrpc.ProgInfo{
+		PeakRss:    30 << 20,
+		OpenFds:    4,
+		UserTime:   2e6,
+		SystemTime: 10e6,
+		PageFaults: 100,
+	}, false)
+	fuzzer.handleResourceUsage(&flatrpc.ProgInfo{
+		PeakRss:    50 << 20,
+		OpenFds:    8,
+		UserTime:   4e6,
+		SystemTime: 20e6,
+		PageFaults: 300,
+	}, true)
+	assert.Equal(t, 40, fuzzer.statProgPeakRSS.Val())
+	assert.Equal(t, 6, fuzzer.statProgOpenFds.Val())
+	assert.Equal(t, 3, fuzzer.statProgUserTime.Val())
+	assert.Equal(t, 15, fuzzer.statProgSystemTime.Val())
+	assert.Equal(t, 200, fuzzer.statProgPageFaults.Val())
+	assert.Equal(t, 1, fuzzer.statProgResourceHogs.Val())
+}
+
 func BenchmarkFuzzer(b *testing.B) {
 	b.ReportAllocs()
 	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64Fuzz)
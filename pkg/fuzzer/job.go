@@ -273,7 +273,7 @@ func (job *triageJob) deflake(exec func(*queue.Request, ProgFlags) *queue.Result
 			// it won't be stable. However, it's still possible if we do more than needRuns runs.
 			// But also we already observed it and we know it's flaky, so at least doing
 			// cover.addRawMaxSignal for it looks useful.
-			prio := signalPrio(job.p, res, call)
+			prio := signalPrio(job.p, res, call, job.fuzzer.Config.TargetDistances)
 			newMaxSignal := job.fuzzer.Cover.addRawMaxSignal(res.Signal, prio)
 			info.newSignal.Merge(newMaxSignal)
 			info.cover.Merge(res.Cover)
@@ -371,7 +371,7 @@ func (job *triageJob) minimize(call int, info *triageCall) (*prog.Prog, int) {
 				// The call was not executed or failed.
 				continue
 			}
-			thisSignal := getSignalAndCover(p1, result.Info, call1)
+			thisSignal := getSignalAndCover(p1, result.Info, call1, job.fuzzer.Config.TargetDistances)
 			if mergedSignal.Len() == 0 {
 				mergedSignal = thisSignal
 			} else {
@@ -407,7 +407,7 @@ func reexecutionSuccess(info *flatrpc.ProgInfo, oldErrno int32, call int) bool {
 	return info.Extra != nil && len(info.Extra.Signal) != 0
 }
 
-func getSignalAndCover(p *prog.Prog, info *flatrpc.ProgInfo, call int) signal.Signal {
+func getSignalAndCover(p *prog.Prog, info *flatrpc.ProgInfo, call int, distances map[uint64]float64) signal.Signal {
 	inf := info.Extra
 	if call != -1 {
 		inf = info.Calls[call]
@@ -415,7 +415,7 @@ func getSignalAndCover(p *prog.Prog, info *flatrpc.ProgInfo, call int) signal.Si
 	if inf == nil {
 		return nil
 	}
-	return signal.FromRaw(inf.Signal, signalPrio(p, inf, call))
+	return signal.FromRaw(inf.Signal, signalPrio(p, inf, call, distances))
 }
 
 func signalPreview(s signal.Signal) string {
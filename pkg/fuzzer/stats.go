@@ -33,6 +33,14 @@ type Stats struct {
 	statExecHint            *stat.Val
 	statExecSeed            *stat.Val
 	statExecCollide         *stat.Val
+	statProgPeakRSS         *stat.Val
+	statProgOpenFds         *stat.Val
+	statProgUserTime        *stat.Val
+	statProgSystemTime      *stat.Val
+	statProgPageFaults      *stat.Val
+	statProgResourceHogs    *stat.Val
+	statCoverOverflows      *stat.Val
+	statCompsOverflows      *stat.Val
 }
 
 type SyscallStats struct {
@@ -51,34 +59,60 @@ func newStats(target *prog.Target) Stats {
 			stat.Graph("corpus")),
 		statJobs: stat.New("fuzzer jobs", "Total running fuzzer jobs", stat.NoGraph),
 		statJobsTriage: stat.New("triage jobs", "Running triage jobs", stat.StackedGraph("jobs"),
-			stat.Link("/jobs?type=triage")),
+			stat.Link("/jobs?type=triage"), stat.Prometheus("syz_fuzzer_running_jobs"), stat.Labels{"type": "triage"}),
 		statJobsTriageCandidate: stat.New("candidate triage jobs", "Running candidate triage jobs",
-			stat.StackedGraph("jobs"), stat.Link("/jobs?type=triage")),
+			stat.StackedGraph("jobs"), stat.Link("/jobs?type=triage"),
+			stat.Prometheus("syz_fuzzer_running_jobs"), stat.Labels{"type": "triage_candidate"}),
 		statJobsSmash: stat.New("smash jobs", "Running smash jobs", stat.StackedGraph("jobs"),
-			stat.Link("/jobs?type=smash")),
-		statJobsFaultInjection: stat.New("fault jobs", "Running fault injection jobs", stat.StackedGraph("jobs")),
+			stat.Link("/jobs?type=smash"), stat.Prometheus("syz_fuzzer_running_jobs"), stat.Labels{"type": "smash"}),
+		statJobsFaultInjection: stat.New("fault jobs", "Running fault injection jobs",
+			stat.StackedGraph("jobs"), stat.Prometheus("syz_fuzzer_running_jobs"), stat.Labels{"type": "fault"}),
 		statJobsHints: stat.New("hints jobs", "Running hints jobs", stat.StackedGraph("jobs"),
-			stat.Link("/jobs?type=hints")),
+			stat.Link("/jobs?type=hints"), stat.Prometheus("syz_fuzzer_running_jobs"), stat.Labels{"type": "hints"}),
 		statExecTime: stat.New("prog exec time", "Test program execution time (ms)", stat.Distribution{}),
 		statExecGenerate: stat.New("exec gen", "Executions of generated programs", stat.Rate{},
-			stat.StackedGraph("exec")),
+			stat.StackedGraph("exec"), stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "gen"}),
 		statExecFuzz: stat.New("exec fuzz", "Executions of mutated programs",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "fuzz"}),
 		statExecCandidate: stat.New("exec candidate", "Executions of candidate programs",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "candidate"}),
 		statExecTriage: stat.New("exec triage", "Executions of corpus triage programs",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "triage"}),
 		statExecMinimize: stat.New("exec minimize", "Executions of programs during minimization",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "minimize"}),
 		statExecSmash: stat.New("exec smash", "Executions of smashed programs",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "smash"}),
 		statExecFaultInject: stat.New("exec inject", "Executions of fault injection",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "inject"}),
 		statExecHint: stat.New("exec hints", "Executions of programs generated using hints",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "hints"}),
 		statExecSeed: stat.New("exec seeds", "Executions of programs for hints extraction",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "seeds"}),
 		statExecCollide: stat.New("exec collide", "Executions of programs in collide mode",
-			stat.Rate{}, stat.StackedGraph("exec")),
+			stat.Rate{}, stat.StackedGraph("exec"),
+			stat.Prometheus("syz_fuzzer_execs_total"), stat.Labels{"type": "collide"}),
+		statProgPeakRSS: stat.New("prog peak rss", "Peak RSS of the test process (MB)", stat.Distribution{}),
+		statProgOpenFds: stat.New("prog open fds", "Number of fds open at the end of the test program",
+			stat.Distribution{}),
+		statProgUserTime: stat.New("prog user time", "User CPU time of the test process (ms)",
+			stat.Distribution{}),
+		statProgSystemTime: stat.New("prog kernel time", "Kernel CPU time of the test process (ms)",
+			stat.Distribution{}),
+		statProgPageFaults: stat.New("prog page faults", "Page faults in the test process",
+			stat.Distribution{}),
+		statProgResourceHogs: stat.New("resource hogs", "Programs not triaged due to excessive resource usage",
+			stat.Rate{}, stat.NoGraph),
+		statCoverOverflows: stat.New("cover overflows", "Number of times coverage buffer of a syscall has overflowed",
+			stat.Rate{}, stat.NoGraph, stat.Link("/syscalls"), stat.LabelNames{"syscall"}),
+		statCompsOverflows: stat.New("comps overflows", "Number of times comparisons buffer of a syscall has overflowed",
+			stat.Rate{}, stat.NoGraph, stat.Link("/syscalls"), stat.LabelNames{"syscall"}),
 	}
 }
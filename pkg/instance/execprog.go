@@ -157,6 +157,9 @@ type ExecParams struct {
 	// Only one of these will be used, depending on the function.
 	CProg   *prog.Prog
 	SyzProg []byte
+	// CProgs are executed concurrently in separate processes by RunCProg (see csource.WriteParallel).
+	// If set, CProg is not used.
+	CProgs []*prog.Prog
 
 	Opts     csource.Options
 	Duration time.Duration
@@ -166,6 +169,15 @@ type ExecParams struct {
 }
 
 func (inst *ExecProgInstance) RunCProg(params ExecParams) (*RunResult, error) {
+	if len(params.CProgs) != 0 {
+		src, err := csource.WriteParallel(params.CProgs, params.Opts)
+		if err != nil {
+			return nil, err
+		}
+		inst.Logf(2, "testing compiled C program with %v parallel programs (duration=%v, %+v)",
+			len(params.CProgs), params.Duration, params.Opts)
+		return inst.RunCProgRaw(src, params.CProgs[0].Target, params.Duration)
+	}
 	src, err := csource.Write(params.CProg, params.Opts)
 	if err != nil {
 		return nil, err
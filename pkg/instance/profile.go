@@ -0,0 +1,355 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/pkg/csource"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/vm"
+)
+
+// ProfileMatrix describes the settings an existing reproducer is profiled with.
+// Empty dimensions are not varied (the base options and the manager config values are used).
+type ProfileMatrix struct {
+	// Configs are manager configs for different kernels/kernel configs.
+	Configs []ProfileConfig
+	// VMs is the number of VMs the runs are spread across (bounded by the VM count in the config).
+	// It's a parallelism knob rather than a property of the bug, see ProfileRequirements.
+	VMs []int
+	// CPUs is the number of CPUs per VM (requires a VM type that has the "cpu" parameter).
+	CPUs      []int
+	Sandboxes []string
+	Threaded  []bool
+	// Collide is only combined with threaded settings (collide requires threaded mode).
+	Collide []bool
+	Procs   []int
+}
+
+// ProfileConfig is a manager config of the matrix.
+type ProfileConfig struct {
+	// ID identifies the config in results (e.g. the config file path).
+	// It must be unique, config names are not suitable since different configs may share them.
+	ID     string
+	Config *mgrconfig.Config
+}
+
+// ProfileSetting is a single point of the matrix.
+type ProfileSetting struct {
+	Config string // ID of the ProfileConfig
+	VMs    int
+	CPUs   int // 0 means the value from the manager config
+	Opts   csource.Options
+}
+
+func (s ProfileSetting) String() string {
+	cpus := "default"
+	if s.CPUs != 0 {
+		cpus = fmt.Sprint(s.CPUs)
+	}
+	return fmt.Sprintf("config=%v vms=%v cpus=%v sandbox=%v threaded=%v collide=%v procs=%v",
+		s.Config, s.VMs, cpus, s.Opts.Sandbox, s.Opts.Threaded, s.Opts.Collide, s.Opts.Procs)
+}
+
+type ProfileResult struct {
+	ProfileSetting
+	Runs    int
+	Crashes int
+	// Errors is the number of runs that failed for infrastructure reasons, they are not counted in Runs.
+	Errors int
+	// Titles maps crash titles to the number of runs that crashed with it.
+	Titles map[string]int
+}
+
+func (res *ProfileResult) Reliability() float64 {
+	if res.Runs == 0 {
+		return 0
+	}
+	return float64(res.Crashes) / float64(res.Runs)
+}
+
+// Settings enumerates option combinations of the matrix for the given VM configuration.
+func (m *ProfileMatrix) Settings(config string, vms, cpus int, base csource.Options) []ProfileSetting {
+	var ret []ProfileSetting
+	for _, sandbox := range orDefault(m.Sandboxes, base.Sandbox) {
+		for _, threaded := range orDefault(m.Threaded, base.Threaded) {
+			for _, collide := range orDefault(m.Collide, base.Collide) {
+				if collide && !threaded {
+					if len(m.Collide) != 0 {
+						continue
+					}
+					collide = false
+				}
+				for _, procs := range orDefault(m.Procs, base.Procs) {
+					opts := base
+					opts.Sandbox = sandbox
+					opts.Threaded = threaded
+					opts.Collide = collide
+					opts.Procs = procs
+					ret = append(ret, ProfileSetting{
+						Config: config,
+						VMs:    vms,
+						CPUs:   cpus,
+						Opts:   opts,
+					})
+				}
+			}
+		}
+	}
+	return ret
+}
+
+func orDefault[T any](vals []T, def T) []T {
+	if len(vals) == 0 {
+		return []T{def}
+	}
+	return vals
+}
+
+type ProfileParams struct {
+	Matrix   ProfileMatrix
+	ReproSyz []byte
+	Opts     csource.Options
+	// Runs is the number of reproducer runs per setting, each run uses a fresh VM.
+	Runs     int
+	Duration time.Duration
+	Logf     ExecutorLogger
+}
+
+// ProfileRepro runs the reproducer params.Runs times for each setting of the matrix.
+// Settings for which VMs could not be created are skipped with an error logged.
+func ProfileRepro(params ProfileParams) ([]*ProfileResult, error) {
+	if params.Logf == nil {
+		params.Logf = func(int, string, ...interface{}) {}
+	}
+	if len(params.Matrix.Configs) == 0 {
+		return nil, fmt.Errorf("no manager configs")
+	}
+	ids := make(map[string]bool)
+	for _, config := range params.Matrix.Configs {
+		if config.ID == "" {
+			return nil, fmt.Errorf("manager config %q has no ID", config.Config.Name)
+		}
+		if ids[config.ID] {
+			return nil, fmt.Errorf("duplicate manager config %q", config.ID)
+		}
+		ids[config.ID] = true
+	}
+	var ret []*ProfileResult
+	for _, config := range params.Matrix.Configs {
+		for _, cpus := range orDefault(params.Matrix.CPUs, 0) {
+			cfg := *config.Config
+			if cpus != 0 {
+				if err := OverrideVMCPUs(&cfg, cpus); err != nil {
+					return nil, err
+				}
+			}
+			res, err := profileConfig(config.ID, &cfg, cpus, params)
+			if err != nil {
+				params.Logf(0, "%v: cpus=%v: %v", config.ID, cpus, err)
+				continue
+			}
+			ret = append(ret, res...)
+		}
+	}
+	return ret, nil
+}
+
+func profileConfig(id string, cfg *mgrconfig.Config, cpus int, params ProfileParams) ([]*ProfileResult, error) {
+	reporter, err := report.NewReporter(cfg)
+	if err != nil {
+		return nil, err
+	}
+	vmPool, err := vm.Create(cfg, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create VM pool: %w", err)
+	}
+	defer vmPool.Close()
+	var ret []*ProfileResult
+	for _, vms := range orDefault(params.Matrix.VMs, vmPool.Count()) {
+		vms = min(vms, vmPool.Count())
+		for _, setting := range params.Matrix.Settings(id, vms, cpus, params.Opts) {
+			res := profileSetting(cfg, vmPool, reporter, setting, params)
+			params.Logf(0, "%v: %v/%v crashes (%v errors)", setting, res.Crashes, res.Runs, res.Errors)
+			ret = append(ret, res)
+		}
+	}
+	return ret, nil
+}
+
+func profileSetting(cfg *mgrconfig.Config, vmPool *vm.Pool, reporter *report.Reporter,
+	setting ProfileSetting, params ProfileParams) *ProfileResult {
+	res := &ProfileResult{
+		ProfileSetting: setting,
+		Titles:         make(map[string]int),
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan bool, params.Runs)
+	for i := 0; i < params.Runs; i++ {
+		jobs <- true
+	}
+	close(jobs)
+	for idx := 0; idx < setting.VMs; idx++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range jobs {
+				rep, err := profileRun(cfg, vmPool, idx, reporter, setting, params)
+				mu.Lock()
+				switch {
+				case err != nil:
+					params.Logf(1, "VM %v: %v", idx, err)
+					res.Errors++
+				case rep != nil:
+					res.Runs++
+					res.Crashes++
+					res.Titles[rep.Title]++
+				default:
+					res.Runs++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return res
+}
+
+func profileRun(cfg *mgrconfig.Config, vmPool *vm.Pool, idx int, reporter *report.Reporter,
+	setting ProfileSetting, params ProfileParams) (*report.Report, error) {
+	inst, err := CreateExecProgInstance(vmPool, idx, cfg, reporter, &OptionalConfig{Logf: params.Logf})
+	if err != nil {
+		return nil, err
+	}
+	defer inst.VMInstance.Close()
+	res, err := inst.RunSyzProg(ExecParams{
+		SyzProg:  params.ReproSyz,
+		Opts:     setting.Opts,
+		Duration: params.Duration,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Report, nil
+}
+
+// OverrideVMCPUs patches the number of CPUs per VM in the VM config.
+func OverrideVMCPUs(cfg *mgrconfig.Config, n int) error {
+	if cfg.Type != "qemu" && cfg.Type != "bhyve" {
+		return fmt.Errorf("VM type %q does not support the cpu parameter", cfg.Type)
+	}
+	vmConfig := make(map[string]interface{})
+	if err := json.Unmarshal(cfg.VM, &vmConfig); err != nil {
+		return fmt.Errorf("failed to parse VM config: %w", err)
+	}
+	vmConfig["cpu"] = n
+	vmCfg, err := json.Marshal(vmConfig)
+	if err != nil {
+		return fmt.Errorf("failed to serialize VM config: %w", err)
+	}
+	cfg.VM = vmCfg
+	return nil
+}
+
+// ProfileRequirements summarizes which values of the profiled dimensions are needed to trigger the bug:
+// a categorical value (config, sandbox, threaded, collide) is required if all crashing settings share it
+// while other tested values never crashed; for numeric dimensions (CPUs, procs)
+// the minimal value that crashed is reported if smaller values were tested and never crashed.
+// The number of VMs is not a requirement: every run uses a fresh VM, so the VM count only changes
+// how many runs are executed at once. Its effect is still visible in the FormatProfile table.
+// Returns nil if nothing crashed and an empty slice if the bug does not depend on the settings.
+func ProfileRequirements(results []*ProfileResult) []string {
+	type dimension struct {
+		name    string
+		numeric bool
+		value   func(*ProfileResult) int
+		format  func(*ProfileResult) string
+	}
+	dims := []dimension{
+		{name: "config", format: func(r *ProfileResult) string { return r.Config }},
+		{name: "cpus", numeric: true, value: func(r *ProfileResult) int { return r.CPUs }},
+		{name: "sandbox", format: func(r *ProfileResult) string { return r.Opts.Sandbox }},
+		{name: "threaded", format: func(r *ProfileResult) string { return fmt.Sprint(r.Opts.Threaded) }},
+		{name: "collide", format: func(r *ProfileResult) string { return fmt.Sprint(r.Opts.Collide) }},
+		{name: "procs", numeric: true, value: func(r *ProfileResult) int { return r.Opts.Procs }},
+	}
+	crashed := false
+	for _, res := range results {
+		crashed = crashed || res.Crashes != 0
+	}
+	if !crashed {
+		return nil
+	}
+	ret := []string{}
+	for _, dim := range dims {
+		if dim.numeric {
+			minTested, minCrashed := -1, -1
+			for _, res := range results {
+				val := dim.value(res)
+				if minTested == -1 || val < minTested {
+					minTested = val
+				}
+				if res.Crashes != 0 && (minCrashed == -1 || val < minCrashed) {
+					minCrashed = val
+				}
+			}
+			// CPUs=0 stands for the config default, so it can't be compared with explicit values.
+			if minCrashed > minTested && (dim.name != "cpus" || minTested != 0) {
+				ret = append(ret, fmt.Sprintf("%v>=%v", dim.name, minCrashed))
+			}
+			continue
+		}
+		tested, crashedVals := make(map[string]bool), make(map[string]bool)
+		for _, res := range results {
+			val := dim.format(res)
+			tested[val] = true
+			if res.Crashes != 0 {
+				crashedVals[val] = true
+			}
+		}
+		if len(crashedVals) < len(tested) {
+			var vals []string
+			for val := range crashedVals {
+				vals = append(vals, val)
+			}
+			sort.Strings(vals)
+			ret = append(ret, fmt.Sprintf("%v=%v", dim.name, strings.Join(vals, "|")))
+		}
+	}
+	return ret
+}
+
+// FormatProfile returns a human-readable reliability table.
+func FormatProfile(results []*ProfileResult) []byte {
+	buf := new(strings.Builder)
+	fmt.Fprintf(buf, "%-20v %4v %7v %-10v %-8v %-7v %5v %12v  %v\n",
+		"config", "vms", "cpus", "sandbox", "threaded", "collide", "procs", "reliability", "titles")
+	for _, res := range results {
+		cpus := "default"
+		if res.CPUs != 0 {
+			cpus = fmt.Sprint(res.CPUs)
+		}
+		var titles []string
+		for title, count := range res.Titles {
+			titles = append(titles, fmt.Sprintf("%v (%v)", title, count))
+		}
+		sort.Strings(titles)
+		reliability := fmt.Sprintf("%v/%v", res.Crashes, res.Runs)
+		if res.Errors != 0 {
+			reliability += fmt.Sprintf(" +%v err", res.Errors)
+		}
+		fmt.Fprintf(buf, "%-20v %4v %7v %-10v %-8v %-7v %5v %12v  %v\n",
+			res.Config, res.VMs, cpus, res.Opts.Sandbox, res.Opts.Threaded, res.Opts.Collide, res.Opts.Procs,
+			reliability, strings.Join(titles, ", "))
+	}
+	return []byte(buf.String())
+}
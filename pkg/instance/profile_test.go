@@ -0,0 +1,184 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package instance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/syzkaller/pkg/csource"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+)
+
+func TestProfileSettings(t *testing.T) {
+	base := csource.Options{
+		Sandbox:  "none",
+		Threaded: true,
+		Procs:    1,
+		LegacyOptions: csource.LegacyOptions{
+			Collide: true,
+		},
+	}
+	m := &ProfileMatrix{
+		Sandboxes: []string{"none", "namespace"},
+		Threaded:  []bool{false, true},
+	}
+	settings := m.Settings("cfg", 2, 0, base)
+	if len(settings) != 4 {
+		t.Fatalf("got %v settings, want 4", len(settings))
+	}
+	for _, s := range settings {
+		if s.Config != "cfg" || s.VMs != 2 || s.Opts.Procs != 1 {
+			t.Errorf("bad setting: %v", s)
+		}
+		if s.Opts.Collide && !s.Opts.Threaded {
+			t.Errorf("collide without threaded: %v", s)
+		}
+	}
+	m.Collide = []bool{false, true}
+	settings = m.Settings("cfg", 2, 0, base)
+	// Non-threaded settings are not combined with collide.
+	if len(settings) != 6 {
+		t.Fatalf("got %v settings with collide, want 6", len(settings))
+	}
+	for _, s := range settings {
+		if s.Opts.Collide && !s.Opts.Threaded {
+			t.Errorf("collide without threaded: %v", s)
+		}
+	}
+	if settings := new(ProfileMatrix).Settings("cfg", 1, 0, base); len(settings) != 1 ||
+		settings[0].Opts != base {
+		t.Errorf("empty matrix: got %+v, want base options", settings)
+	}
+}
+
+func TestProfileRequirements(t *testing.T) {
+	result := func(config string, cpus int, sandbox string, threaded bool, procs, crashes int) *ProfileResult {
+		return &ProfileResult{
+			ProfileSetting: ProfileSetting{
+				Config: config,
+				VMs:    1,
+				CPUs:   cpus,
+				Opts: csource.Options{
+					Sandbox:  sandbox,
+					Threaded: threaded,
+					Procs:    procs,
+				},
+			},
+			Runs:    10,
+			Crashes: crashes,
+		}
+	}
+	withCollide := func(res *ProfileResult) *ProfileResult {
+		res.Opts.Collide = true
+		return res
+	}
+	withVMs := func(res *ProfileResult, vms int) *ProfileResult {
+		res.VMs = vms
+		return res
+	}
+	tests := []struct {
+		name    string
+		results []*ProfileResult
+		want    []string
+	}{
+		{
+			name: "no-crashes",
+			results: []*ProfileResult{
+				result("a", 1, "none", true, 1, 0),
+			},
+		},
+		{
+			name: "always",
+			results: []*ProfileResult{
+				result("a", 1, "none", true, 1, 3),
+				result("a", 2, "namespace", false, 4, 5),
+			},
+			want: []string{},
+		},
+		{
+			name: "sandbox-and-cpus",
+			results: []*ProfileResult{
+				result("a", 1, "none", true, 1, 0),
+				result("a", 1, "namespace", true, 1, 0),
+				result("a", 2, "none", true, 1, 0),
+				result("a", 2, "namespace", true, 1, 2),
+				result("a", 4, "namespace", true, 1, 7),
+			},
+			want: []string{"cpus>=2", "sandbox=namespace"},
+		},
+		{
+			name: "config-and-procs",
+			results: []*ProfileResult{
+				result("a", 0, "none", false, 1, 0),
+				result("a", 0, "none", false, 2, 1),
+				result("b", 0, "none", true, 1, 0),
+				result("b", 0, "none", true, 2, 0),
+				result("c", 0, "none", true, 2, 1),
+			},
+			want: []string{"config=a|c", "procs>=2"},
+		},
+		{
+			name: "vms",
+			results: []*ProfileResult{
+				result("a", 1, "none", true, 1, 0),
+				withVMs(result("a", 1, "none", true, 1, 3), 4),
+			},
+			want: []string{},
+		},
+		{
+			name: "collide",
+			results: []*ProfileResult{
+				result("a", 1, "none", true, 1, 0),
+				withCollide(result("a", 1, "none", true, 1, 4)),
+				result("a", 1, "none", false, 1, 0),
+			},
+			want: []string{"threaded=true", "collide=true"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := ProfileRequirements(test.results)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOverrideVMCPUs(t *testing.T) {
+	cfg := &mgrconfig.Config{
+		Type: "qemu",
+		VM:   []byte(`{"count": 4, "mem": 2048}`),
+	}
+	if err := OverrideVMCPUs(cfg, 8); err != nil {
+		t.Fatal(err)
+	}
+	vmCfg := make(map[string]interface{})
+	if err := json.Unmarshal(cfg.VM, &vmCfg); err != nil {
+		t.Fatal(err)
+	}
+	if vmCfg["cpu"] != 8.0 || vmCfg["count"] != 4.0 || vmCfg["mem"] != 2048.0 {
+		t.Errorf("bad VM config: %s", cfg.VM)
+	}
+	if err := OverrideVMCPUs(&mgrconfig.Config{Type: "gce", VM: []byte(`{}`)}, 2); err == nil {
+		t.Errorf("expected an error for gce")
+	}
+}
+
+func TestProfileReproDuplicateConfigs(t *testing.T) {
+	cfg := &mgrconfig.Config{Name: "ci-qemu"}
+	_, err := ProfileRepro(ProfileParams{
+		Matrix: ProfileMatrix{
+			Configs: []ProfileConfig{
+				{ID: "a/manager.cfg", Config: cfg},
+				{ID: "a/manager.cfg", Config: cfg},
+			},
+		},
+	})
+	if err == nil || err.Error() != `duplicate manager config "a/manager.cfg"` {
+		t.Fatalf("expected a duplicate config error, got: %v", err)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/syzkaller/pkg/corpus"
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/stat"
+)
+
+// AutoFocus periodically selects focus areas around the coverage frontier:
+// uncovered functions that are directly called from covered functions.
+// Programs that cover the callers are the most likely ones to reach the uncovered functions
+// after mutations, so in the "apply" mode the fuzzer is made to choose them more often.
+type AutoFocus struct {
+	cfg    *mgrconfig.Config
+	source *ReportGeneratorWrapper
+	// filter is the executor coverage filter (if any), we can't get coverage outside of it.
+	filter map[uint64]struct{}
+	state  atomic.Pointer[AutoFocusState]
+}
+
+type AutoFocusState struct {
+	Time    time.Time
+	Applied bool
+	Areas   []*AutoFocusArea
+	Error   string
+}
+
+type AutoFocusArea struct {
+	// Target is the uncovered function.
+	Target string
+	// Callers are the covered functions that call Target.
+	Callers []string
+	// UncoveredPCs is the number of coverage points in Target and in the uncovered functions
+	// that are reachable from it.
+	UncoveredPCs int
+	Weight       float64
+
+	target  *backend.Symbol
+	callers []*backend.Symbol
+}
+
+const (
+	autoFocusPeriod = 30 * time.Minute
+	// autoFocusDepth limits how deep we look for uncovered functions reachable from the target.
+	autoFocusDepth = 3
+)
+
+func NewAutoFocus(cfg *mgrconfig.Config, source *ReportGeneratorWrapper,
+	filter map[uint64]struct{}) *AutoFocus {
+	af := &AutoFocus{
+		cfg:    cfg,
+		source: source,
+		filter: filter,
+	}
+	stat.New("auto focus", "Number of automatically selected focus areas",
+		stat.Simple, stat.NoGraph, stat.Link("/autofocus"), func() int {
+			if state := af.State(); state != nil {
+				return len(state.Areas)
+			}
+			return 0
+		})
+	return af
+}
+
+// State returns the last selected areas, or nil if they were not selected yet.
+func (af *AutoFocus) State() *AutoFocusState {
+	return af.state.Load()
+}
+
+func (af *AutoFocus) Loop(ctx context.Context, corpusObj *corpus.Corpus) {
+	ticker := time.NewTicker(autoFocusPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		state, err := af.Update(corpusObj)
+		if err != nil {
+			log.Logf(0, "auto focus failed: %v", err)
+			af.state.Store(&AutoFocusState{Time: time.Now(), Error: err.Error()})
+			continue
+		}
+		log.Logf(1, "auto focus: selected %v areas", len(state.Areas))
+	}
+}
+
+// Update selects new focus areas based on the current corpus coverage,
+// and applies them to the corpus if configured.
+func (af *AutoFocus) Update(corpusObj *corpus.Corpus) (*AutoFocusState, error) {
+	rg, err := af.source.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report generator: %w", err)
+	}
+	covered := make(map[uint64]bool)
+	for _, pc := range CoverToPCs(af.cfg, corpusObj.Cover()) {
+		covered[pc] = true
+	}
+	allowed := func(sym *backend.Symbol) bool {
+		if af.filter == nil {
+			return true
+		}
+		_, ok := af.filter[backend.NextInstructionPC(af.cfg.SysTarget, af.cfg.Type, sym.PCs[0])]
+		return ok
+	}
+	cfg := af.cfg.Experimental.AutoFocus
+	state := &AutoFocusState{
+		Time:    time.Now(),
+		Applied: cfg.Mode == mgrconfig.AutoFocusApply,
+		Areas:   selectAutoFocusAreas(rg.Symbols, covered, allowed, cfg.Areas, cfg.Weight),
+	}
+	if state.Applied {
+		var areas []corpus.FocusArea
+		for _, area := range state.Areas {
+			areas = append(areas, corpus.FocusArea{
+				Name:     "auto: " + area.Target,
+				CoverPCs: af.coverPCs(area),
+				Weight:   area.Weight,
+			})
+		}
+		corpusObj.SetAutoFocusAreas(areas)
+	}
+	af.state.Store(state)
+	return state, nil
+}
+
+// coverPCs returns the PCs (as reported by KCOV) that are matched against the corpus programs.
+func (af *AutoFocus) coverPCs(area *AutoFocusArea) map[uint64]struct{} {
+	pcs := make(map[uint64]struct{})
+	for _, sym := range append([]*backend.Symbol{area.target}, area.callers...) {
+		for _, pc := range sym.PCs {
+			pcs[backend.NextInstructionPC(af.cfg.SysTarget, af.cfg.Type, pc)] = struct{}{}
+		}
+	}
+	return pcs
+}
+
+// selectAutoFocusAreas returns up to n areas with the largest number of reachable uncovered PCs.
+// The weight is split between the areas proportionally to the number of uncovered PCs.
+func selectAutoFocusAreas(symbols []*backend.Symbol, covered map[uint64]bool,
+	allowed func(*backend.Symbol) bool, n int, weight float64) []*AutoFocusArea {
+	symCovered := make(map[*backend.Symbol]bool)
+	for _, sym := range symbols {
+		for _, pc := range sym.PCs {
+			if covered[pc] {
+				symCovered[sym] = true
+				break
+			}
+		}
+	}
+	callers := make(map[*backend.Symbol][]*backend.Symbol)
+	for _, sym := range symbols {
+		if !symCovered[sym] {
+			continue
+		}
+		for _, callee := range sym.Callees {
+			if symCovered[callee] || len(callee.PCs) == 0 || !allowed(callee) {
+				continue
+			}
+			callers[callee] = append(callers[callee], sym)
+		}
+	}
+	var res []*AutoFocusArea
+	for target, targetCallers := range callers {
+		area := &AutoFocusArea{
+			Target:       target.Name,
+			UncoveredPCs: reachableUncoveredPCs(target, symCovered),
+			target:       target,
+			callers:      targetCallers,
+		}
+		for _, caller := range targetCallers {
+			area.Callers = append(area.Callers, caller.Name)
+		}
+		sort.Strings(area.Callers)
+		res = append(res, area)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].UncoveredPCs != res[j].UncoveredPCs {
+			return res[i].UncoveredPCs > res[j].UncoveredPCs
+		}
+		return res[i].Target < res[j].Target
+	})
+	if len(res) > n {
+		res = res[:n]
+	}
+	total := 0
+	for _, area := range res {
+		total += area.UncoveredPCs
+	}
+	for _, area := range res {
+		area.Weight = weight * float64(area.UncoveredPCs) / float64(total)
+	}
+	return res
+}
+
+func reachableUncoveredPCs(target *backend.Symbol, symCovered map[*backend.Symbol]bool) int {
+	visited := map[*backend.Symbol]bool{target: true}
+	queue := []*backend.Symbol{target}
+	total := 0
+	for depth := 0; depth < autoFocusDepth && len(queue) != 0; depth++ {
+		var next []*backend.Symbol
+		for _, sym := range queue {
+			total += len(sym.PCs)
+			for _, callee := range sym.Callees {
+				if !visited[callee] && !symCovered[callee] {
+					visited[callee] = true
+					next = append(next, callee)
+				}
+			}
+		}
+		queue = next
+	}
+	return total
+}
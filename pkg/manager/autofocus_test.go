@@ -0,0 +1,48 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"testing"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectAutoFocusAreas(t *testing.T) {
+	sym := func(name string, pcs ...uint64) *backend.Symbol {
+		return &backend.Symbol{ObjectUnit: backend.ObjectUnit{Name: name, PCs: pcs}}
+	}
+	entry := sym("entry", 1, 2)
+	handler := sym("handler", 3)
+	small := sym("small", 10)
+	big := sym("big", 20, 21)
+	bigHelper := sym("big_helper", 30, 31, 32)
+	filtered := sym("filtered", 40, 41, 42, 43, 44, 45)
+	unreachable := sym("unreachable", 50, 51, 52, 53, 54, 55, 56)
+	entry.Callees = []*backend.Symbol{handler, small, filtered}
+	handler.Callees = []*backend.Symbol{big, small}
+	big.Callees = []*backend.Symbol{bigHelper, handler}
+	symbols := []*backend.Symbol{entry, handler, small, big, bigHelper, filtered, unreachable}
+	covered := map[uint64]bool{1: true, 3: true}
+	allowed := func(sym *backend.Symbol) bool {
+		return sym != filtered
+	}
+
+	areas := selectAutoFocusAreas(symbols, covered, allowed, 10, 1.0)
+	assert.Len(t, areas, 2)
+	assert.Equal(t, "big", areas[0].Target)
+	assert.Equal(t, []string{"handler"}, areas[0].Callers)
+	assert.Equal(t, 5, areas[0].UncoveredPCs)
+	assert.InDelta(t, 5.0/6, areas[0].Weight, 1e-9)
+	assert.Equal(t, "small", areas[1].Target)
+	assert.Equal(t, []string{"entry", "handler"}, areas[1].Callers)
+	assert.Equal(t, 1, areas[1].UncoveredPCs)
+	assert.InDelta(t, 1.0/6, areas[1].Weight, 1e-9)
+
+	areas = selectAutoFocusAreas(symbols, covered, allowed, 1, 2.0)
+	assert.Len(t, areas, 1)
+	assert.Equal(t, "big", areas[0].Target)
+	assert.InDelta(t, 2.0, areas[0].Weight, 1e-9)
+}
@@ -4,6 +4,7 @@
 package manager
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -11,12 +12,14 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/syzkaller/pkg/hash"
 	"github.com/google/syzkaller/pkg/log"
 	"github.com/google/syzkaller/pkg/mgrconfig"
 	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/report"
 	"github.com/google/syzkaller/prog"
 )
 
@@ -25,14 +28,27 @@ type CrashStore struct {
 	BaseDir      string
 	MaxCrashLogs int
 	MaxReproLogs int
+
+	mu sync.Mutex
+	// Normalized crash stacks of bugs for SimilarBugs, keyed by bug id.
+	// Entries are dropped when new crashes of the bug are saved.
+	frames map[string][][]string
 }
 
 const reproFileName = "repro.prog"
 const cReproFileName = "repro.cprog"
+const parallelReproFileName = "repro.parallel"
 const straceFileName = "strace.log"
+const vmcoreFileName = "vmcore"
 
 const MaxReproAttempts = 3
 
+// SimilarBugThreshold is the minimal stack similarity score for bugs to be considered probable duplicates.
+const SimilarBugThreshold = report.DuplicateSimilarity
+
+// Max number of crash details per bug used for similarity comparison.
+const maxSimilarityDetails = 5
+
 func NewCrashStore(cfg *mgrconfig.Config) *CrashStore {
 	return &CrashStore{
 		Tag:          cfg.Tag,
@@ -89,6 +105,31 @@ func (cs *CrashStore) SaveCrash(crash *Crash) (bool, error) {
 	writeOrRemove("tag", []byte(cs.Tag))
 	writeOrRemove("report", crash.Report.Report)
 	writeOrRemove("machineInfo", crash.MachineInfo)
+	var details []byte
+	if crash.Report.Details != nil {
+		details, err = json.MarshalIndent(crash.Report.Details, "", "\t")
+		if err != nil {
+			return false, fmt.Errorf("failed to serialize report details: %w", err)
+		}
+	}
+	writeOrRemove("details", details)
+	cs.mu.Lock()
+	delete(cs.frames, crashHash(crash.Title))
+	cs.mu.Unlock()
+	var vmcoreAnalysis []byte
+	if crash.Vmcore != nil {
+		vmcoreAnalysis = crash.Vmcore.Analysis
+	}
+	writeOrRemove("vmcoreAnalysis", vmcoreAnalysis)
+	// Crash dumps are as large as the VM memory, so keep only one per bug and move it instead of copying.
+	if crash.Vmcore != nil && crash.Vmcore.File != "" {
+		vmcore := filepath.Join(dir, vmcoreFileName)
+		if osutil.IsExist(vmcore) {
+			os.Remove(crash.Vmcore.File)
+		} else if err := osutil.Rename(crash.Vmcore.File, vmcore); err != nil {
+			return false, fmt.Errorf("failed to save vmcore: %w", err)
+		}
+	}
 
 	return first, nil
 }
@@ -147,6 +188,9 @@ func (cs *CrashStore) SaveRepro(res *ReproResult, progText, cProgText []byte) er
 	if len(cProgText) > 0 {
 		osutil.WriteFile(filepath.Join(dir, cReproFileName), cProgText)
 	}
+	if parallelLog := repro.ParallelLog(); len(parallelLog) > 0 {
+		osutil.WriteFile(filepath.Join(dir, parallelReproFileName), parallelLog)
+	}
 	var assetErr error
 	repro.Prog.ForEachAsset(func(name string, typ prog.AssetType, r io.Reader, c *prog.Call) {
 		fileName := filepath.Join(dir, name+".gz")
@@ -203,9 +247,10 @@ type CrashInfo struct {
 	Log   string // filename relative to the workdir
 
 	// These fields are only set if full=true.
-	Tag    string
-	Report string // filename relative to workdir
-	Time   time.Time
+	Tag     string
+	Report  string // filename relative to workdir
+	Details string // filename relative to workdir, structured report in JSON format
+	Time    time.Time
 }
 
 type BugInfo struct {
@@ -271,6 +316,10 @@ func (cs *CrashStore) BugInfo(id string, full bool) (*BugInfo, error) {
 		if osutil.IsExist(filepath.Join(cs.BaseDir, reportFile)) {
 			crash.Report = reportFile
 		}
+		detailsFile := filepath.Join("crashes", id, fmt.Sprintf("details%d", crash.Index))
+		if osutil.IsExist(filepath.Join(cs.BaseDir, detailsFile)) {
+			crash.Details = detailsFile
+		}
 	}
 	sort.Slice(ret.Crashes, func(i, j int) bool {
 		return ret.Crashes[i].Time.After(ret.Crashes[j].Time)
@@ -278,6 +327,104 @@ func (cs *CrashStore) BugInfo(id string, full bool) (*BugInfo, error) {
 	return ret, nil
 }
 
+type SimilarBug struct {
+	ID    string
+	Title string
+	Score float64 // stack similarity in the [0, 1] range
+}
+
+// SimilarBugs returns other bugs with crash stacks similar to the stacks of bug id
+// (probable duplicates with a different title), most similar first.
+func (cs *CrashStore) SimilarBugs(id string, threshold float64) ([]SimilarBug, error) {
+	frames := cs.bugFrames(id)
+	if len(frames) == 0 {
+		return nil, nil
+	}
+	dirs, err := osutil.ListDir(filepath.Join(cs.BaseDir, "crashes"))
+	if err != nil {
+		return nil, err
+	}
+	var ret []SimilarBug
+	for _, dir := range dirs {
+		if dir == id {
+			continue
+		}
+		score := 0.0
+		for _, other := range cs.bugFrames(dir) {
+			for _, cur := range frames {
+				score = max(score, report.FramesSimilarity(cur, other))
+			}
+		}
+		if score < threshold {
+			continue
+		}
+		desc, err := os.ReadFile(filepath.Join(cs.BaseDir, "crashes", dir, "description"))
+		if err != nil {
+			continue
+		}
+		ret = append(ret, SimilarBug{
+			ID:    dir,
+			Title: strings.TrimSpace(string(desc)),
+			Score: score,
+		})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		return ret[i].Title < ret[j].Title
+	})
+	return ret, nil
+}
+
+// bugFrames returns normalized stacks of the saved crashes of the bug.
+// Parsing all details files of all bugs on every page load is slow, so the result is cached.
+func (cs *CrashStore) bugFrames(id string) [][]string {
+	cs.mu.Lock()
+	frames, ok := cs.frames[id]
+	cs.mu.Unlock()
+	if ok {
+		return frames
+	}
+	for _, details := range cs.bugDetails(id) {
+		if stack := details.NormalizedStack(); len(stack) != 0 {
+			frames = append(frames, stack)
+		}
+	}
+	cs.mu.Lock()
+	if cs.frames == nil {
+		cs.frames = make(map[string][][]string)
+	}
+	cs.frames[id] = frames
+	cs.mu.Unlock()
+	return frames
+}
+
+func (cs *CrashStore) bugDetails(id string) []*report.Details {
+	dir := filepath.Join(cs.BaseDir, "crashes", id)
+	files, err := osutil.ListDir(dir)
+	if err != nil {
+		return nil
+	}
+	var ret []*report.Details
+	for _, f := range files {
+		if !strings.HasPrefix(f, "details") || len(ret) == maxSimilarityDetails {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f))
+		if err != nil {
+			continue
+		}
+		details := new(report.Details)
+		if err := json.Unmarshal(data, details); err != nil {
+			log.Logf(0, "failed to parse %v: %v", filepath.Join(dir, f), err)
+			continue
+		}
+		ret = append(ret, details)
+	}
+	return ret
+}
+
 func (cs *CrashStore) BugList() ([]*BugInfo, error) {
 	dirs, err := osutil.ListDir(filepath.Join(cs.BaseDir, "crashes"))
 	if err != nil {
@@ -4,6 +4,10 @@
 package manager
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/syzkaller/pkg/report"
@@ -113,3 +117,123 @@ func TestCrashRepro(t *testing.T) {
 	assert.Equal(t, []byte("c prog text"), report.CProg)
 	assert.Equal(t, []byte("Some report"), report.Report)
 }
+
+func TestCrashDetails(t *testing.T) {
+	crashStore := &CrashStore{
+		BaseDir:      t.TempDir(),
+		MaxCrashLogs: 5,
+	}
+	details := &report.Details{
+		Stacks: []*report.Stack{{
+			Kind:   report.StackTrace,
+			Title:  "Call Trace:",
+			Frames: []*report.Frame{{Func: "foo", Offset: 0x10, Size: 0x100, File: "foo.c", Line: 10}},
+		}},
+	}
+	_, err := crashStore.SaveCrash(&Crash{Report: &report.Report{
+		Title:   "Some title",
+		Output:  []byte("Some output"),
+		Report:  []byte("Some report"),
+		Details: details,
+	}})
+	assert.NoError(t, err)
+
+	info, err := crashStore.BugInfo(crashHash("Some title"), true)
+	assert.NoError(t, err)
+	assert.Len(t, info.Crashes, 1)
+	assert.NotEmpty(t, info.Crashes[0].Details)
+	data, err := os.ReadFile(filepath.Join(crashStore.BaseDir, info.Crashes[0].Details))
+	assert.NoError(t, err)
+	var got *report.Details
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, details, got)
+}
+
+func TestCrashVmcore(t *testing.T) {
+	crashStore := &CrashStore{
+		BaseDir:      t.TempDir(),
+		MaxCrashLogs: 5,
+	}
+	tmp := t.TempDir()
+	for i := 0; i < 3; i++ {
+		file := filepath.Join(tmp, fmt.Sprintf("vm%v", i))
+		assert.NoError(t, os.WriteFile(file, []byte(fmt.Sprintf("dump%v", i)), 0644))
+		_, err := crashStore.SaveCrash(&Crash{
+			Report: &report.Report{
+				Title:     "Some title",
+				Output:    []byte("Some output"),
+				Corrupted: true,
+			},
+			Vmcore: &Vmcore{
+				File:     file,
+				Analysis: []byte("analysis"),
+			},
+		})
+		assert.NoError(t, err)
+		// The dump is either moved to the crash store or removed.
+		assert.NoFileExists(t, file)
+	}
+	// Only the first dump is kept.
+	dir := crashStore.path("Some title")
+	data, err := os.ReadFile(filepath.Join(dir, vmcoreFileName))
+	assert.NoError(t, err)
+	assert.Equal(t, "dump0", string(data))
+	matches, err := filepath.Glob(filepath.Join(dir, "vmcore*"))
+	assert.NoError(t, err)
+	assert.Len(t, matches, 4) // the dump and 3 analyses
+}
+
+func TestSimilarBugs(t *testing.T) {
+	crashStore := &CrashStore{
+		BaseDir:      t.TempDir(),
+		MaxCrashLogs: 5,
+	}
+	stack := func(funcs ...string) *report.Details {
+		trace := &report.Stack{Kind: report.StackTrace}
+		for _, fn := range funcs {
+			trace.Frames = append(trace.Frames, &report.Frame{Func: fn})
+		}
+		return &report.Details{Stacks: []*report.Stack{trace}}
+	}
+	bugs := map[string]*report.Details{
+		"WARNING in foo":        stack("foo", "bar", "baz", "entry"),
+		"WARNING in bar":        stack("foo", "bar", "baz", "entry"),
+		"WARNING in qux":        stack("foo", "bar", "qux", "entry"),
+		"KASAN: use-after-free": stack("a", "b", "c", "entry"),
+		"no details":            nil,
+	}
+	for title, details := range bugs {
+		_, err := crashStore.SaveCrash(&Crash{Report: &report.Report{
+			Title:   title,
+			Output:  []byte("output"),
+			Details: details,
+		}})
+		assert.NoError(t, err)
+	}
+	similar, err := crashStore.SimilarBugs(crashHash("WARNING in foo"), SimilarBugThreshold)
+	assert.NoError(t, err)
+	var titles []string
+	for _, bug := range similar {
+		titles = append(titles, bug.Title)
+		assert.Equal(t, crashHash(bug.Title), bug.ID)
+	}
+	assert.Equal(t, []string{"WARNING in bar", "WARNING in qux"}, titles)
+	assert.Equal(t, 1.0, similar[0].Score)
+	assert.Less(t, similar[1].Score, 1.0)
+
+	similar, err = crashStore.SimilarBugs(crashHash("no details"), SimilarBugThreshold)
+	assert.NoError(t, err)
+	assert.Empty(t, similar)
+
+	// Stacks of the new crashes must be taken into account.
+	_, err = crashStore.SaveCrash(&Crash{Report: &report.Report{
+		Title:   "KASAN: use-after-free",
+		Output:  []byte("output"),
+		Details: stack("foo", "bar", "baz", "entry"),
+	}})
+	assert.NoError(t, err)
+	similar, err = crashStore.SimilarBugs(crashHash("WARNING in foo"), SimilarBugThreshold)
+	assert.NoError(t, err)
+	assert.Len(t, similar, 3)
+	assert.Equal(t, "KASAN: use-after-free", similar[0].Title)
+}
@@ -566,8 +566,9 @@ func (kc *kernelContext) CoverageFilter(modules []*vminfo.KernelModule) ([]uint6
 func (kc *kernelContext) fuzzerInstance(ctx context.Context, inst *vm.Instance, updInfo dispatcher.UpdateInfo) {
 	index := inst.Index()
 	injectExec := make(chan bool, 10)
-	kc.serv.CreateInstance(index, injectExec, updInfo)
-	rep, err := kc.runInstance(ctx, inst, injectExec)
+	diagnoseHang := make(chan bool, 1)
+	kc.serv.CreateInstance(index, injectExec, diagnoseHang, updInfo)
+	rep, err := kc.runInstance(ctx, inst, injectExec, diagnoseHang)
 	lastExec, _ := kc.serv.ShutdownInstance(index, rep != nil)
 	if rep != nil {
 		rpcserver.PrependExecuting(rep, lastExec)
@@ -582,7 +583,7 @@ func (kc *kernelContext) fuzzerInstance(ctx context.Context, inst *vm.Instance,
 }
 
 func (kc *kernelContext) runInstance(ctx context.Context, inst *vm.Instance,
-	injectExec <-chan bool) (*report.Report, error) {
+	injectExec, diagnoseHang <-chan bool) (*report.Report, error) {
 	fwdAddr, err := inst.Forward(kc.serv.Port())
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup port forwarding: %w", err)
@@ -600,6 +601,7 @@ func (kc *kernelContext) runInstance(ctx context.Context, inst *vm.Instance,
 	defer cancel()
 	_, rep, err := inst.Run(ctxTimeout, kc.reporter, cmd, vm.ExitTimeout,
 		vm.InjectExecuting(injectExec),
+		vm.DiagnoseHang(diagnoseHang),
 		vm.EarlyFinishCb(func() {
 			// Depending on the crash type and kernel config, fuzzing may continue
 			// running for several seconds even after kernel has printed a crash report.
@@ -697,7 +699,7 @@ func (rr *reproRunner) Run(ctx context.Context, r *repro.Result) {
 				return
 			}
 			result, err = ret.RunSyzProg(instance.ExecParams{
-				SyzProg:  r.Prog.Serialize(),
+				SyzProg:  r.ExecProg(),
 				Duration: max(r.Duration, time.Minute),
 				Opts:     opts,
 			})
@@ -99,7 +99,7 @@ func (s *DiffFuzzerStore) SaveRepro(result *ReproResult) {
 
 	s.patch(title, func(obj *DiffBug) {
 		if result.Repro != nil {
-			obj.Patched.Repro = s.saveFile(title, reproFileName, result.Repro.Prog.Serialize())
+			obj.Patched.Repro = s.saveFile(title, reproFileName, result.Repro.Serialize())
 		}
 		if result.Stats != nil {
 			reproLog := fmt.Sprintf("%v.repro.log", now)
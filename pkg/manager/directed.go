@@ -0,0 +1,34 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package manager
+
+import (
+	"fmt"
+
+	"github.com/google/syzkaller/pkg/cover/backend"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+)
+
+// TargetDistances returns distances of coverage PCs (as reported by KCOV) to the directed fuzzing targets,
+// or nil if directed fuzzing is not enabled.
+func TargetDistances(source *ReportGeneratorWrapper, cfg *mgrconfig.Config) (map[uint64]float64, error) {
+	if len(cfg.Experimental.DirectedTargets) == 0 {
+		return nil, nil
+	}
+	rg, err := source.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report generator: %w", err)
+	}
+	dist, err := backend.TargetDistances(rg.Symbols, cfg.Experimental.DirectedTargets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate target distances: %w", err)
+	}
+	log.Logf(0, "directed fuzzing: %v functions can reach the targets", len(dist.Funcs))
+	ret := make(map[uint64]float64, len(dist.PCs))
+	for pc, d := range dist.PCs {
+		ret[backend.NextInstructionPC(cfg.SysTarget, cfg.Type, pc)] = d
+	}
+	return ret, nil
+}
@@ -25,7 +25,9 @@ import (
 
 	"github.com/google/syzkaller/pkg/corpus"
 	"github.com/google/syzkaller/pkg/cover"
+	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/fuzzer"
+	"github.com/google/syzkaller/pkg/fuzzer/queue"
 	"github.com/google/syzkaller/pkg/html/pages"
 	"github.com/google/syzkaller/pkg/log"
 	"github.com/google/syzkaller/pkg/mgrconfig"
@@ -36,8 +38,6 @@ import (
 	"github.com/google/syzkaller/vm"
 	"github.com/google/syzkaller/vm/dispatcher"
 	"github.com/gorilla/handlers"
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 type CoverageInfo struct {
@@ -61,6 +61,7 @@ type HTTPServer struct {
 	Corpus          atomic.Pointer[corpus.Corpus]
 	Fuzzer          atomic.Pointer[fuzzer.Fuzzer]
 	Cover           atomic.Pointer[CoverageInfo]
+	AutoFocus       atomic.Pointer[AutoFocus]
 	EnabledSyscalls atomic.Value // map[*prog.Syscall]bool
 
 	// Internal state.
@@ -82,10 +83,13 @@ func (serv *HTTPServer) Serve(ctx context.Context) error {
 	handle("/", serv.httpMain)
 	handle("/action", serv.httpAction)
 	handle("/addcandidate", serv.httpAddCandidate)
+	handle("/autofocus", serv.httpAutoFocus)
+	handle("/cobertura", serv.httpCobertura)
 	handle("/config", serv.httpConfig)
 	handle("/corpus", serv.httpCorpus)
 	handle("/corpus.db", serv.httpDownloadCorpus)
 	handle("/cover", serv.httpCover)
+	handle("/coverdiff", serv.httpCoverDiff)
 	handle("/coverprogs", serv.httpPrograms)
 	handle("/debuginput", serv.httpDebugInput)
 	handle("/file", serv.httpFile)
@@ -94,7 +98,8 @@ func (serv *HTTPServer) Serve(ctx context.Context) error {
 	handle("/funccover", serv.httpFuncCover)
 	handle("/input", serv.httpInput)
 	handle("/jobs", serv.httpJobs)
-	handle("/metrics", promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{}).ServeHTTP)
+	handle("/lcov", serv.httpLCOV)
+	handle("/metrics", stat.Handler().ServeHTTP)
 	handle("/modulecover", serv.httpModuleCover)
 	handle("/modules", serv.modulesInfo)
 	handle("/prio", serv.httpPrio)
@@ -102,6 +107,7 @@ func (serv *HTTPServer) Serve(ctx context.Context) error {
 	handle("/rawcoverfiles", serv.httpRawCoverFiles)
 	handle("/stats", serv.httpStats)
 	handle("/subsystemcover", serv.httpSubsystemCover)
+	handle("/syscallcover", serv.httpSyscallCover)
 	handle("/syscalls", serv.httpSyscalls)
 	handle("/vm", serv.httpVM)
 	handle("/vms", serv.httpVMs)
@@ -381,9 +387,14 @@ func (serv *HTTPServer) httpCrash(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "failed to read crash info", http.StatusInternalServerError)
 		return
 	}
+	similar, err := serv.CrashStore.SimilarBugs(crashID, SimilarBugThreshold)
+	if err != nil {
+		log.Logf(0, "failed to find similar bugs: %v", err)
+	}
 	data := UICrashPage{
 		UIPageHeader: serv.pageHeader(r, info.Title),
 		UICrashType:  makeUICrashType(info, serv.StartTime, nil),
+		Similar:      similar,
 	}
 	executeTemplate(w, crashTemplate, data)
 }
@@ -446,6 +457,12 @@ const (
 	DoFilterPCs
 	DoCoverJSONL
 	DoCoverPrograms
+	DoLCOV
+	DoCobertura
+	DoCoverDiffHTML
+	DoCoverDiffJSON
+	DoSyscallCoverHTML
+	DoSyscallCoverJSON
 )
 
 func (serv *HTTPServer) httpCover(w http.ResponseWriter, r *http.Request) {
@@ -472,6 +489,35 @@ func (serv *HTTPServer) httpPrograms(w http.ResponseWriter, r *http.Request) {
 	serv.httpCoverCover(w, r, DoCoverPrograms)
 }
 
+func (serv *HTTPServer) httpLCOV(w http.ResponseWriter, r *http.Request) {
+	serv.httpCoverCover(w, r, DoLCOV)
+}
+
+func (serv *HTTPServer) httpCobertura(w http.ResponseWriter, r *http.Request) {
+	serv.httpCoverCover(w, r, DoCobertura)
+}
+
+// httpCoverDiff compares the current corpus coverage with the base raw coverage (e.g. /rawcover output
+// of another manager, or of this manager saved some time ago) posted in the request body.
+func (serv *HTTPServer) httpCoverDiff(w http.ResponseWriter, r *http.Request) {
+	// Note: r.FormValue would consume the request body.
+	if r.URL.Query().Get("json") == "1" {
+		serv.httpCoverCover(w, r, DoCoverDiffJSON)
+		return
+	}
+	serv.httpCoverCover(w, r, DoCoverDiffHTML)
+}
+
+// httpSyscallCover shows which syscalls reach which kernel functions.
+// Use ?call=name to see what a particular syscall reaches.
+func (serv *HTTPServer) httpSyscallCover(w http.ResponseWriter, r *http.Request) {
+	if r.FormValue("json") == "1" {
+		serv.httpCoverCover(w, r, DoSyscallCoverJSON)
+		return
+	}
+	serv.httpCoverCover(w, r, DoSyscallCoverHTML)
+}
+
 func (serv *HTTPServer) httpSubsystemCover(w http.ResponseWriter, r *http.Request) {
 	if !serv.Cfg.Cover {
 		serv.httpCoverFallback(w, r)
@@ -490,6 +536,7 @@ func (serv *HTTPServer) httpModuleCover(w http.ResponseWriter, r *http.Request)
 
 const ctTextPlain = "text/plain; charset=utf-8"
 const ctApplicationJSON = "application/json"
+const ctApplicationXML = "application/xml"
 
 func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, funcFlag int) {
 	if !serv.Cfg.Cover {
@@ -497,6 +544,22 @@ func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, f
 		return
 	}
 
+	var baseProgs []cover.Prog
+	if funcFlag == DoCoverDiffHTML || funcFlag == DoCoverDiffJSON {
+		// The body needs to be read before any form values are accessed.
+		pcs, err := cover.ParseRawCover(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to parse base coverage: %v", err), http.StatusBadRequest)
+			return
+		}
+		if len(pcs) == 0 {
+			http.Error(w, "POST the base raw coverage (/rawcover output) in the request body",
+				http.StatusBadRequest)
+			return
+		}
+		baseProgs = []cover.Prog{{Sig: "base", PCs: pcs}}
+	}
+
 	coverInfo := serv.Cover.Load()
 	if coverInfo == nil {
 		http.Error(w, "coverage is not ready, please try again later after fuzzer started", http.StatusInternalServerError)
@@ -538,12 +601,14 @@ func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, f
 			progs = append(progs, cover.Prog{
 				Sig:  sig,
 				Data: string(inp.Prog.Serialize()),
+				Call: inp.StringCall(),
 				PCs:  CoverToPCs(serv.Cfg, inp.Updates[updateID].RawCover),
 			})
 		} else {
 			progs = append(progs, cover.Prog{
 				Sig:  sig,
 				Data: string(inp.Prog.Serialize()),
+				Call: inp.StringCall(),
 				PCs:  CoverToPCs(serv.Cfg, inp.Cover),
 			})
 		}
@@ -556,6 +621,7 @@ func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, f
 			progs = append(progs, cover.Prog{
 				Sig:  inp.Sig,
 				Data: string(inp.Prog.Serialize()),
+				Call: inp.StringCall(),
 				PCs:  CoverToPCs(serv.Cfg, inp.Cover),
 			})
 		}
@@ -571,10 +637,17 @@ func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, f
 	}
 
 	params := cover.HandlerParams{
-		Progs:  progs,
-		Filter: coverFilter,
-		Debug:  r.FormValue("debug") != "",
-		Force:  r.FormValue("force") != "",
+		Progs:     progs,
+		Filter:    coverFilter,
+		Debug:     r.FormValue("debug") != "",
+		Force:     r.FormValue("force") != "",
+		BaseProgs: baseProgs,
+	}
+	if fuzzerObj := serv.Fuzzer.Load(); fuzzerObj != nil && funcFlag == DoHTML && r.FormValue("input") == "" {
+		// The hit counts are collected for all executions, so they don't make sense for a single input.
+		if hits, execs := fuzzerObj.HitCounts.Get(); execs != 0 {
+			params.Hits = HitsToPCs(serv.Cfg, hits)
+		}
 	}
 
 	type handlerFuncType func(w io.Writer, params cover.HandlerParams) error
@@ -582,16 +655,22 @@ func (serv *HTTPServer) httpCoverCover(w http.ResponseWriter, r *http.Request, f
 		Do          handlerFuncType
 		contentType string
 	}{
-		DoHTML:           {rg.DoHTML, ""},
-		DoSubsystemCover: {rg.DoSubsystemCover, ""},
-		DoModuleCover:    {rg.DoModuleCover, ""},
-		DoFuncCover:      {rg.DoFuncCover, ctTextPlain},
-		DoFileCover:      {rg.DoFileCover, ctTextPlain},
-		DoRawCoverFiles:  {rg.DoRawCoverFiles, ctTextPlain},
-		DoRawCover:       {rg.DoRawCover, ctTextPlain},
-		DoFilterPCs:      {rg.DoFilterPCs, ctTextPlain},
-		DoCoverJSONL:     {rg.DoCoverJSONL, ctApplicationJSON},
-		DoCoverPrograms:  {rg.DoCoverPrograms, ctApplicationJSON},
+		DoHTML:             {rg.DoHTML, ""},
+		DoSubsystemCover:   {rg.DoSubsystemCover, ""},
+		DoModuleCover:      {rg.DoModuleCover, ""},
+		DoFuncCover:        {rg.DoFuncCover, ctTextPlain},
+		DoFileCover:        {rg.DoFileCover, ctTextPlain},
+		DoRawCoverFiles:    {rg.DoRawCoverFiles, ctTextPlain},
+		DoRawCover:         {rg.DoRawCover, ctTextPlain},
+		DoFilterPCs:        {rg.DoFilterPCs, ctTextPlain},
+		DoCoverJSONL:       {rg.DoCoverJSONL, ctApplicationJSON},
+		DoCoverPrograms:    {rg.DoCoverPrograms, ctApplicationJSON},
+		DoLCOV:             {rg.DoLCOV, ctTextPlain},
+		DoCobertura:        {rg.DoCobertura, ctApplicationXML},
+		DoCoverDiffHTML:    {rg.DoCoverDiffHTML, ""},
+		DoCoverDiffJSON:    {rg.DoCoverDiffJSON, ctApplicationJSON},
+		DoSyscallCoverHTML: {rg.DoSyscallCoverHTML, ""},
+		DoSyscallCoverJSON: {rg.DoSyscallCoverJSON, ctApplicationJSON},
 	}
 
 	if ct := flagToFunc[funcFlag].contentType; ct != "" {
@@ -682,6 +761,30 @@ func (serv *HTTPServer) httpPrio(w http.ResponseWriter, r *http.Request) {
 	executeTemplate(w, prioTemplate, data)
 }
 
+func (serv *HTTPServer) httpAutoFocus(w http.ResponseWriter, r *http.Request) {
+	data := &UIAutoFocusData{
+		UIPageHeader: serv.pageHeader(r, "auto focus"),
+	}
+	if af := serv.AutoFocus.Load(); af != nil {
+		data.Enabled = true
+		if state := af.State(); state != nil {
+			data.Computed = true
+			data.Time = state.Time
+			data.Applied = state.Applied
+			data.Error = state.Error
+			for _, area := range state.Areas {
+				data.Areas = append(data.Areas, UIAutoFocusArea{
+					Target:       area.Target,
+					Callers:      area.Callers,
+					UncoveredPCs: area.UncoveredPCs,
+					Weight:       area.Weight,
+				})
+			}
+		}
+	}
+	executeTemplate(w, autoFocusTemplate, data)
+}
+
 func (serv *HTTPServer) httpFile(w http.ResponseWriter, r *http.Request) {
 	file := filepath.Clean(r.FormValue("name"))
 	if !strings.HasPrefix(file, "crashes/") && !strings.HasPrefix(file, "corpus/") {
@@ -707,8 +810,94 @@ func (serv *HTTPServer) httpInput(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "can't find the input", http.StatusInternalServerError)
 		return
 	}
+	data := inp.Prog.Serialize()
+	if traceRequested(r) {
+		info, err := serv.traceInput(r.Context(), inp.Prog)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data = formatTracedProg(inp.Prog, info)
+	}
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
-	w.Write(inp.Prog.Serialize())
+	w.Write(data)
+}
+
+// traceRequested returns whether the input page asks to re-execute the program with call tracing.
+// Execution has side effects on the fuzzing VMs, so it's only done for POST form submissions
+// and not for plain GET links that may be followed by crawlers or prefetching.
+func traceRequested(r *http.Request) bool {
+	return r.Method == http.MethodPost && r.PostFormValue("trace") != ""
+}
+
+// traceInput re-executes the program with call tracing enabled.
+func (serv *HTTPServer) traceInput(ctx context.Context, p *prog.Prog) (*flatrpc.ProgInfo, error) {
+	fuzzerObj := serv.Fuzzer.Load()
+	if fuzzerObj == nil {
+		return nil, fmt.Errorf("the fuzzer is not yet running")
+	}
+	res := fuzzerObj.TraceProg(ctx, p)
+	if res.Status != queue.Success || res.Info == nil {
+		return nil, fmt.Errorf("failed to execute the program: %v (%v)", res.Status, res.Err)
+	}
+	return res.Info, nil
+}
+
+// formatTracedProg returns the serialized program with the call traces added as comments after each call.
+func formatTracedProg(p *prog.Prog, info *flatrpc.ProgInfo) []byte {
+	buf := new(bytes.Buffer)
+	for i, line := range strings.Split(strings.TrimSpace(string(p.Serialize())), "\n") {
+		fmt.Fprintf(buf, "%v\n", line)
+		if i >= len(p.Calls) || i >= len(info.Calls) {
+			continue
+		}
+		for _, trace := range formatCallTrace(p.Calls[i], info.Calls[i]) {
+			fmt.Fprintf(buf, "# %v\n", trace)
+		}
+	}
+	return buf.Bytes()
+}
+
+func formatCallTrace(c *prog.Call, info *flatrpc.CallInfo) []string {
+	if info == nil || info.Flags&flatrpc.CallFlagExecuted == 0 {
+		return []string{"not executed"}
+	}
+	if info.Trace == nil {
+		return []string{"no trace"}
+	}
+	var ret []string
+	if info.Flags&flatrpc.CallFlagFinished == 0 {
+		ret = append(ret, "did not finish")
+	} else {
+		ret = append(ret, fmt.Sprintf("returned %#x (errno %v)", info.Trace.Res, info.Error))
+	}
+	var args []string
+	for _, arg := range info.Trace.Args {
+		args = append(args, fmt.Sprintf("%#x", arg))
+	}
+	if len(args) != 0 {
+		ret = append(ret, fmt.Sprintf("args: %v", strings.Join(args, ", ")))
+	}
+	for _, buf := range info.Trace.Buffers {
+		name := fmt.Sprintf("arg %v", buf.Arg)
+		before, after := buf.Before, buf.After
+		if idx := int(buf.Arg); idx < len(c.Args) {
+			name = c.Meta.Args[idx].Name
+			// The executor does not know the pointee size, so trim the buffers to the actual size.
+			if ptr, ok := c.Args[idx].(*prog.PointerArg); ok && ptr.Res != nil {
+				size := int(ptr.Res.Size())
+				before = before[:min(size, len(before))]
+				after = after[:min(size, len(after))]
+			}
+		}
+		ret = append(ret, fmt.Sprintf("%v before: %x", name, before))
+		if bytes.Equal(before, after) {
+			ret = append(ret, fmt.Sprintf("%v after: unchanged", name))
+		} else {
+			ret = append(ret, fmt.Sprintf("%v after: %x", name, after))
+		}
+	}
+	return ret
 }
 
 func (serv *HTTPServer) httpDebugInput(w http.ResponseWriter, r *http.Request) {
@@ -731,17 +920,30 @@ func (serv *HTTPServer) httpDebugInput(w http.ResponseWriter, r *http.Request) {
 		}
 		return ret
 	}
+	var info *flatrpc.ProgInfo
+	if traceRequested(r) {
+		var err error
+		info, err = serv.traceInput(r.Context(), inp.Prog)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 	var calls []UIRawCallCover
 	for pos, line := range strings.Split(string(inp.Prog.Serialize()), "\n") {
 		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
-		calls = append(calls, UIRawCallCover{
+		call := UIRawCallCover{
 			Sig:       r.FormValue("sig"),
 			Call:      line,
 			UpdateIDs: getIDs(pos),
-		})
+		}
+		if info != nil && pos < len(info.Calls) {
+			call.Trace = formatCallTrace(inp.Prog.Calls[pos], info.Calls[pos])
+		}
+		calls = append(calls, call)
 	}
 	extraIDs := getIDs(-1)
 	if len(extraIDs) > 0 {
@@ -753,6 +955,8 @@ func (serv *HTTPServer) httpDebugInput(w http.ResponseWriter, r *http.Request) {
 	}
 	data := UIRawCoverPage{
 		UIPageHeader: serv.pageHeader(r, "raw coverage"),
+		Sig:          r.FormValue("sig"),
+		Traced:       info != nil,
 		Calls:        calls,
 	}
 	executeTemplate(w, rawCoverTemplate, data)
@@ -1017,6 +1221,8 @@ type UISyscallsData struct {
 type UICrashPage struct {
 	UIPageHeader
 	UICrashType
+	// Bugs with similar crash stacks, i.e. probable duplicates.
+	Similar []SimilarBug
 }
 
 type UICrashType struct {
@@ -1133,6 +1339,23 @@ type UIPrio struct {
 	Prio int32
 }
 
+type UIAutoFocusData struct {
+	UIPageHeader
+	Enabled  bool
+	Computed bool
+	Time     time.Time
+	Applied  bool
+	Error    string
+	Areas    []UIAutoFocusArea
+}
+
+type UIAutoFocusArea struct {
+	Target       string
+	Callers      []string
+	UncoveredPCs int
+	Weight       float64
+}
+
 type UIFallbackCoverData struct {
 	UIPageHeader
 	Calls []UIFallbackCall
@@ -1146,13 +1369,16 @@ type UIFallbackCall struct {
 
 type UIRawCoverPage struct {
 	UIPageHeader
-	Calls []UIRawCallCover
+	Sig    string
+	Traced bool
+	Calls  []UIRawCallCover
 }
 
 type UIRawCallCover struct {
 	Sig       string
 	Call      string
 	UpdateIDs []int
+	Trace     []string
 }
 
 type UIJobList struct {
@@ -1180,6 +1406,7 @@ var (
 	crashTemplate         = createPage("crash", UICrashPage{})
 	corpusTemplate        = createPage("corpus", UICorpusPage{})
 	prioTemplate          = createPage("prio", UIPrioData{})
+	autoFocusTemplate     = createPage("autofocus", UIAutoFocusData{})
 	fallbackCoverTemplate = createPage("fallback_cover", UIFallbackCoverData{})
 	rawCoverTemplate      = createPage("raw_cover", UIRawCoverPage{})
 	jobListTemplate       = createPage("job_list", UIJobList{})
@@ -6,9 +6,17 @@ package manager
 import (
 	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
+	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/testutil"
+	"github.com/google/syzkaller/prog"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestHttpTemplates(t *testing.T) {
@@ -21,3 +29,66 @@ func TestHttpTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatTracedProg(t *testing.T) {
+	target, err := prog.GetTarget(targets.TestOS, targets.TestArch64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := target.Deserialize([]byte(`test$res3(&(0x7f0000000000))
+test$res0()
+`), prog.NonStrict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &flatrpc.ProgInfo{
+		Calls: []*flatrpc.CallInfo{
+			{
+				Flags: flatrpc.CallFlagExecuted | flatrpc.CallFlagFinished,
+				Trace: &flatrpc.CallTrace{
+					Args: []uint64{0x7f0000000000},
+					Res:  0,
+					Buffers: []*flatrpc.CallBuffer{
+						{
+							Arg:    0,
+							Before: []byte{0, 0, 0, 0, 0xaa, 0xbb},
+							After:  []byte{1, 2, 3, 4, 0xaa, 0xbb},
+						},
+					},
+				},
+			},
+			{
+				Flags: flatrpc.CallFlagExecuted | flatrpc.CallFlagFinished,
+				Error: 22,
+				Trace: &flatrpc.CallTrace{
+					Res: 0xffffffffffffffff,
+				},
+			},
+		},
+	}
+	assert.Equal(t, `test$res3(&(0x7f0000000000))
+# returned 0x0 (errno 0)
+# args: 0x7f0000000000
+# a0 before: 00000000
+# a0 after: 01020304
+test$res0()
+# returned 0xffffffffffffffff (errno 22)
+`, string(formatTracedProg(p, info)))
+}
+
+func TestTraceRequested(t *testing.T) {
+	// Links must not re-execute programs.
+	assert.False(t, traceRequested(httptest.NewRequest(http.MethodGet, "/input?sig=abcd&trace=1", nil)))
+	req := httptest.NewRequest(http.MethodPost, "/input?trace=1", strings.NewReader(url.Values{
+		"sig": {"abcd"},
+	}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.False(t, traceRequested(req))
+	req = httptest.NewRequest(http.MethodPost, "/input", strings.NewReader(url.Values{
+		"sig":   {"abcd"},
+		"trace": {"1"},
+	}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.True(t, traceRequested(req))
+	assert.Equal(t, "abcd", req.FormValue("sig"))
+}
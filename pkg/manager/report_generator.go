@@ -68,3 +68,12 @@ func CoverToPCs(cfg *mgrconfig.Config, cov []uint64) []uint64 {
 	}
 	return pcs
 }
+
+// HitsToPCs is the same as CoverToPCs, but for coverage hit counts.
+func HitsToPCs(cfg *mgrconfig.Config, hits map[uint64]uint64) map[uint64]uint64 {
+	res := make(map[uint64]uint64, len(hits))
+	for pc, count := range hits {
+		res[backend.PreviousInstructionPC(cfg.SysTarget, cfg.Type, pc)] += count
+	}
+	return res
+}
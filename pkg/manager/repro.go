@@ -29,9 +29,22 @@ type Crash struct {
 	FromHub       bool // this crash was created based on a repro from syz-hub
 	FromDashboard bool // .. or from dashboard
 	Manual        bool
+	// Vmcore is set if the kernel crash dump was collected after the crash.
+	Vmcore *Vmcore
 	*report.Report
 }
 
+// Vmcore describes the kernel crash dump collected after a crash.
+type Vmcore struct {
+	// File is the raw crash dump (/proc/vmcore of the crash kernel).
+	File string
+	// Analysis is the human-readable information extracted from the dump (see pkg/vmcore).
+	Analysis []byte
+	// Recovered is set if the console report was corrupted and the report
+	// was extracted from the kernel log in the crash dump instead.
+	Recovered bool
+}
+
 func (c *Crash) FullTitle() string {
 	if c.Report.Title != "" {
 		return c.Report.Title
@@ -248,6 +248,12 @@ type Experimental struct {
 	// Use automatically (auto) generated or manually (manual) written descriptions or any (any) (default: manual)
 	DescriptionsMode string `json:"descriptions_mode"`
 
+	// Record console output of VM instances to workdir/recordings.
+	// Recordings of sessions that ended with a crash are kept (at most max_crash_logs per crash),
+	// the rest are overwritten.
+	// The recordings can be replayed with syz-replay.
+	RecordConsole bool `json:"record_console"`
+
 	// FocusAreas configures what attention syzkaller should pay to the specific areas of the kernel.
 	// The probability of selecting a program from an area is at least `Weight / sum of weights`.
 	// If FocusAreas is non-empty, by default all kernel code not covered by any filter will be ignored.
@@ -255,8 +261,56 @@ type Experimental struct {
 	// with an empty Filter, but non-empty weight.
 	// E.g. "focus_areas": [ {"filter": {"files": ["^net"]}, "weight": 10.0}, {"weight": 1.0} ].
 	FocusAreas []FocusArea `json:"focus_areas,omitempty"`
+
+	// AutoFocus periodically analyzes the corpus coverage and selects focus areas around
+	// uncovered functions that are called from covered functions (see /autofocus page).
+	AutoFocus AutoFocus `json:"auto_focus"`
+
+	// DirectedTargets enables directed fuzzing towards the listed kernel functions.
+	// Distances to the functions are calculated from the static call graph, and signal of calls
+	// that get closer to the functions gets higher priority (similar to AFLGo).
+	// Building the indirect call graph requires CONFIG_DEBUG_INFO and takes some time on start.
+	// E.g. "directed_targets": ["ext4_fill_super", "tcp_v4_connect"].
+	DirectedTargets []string `json:"directed_targets,omitempty"`
+
+	// CoverHitSampling enables coverage hit counts: 1 out of CoverHitSampling fuzzing executions
+	// collects raw coverage that shows how many times each basic block was executed.
+	// The counts are shown in the coverage report as a heat map, which helps to find code
+	// that is reached, but is exercised only trivially. 0 disables the hit counts (default: 0).
+	// E.g. "cover_hit_sampling": 100.
+	CoverHitSampling int `json:"cover_hit_sampling,omitempty"`
+
+	// ProgMaxRSS and ProgMaxFds make the fuzzer ignore new coverage of programs whose test process
+	// reaches the given peak RSS (in MB) or leaves the given number of fds open, as such programs
+	// tend to cause OOMs and other failures. The RSS includes the memory inherited from syz-executor,
+	// so the limit should be well above the peak RSS of ordinary programs (see "prog peak rss" stats).
+	// 0 means no limit, the usage is only reported in the stats (default: 0).
+	// E.g. "prog_max_rss": 180, "prog_max_fds": 200.
+	ProgMaxRSS int `json:"prog_max_rss,omitempty"`
+	ProgMaxFds int `json:"prog_max_fds,omitempty"`
+}
+
+type AutoFocus struct {
+	// Mode is one of:
+	// "" or "off": auto focus is disabled,
+	// "propose": the proposed focus areas are only shown on the /autofocus page,
+	// "apply": the fuzzer focuses on the proposed areas as well.
+	Mode string `json:"mode,omitempty"`
+
+	// Weight is the total weight of all auto focus areas relative to the weight of the configured
+	// focus areas, or to the weight of the whole corpus (1.0) if there are none (default: 1.0).
+	Weight float64 `json:"weight,omitempty"`
+
+	// Areas is the maximum number of the selected areas (default: 10).
+	Areas int `json:"areas,omitempty"`
 }
 
+const (
+	AutoFocusOff     = "off"
+	AutoFocusPropose = "propose"
+	AutoFocusApply   = "apply"
+)
+
 type FocusArea struct {
 	// Name allows to display detailed statistics for every focus area.
 	Name string `json:"name"`
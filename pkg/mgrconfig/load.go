@@ -381,6 +381,21 @@ func (cfg *Config) completeFocusAreas() error {
 			seenEmptyFilter = true
 		}
 	}
+	if err := cfg.completeAutoFocus(); err != nil {
+		return err
+	}
+	if len(cfg.Experimental.DirectedTargets) != 0 && !cfg.Cover {
+		return fmt.Errorf("directed_targets requires coverage")
+	}
+	if cfg.Experimental.CoverHitSampling < 0 {
+		return fmt.Errorf("cover_hit_sampling must not be negative")
+	}
+	if cfg.Experimental.CoverHitSampling != 0 && !cfg.Cover {
+		return fmt.Errorf("cover_hit_sampling requires coverage")
+	}
+	if cfg.Experimental.ProgMaxRSS < 0 || cfg.Experimental.ProgMaxFds < 0 {
+		return fmt.Errorf("prog_max_rss and prog_max_fds must not be negative")
+	}
 	if !cfg.CovFilter.Empty() {
 		if len(cfg.Experimental.FocusAreas) > 0 {
 			return fmt.Errorf("you cannot use both cov_filter and focus_areas")
@@ -397,6 +412,31 @@ func (cfg *Config) completeFocusAreas() error {
 	return nil
 }
 
+func (cfg *Config) completeAutoFocus() error {
+	af := &cfg.Experimental.AutoFocus
+	switch af.Mode {
+	case "":
+		af.Mode = AutoFocusOff
+	case AutoFocusOff, AutoFocusPropose, AutoFocusApply:
+	default:
+		return fmt.Errorf("unknown auto_focus mode %q, want %v, %v or %v",
+			af.Mode, AutoFocusOff, AutoFocusPropose, AutoFocusApply)
+	}
+	if af.Mode != AutoFocusOff && !cfg.Cover {
+		return fmt.Errorf("auto_focus requires coverage")
+	}
+	if af.Weight < 0 || af.Areas < 0 {
+		return fmt.Errorf("auto_focus: negative weight or number of areas")
+	}
+	if af.Weight == 0 {
+		af.Weight = 1.0
+	}
+	if af.Areas == 0 {
+		af.Areas = 10
+	}
+	return nil
+}
+
 func splitTarget(target string) (string, string, string, error) {
 	if target == "" {
 		return "", "", "", fmt.Errorf("target is empty")
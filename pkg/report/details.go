@@ -0,0 +1,105 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package report
+
+import (
+	"github.com/google/syzkaller/sys/targets"
+)
+
+// Details is a structured representation of the oops text in Report.Report.
+// It's meant for consumers that would otherwise need to re-parse the report text
+// (frames, registers, bad access info, etc). Currently only Linux reports have it.
+// Details is serialized as JSON, so keep the types plain data.
+type Details struct {
+	// IP contains the location of the faulting instruction (e.g. from "RIP:" or "pc :" lines).
+	// It has several frames if the location was symbolized into inlined functions,
+	// the innermost function goes first.
+	IP []*Frame `json:",omitempty"`
+	// Stacks contains all stack traces in the order they appear in the report.
+	Stacks []*Stack `json:",omitempty"`
+	// Registers contains the first register dump in the report.
+	Registers []Register `json:",omitempty"`
+	// Access describes the bad memory access for KASAN reports.
+	Access *MemoryAccess `json:",omitempty"`
+}
+
+type StackKind string
+
+const (
+	// StackTrace is the stack of the context that crashed or printed the report
+	// (e.g. "Call Trace:"), there may be several of them if the report contains several contexts.
+	StackTrace StackKind = "trace"
+	// StackAlloc/StackFree are the stacks where the accessed memory was allocated/freed.
+	StackAlloc StackKind = "alloc"
+	StackFree  StackKind = "free"
+	// StackAux are auxiliary stacks (e.g. "Last potentially related work creation:").
+	StackAux StackKind = "aux"
+)
+
+type Stack struct {
+	Kind StackKind
+	// Title is the line that precedes the stack trace (e.g. "Freed by task 1794:").
+	Title string
+	// PID of the task for alloc/free stacks, if known.
+	PID    int `json:",omitempty"`
+	Frames []*Frame
+}
+
+type Frame struct {
+	Func string
+	// Offset/Size of the PC within the function as printed by the kernel (func+0x10/0x100).
+	// Both are 0 for inlined frames.
+	Offset uint64 `json:",omitempty"`
+	Size   uint64 `json:",omitempty"`
+	// Module is the kernel module name, empty for the core kernel.
+	Module string `json:",omitempty"`
+	// File/Line are present only in symbolized reports.
+	File string `json:",omitempty"`
+	Line int    `json:",omitempty"`
+	// Inline is set if the frame is a function inlined into the next frame.
+	Inline bool `json:",omitempty"`
+	// Questionable is set for frames marked with '?' by the unwinder, they may be stale.
+	Questionable bool `json:",omitempty"`
+}
+
+type Register struct {
+	Name  string
+	Value uint64
+}
+
+type MemoryAccess struct {
+	// Bug is the bug kind as printed by the sanitizer (e.g. "slab-use-after-free").
+	Bug   string
+	Addr  uint64 `json:",omitempty"`
+	Size  int    `json:",omitempty"`
+	Write bool   `json:",omitempty"`
+	// Task/PID that did the bad access.
+	Task string `json:",omitempty"`
+	PID  int    `json:",omitempty"`
+	// The heap object the accessed address belongs to.
+	ObjectAddr uint64 `json:",omitempty"`
+	ObjectSize int    `json:",omitempty"`
+	Cache      string `json:",omitempty"`
+}
+
+var detailsParsers = map[string]func(report []byte) *Details{
+	targets.Linux: parseLinuxDetails,
+}
+
+// ReportToDetails extracts structured details from already extracted report text
+// (e.g. Report.Report stored by the manager).
+// Returns nil if details are not supported for the OS.
+func (reporter *Reporter) ReportToDetails(report []byte) *Details {
+	return ParseDetails(reporter.typ, report)
+}
+
+// ParseDetails is the same as Reporter.ReportToDetails, but it does not need a Reporter
+// (e.g. for the dashboard that has only the report text and the OS name).
+func ParseDetails(os string, report []byte) *Details {
+	parser := detailsParsers[os]
+	if parser == nil {
+		return nil
+	}
+	return parser(report)
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package report
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func parseLinuxDetails(report []byte) *Details {
+	details := new(Details)
+	var stack *Stack
+	var access *MemoryAccess
+	regsDone, ipDone := false, false
+	regNames := make(map[string]bool)
+	for _, line := range bytes.Split(report, []byte{'\n'}) {
+		ln := strings.TrimSpace(string(line))
+		if stack != nil {
+			if linuxStackMarkerRe.MatchString(ln) {
+				continue
+			}
+			if frame := parseLinuxFrame(ln); frame != nil {
+				stack.Frames = append(stack.Frames, frame)
+				continue
+			}
+			if len(stack.Frames) != 0 {
+				details.Stacks = append(details.Stacks, stack)
+			}
+			stack = nil
+		}
+		if stack = parseLinuxStackStart(ln); stack != nil {
+			continue
+		}
+		if match := linuxIPRe.FindStringSubmatch(ln); match != nil && !ipDone {
+			if frame := parseLinuxFrame(match[1]); frame != nil {
+				details.IP = append(details.IP, frame)
+			}
+		} else if len(details.IP) != 0 {
+			ipDone = true
+		}
+		if !regsDone && ln != "" && linuxRegsLineRe.MatchString(ln) {
+			for _, match := range linuxRegRe.FindAllStringSubmatch(ln, -1) {
+				// A repeated register means the next register dump (e.g. user-space registers).
+				if regNames[match[1]] {
+					regsDone = true
+					break
+				}
+				regNames[match[1]] = true
+				val, _ := strconv.ParseUint(match[2], 16, 64)
+				details.Registers = append(details.Registers, Register{match[1], val})
+			}
+		}
+		access = parseLinuxAccess(ln, access)
+	}
+	if stack != nil && len(stack.Frames) != 0 {
+		details.Stacks = append(details.Stacks, stack)
+	}
+	details.Access = access
+	if details.IP == nil && details.Stacks == nil && details.Registers == nil && details.Access == nil {
+		return nil
+	}
+	return details
+}
+
+func parseLinuxStackStart(ln string) *Stack {
+	for _, start := range linuxStackStarts {
+		match := start.re.FindStringSubmatch(ln)
+		if match == nil {
+			continue
+		}
+		stack := &Stack{
+			Kind:  start.kind,
+			Title: ln,
+		}
+		if len(match) > 1 && match[1] != "" {
+			stack.PID, _ = strconv.Atoi(match[1])
+		}
+		return stack
+	}
+	return nil
+}
+
+func parseLinuxFrame(ln string) *Frame {
+	match := linuxFrameRe.FindStringSubmatch(ln)
+	// Require either offset or file:line, otherwise it's just some word.
+	if match == nil || match[4] == "" && match[6] == "" {
+		return nil
+	}
+	frame := &Frame{
+		Func:         match[3],
+		File:         match[6],
+		Inline:       match[8] != "",
+		Module:       match[9],
+		Questionable: match[1] != "" || match[2] != "",
+	}
+	frame.Offset, _ = strconv.ParseUint(match[4], 16, 64)
+	frame.Size, _ = strconv.ParseUint(match[5], 16, 64)
+	frame.Line, _ = strconv.Atoi(match[7])
+	return frame
+}
+
+func parseLinuxAccess(ln string, access *MemoryAccess) *MemoryAccess {
+	if match := linuxKasanBugRe.FindStringSubmatch(ln); match != nil {
+		if access != nil {
+			// Only the first report is interesting.
+			return access
+		}
+		return &MemoryAccess{Bug: match[1]}
+	}
+	if access == nil {
+		return nil
+	}
+	if match := linuxKasanAccessRe.FindStringSubmatch(ln); match != nil && access.Addr == 0 {
+		access.Write = match[1] == "Write"
+		access.Size, _ = strconv.Atoi(match[2])
+		access.Addr, _ = strconv.ParseUint(match[3], 16, 64)
+		access.Task = match[4]
+		access.PID, _ = strconv.Atoi(match[5])
+	} else if match := linuxKasanObjectRe.FindStringSubmatch(ln); match != nil && access.ObjectAddr == 0 {
+		access.ObjectAddr, _ = strconv.ParseUint(match[1], 16, 64)
+	} else if match := linuxKasanCacheRe.FindStringSubmatch(ln); match != nil && access.Cache == "" {
+		access.Cache = match[1]
+		access.ObjectSize, _ = strconv.Atoi(match[2])
+	}
+	return access
+}
+
+var linuxStackStarts = []struct {
+	re   *regexp.Regexp
+	kind StackKind
+}{
+	{regexp.MustCompile(`^(?:Call (?:T|t)race|Backtrace):$`), StackTrace},
+	{regexp.MustCompile(`^Allocated(?: by task ([0-9]+))?:$`), StackAlloc},
+	{regexp.MustCompile(`^Freed(?: by task ([0-9]+))?:$`), StackFree},
+	// Kmemleak prints the stack where the leaked object was allocated.
+	{regexp.MustCompile(`^backtrace(?: \(crc [0-9a-f]+\))?:$`), StackAlloc},
+	{regexp.MustCompile(`^(?:Last|Second to last) potentially related work creation:$`), StackAux},
+	{regexp.MustCompile(`^(?:Last|Second to last) call_rcu\(\):$`), StackAux},
+	{regexp.MustCompile(`^Uninit was (?:stored to memory|created) at:$`), StackAux},
+}
+
+var (
+	// Matches both raw (func+0x1/0x2) and symbolized (func+0x1/0x2 file.c:1, func file.h:1 [inline]) frames.
+	linuxFrameRe = regexp.MustCompile(`^(\? )?(?:\[<[0-9a-f]+>\]\s+)*(\? )?([a-zA-Z0-9_.]+)` +
+		`(?:\+0x([0-9a-f]+)/0x([0-9a-f]+))?(?: ([^ :\[\]]+):([0-9]+))?( \[inline\])?` +
+		`(?: \[([a-zA-Z0-9_.]+)(?: [0-9a-f]+)?\])?$`)
+	linuxStackMarkerRe = regexp.MustCompile(`^</?[A-Z]+>$`)
+	linuxIPRe          = regexp.MustCompile(`^(?:RIP: [0-9a-f]{4}:|pc : |PC is at |NIP: +|IP: )(.+)$`)
+	linuxRegRe         = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9_]*) ?: +(?:[0-9a-f]{4}:)?([0-9a-f]{4,16})\b`)
+	linuxRegsLineRe    = regexp.MustCompile(`^(?:` + linuxRegRe.String() + `\s*)+$`)
+	linuxKasanBugRe    = regexp.MustCompile(`BUG: KASAN: ([a-zA-Z0-9-]+)`)
+	linuxKasanAccessRe = regexp.MustCompile(`^(Read|Write) of size ([0-9]+) at addr ([0-9a-f]+) by task (.+)/([0-9]+)$`)
+	linuxKasanObjectRe = regexp.MustCompile(`^The buggy address belongs to the object at ([0-9a-f]+)$`)
+	linuxKasanCacheRe  = regexp.MustCompile(`which belongs to the cache (\S+) of size ([0-9]+)$`)
+)
@@ -72,6 +72,9 @@ type Report struct {
 	MachineInfo []byte
 	// If the crash happened in the context of the syz-executor process, Executor will hold more info.
 	Executor *ExecutorInfo
+	// Details contains the structured representation of Report (updated by Symbolize).
+	// Not all OSes support it, so it may be nil.
+	Details *Details
 	// reportPrefixLen is length of additional prefix lines that we added before actual crash report.
 	reportPrefixLen int
 	// symbolized is set if the report is symbolized.
@@ -216,6 +219,7 @@ func (reporter *Reporter) ParseFrom(output []byte, minReportPos int) *Report {
 	// This generally should not happen.
 	// But openbsd does some hacks with /r/n which may lead to off-by-one EndPos.
 	rep.EndPos = max(rep.EndPos, rep.SkipPos)
+	rep.Details = reporter.ReportToDetails(rep.Report)
 	return rep
 }
 
@@ -231,6 +235,8 @@ func (reporter *Reporter) Symbolize(rep *Report) error {
 	if err := reporter.impl.Symbolize(rep); err != nil {
 		return err
 	}
+	// Symbolization adds file:line info and inline frames.
+	rep.Details = reporter.ReportToDetails(rep.Report)
 	if !reporter.isInteresting(rep) {
 		rep.Suppressed = true
 	}
@@ -6,6 +6,7 @@ package report
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -510,3 +511,34 @@ BCDEF`), Truncate([]byte(`0123456789ABCDEF`), 0, 5))
 
 DEF`), Truncate([]byte(`0123456789ABCDEF`), 4, 3))
 }
+
+func TestDetails(t *testing.T) {
+	forEachFile(t, "details", testDetailsFile)
+}
+
+func testDetailsFile(t *testing.T, reporter *Reporter, fn string) {
+	data, err := os.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const separator = "\nDETAILS:\n"
+	log, want, ok := bytes.Cut(data, []byte(separator))
+	if !ok {
+		t.Fatalf("no DETAILS section in the file")
+	}
+	rep := reporter.Parse(log)
+	if rep == nil {
+		t.Fatalf("did not find crash")
+	}
+	got, err := json.MarshalIndent(rep.Details, "", "\t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got = append(got, '\n')
+	if !bytes.Equal(want, got) {
+		if *flagUpdate {
+			osutil.WriteFile(fn, append(append(log, separator...), got...))
+		}
+		assert.Equal(t, string(want), string(got))
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package report
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/syzkaller/pkg/report/crash"
+)
+
+type SeverityTier int
+
+const (
+	SeverityUnknown SeverityTier = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+func (tier SeverityTier) String() string {
+	switch tier {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
+// Severity is a coarse estimation of the security impact of a crash.
+// It's only a triage hint: it does not prove (non-)exploitability.
+type Severity struct {
+	Tier SeverityTier
+	// Reasons explain the factors that contributed to the tier, in the order they were considered.
+	Reasons []string
+}
+
+// ClassifySeverity estimates the crash severity using the crash type (see TitlesToImpact)
+// refined with the structured report details (may be nil).
+// sandbox is the sandbox used by the reproducer (csource.Options.Sandbox), empty if there is no reproducer.
+func ClassifySeverity(title string, details *Details, sandbox string) *Severity {
+	sev := &Severity{}
+	typ := TitleToCrashType(title)
+	var access *MemoryAccess
+	if details != nil {
+		access = details.Access
+	}
+	switch {
+	case memoryCorruptionTypes[typ]:
+		sev.raise(SeverityHigh, "memory corruption (%v)", typ)
+	case memoryReadTypes[typ]:
+		sev.raise(SeverityMedium, "bad memory read (%v), potential information leak", typ)
+	case typ == crash.RefcountWARNING || typ == crash.KCSANAssert:
+		sev.raise(SeverityMedium, "broken invariant (%v) that frequently leads to use-after-free", typ)
+	case typ != crash.UnknownType:
+		sev.raise(SeverityLow, "denial of service (%v)", typ)
+	case access != nil && access.Write:
+		sev.raise(SeverityHigh, "bad memory write (%v)", access.Bug)
+	case access != nil:
+		sev.raise(SeverityMedium, "bad memory read (%v)", access.Bug)
+	}
+	if sev.Tier == SeverityUnknown {
+		sev.Reasons = append(sev.Reasons, "unknown crash type")
+		return sev
+	}
+	if access != nil {
+		sev.classifyAccess(access)
+	}
+	if details != nil && inInterrupt(details) {
+		sev.lower("crash in interrupt context, the triggering state is harder to control")
+	}
+	switch sandbox {
+	case "":
+		sev.Reasons = append(sev.Reasons, "no reproducer, reachability by unprivileged users is unknown")
+	case "none":
+		sev.Reasons = append(sev.Reasons, "the reproducer runs as root (sandbox none)")
+	default:
+		sev.raise(sev.Tier+1, "the reproducer runs as an unprivileged user (sandbox %v)", sandbox)
+	}
+	return sev
+}
+
+func (sev *Severity) classifyAccess(access *MemoryAccess) {
+	if strings.Contains(access.Bug, "use-after-free") {
+		tier := sev.Tier
+		if !access.Write {
+			tier = min(sev.Tier+1, SeverityHigh)
+		}
+		sev.raise(tier, "use-after-free, the freed object may be reallocated with controlled contents")
+	}
+	switch {
+	case access.Addr >= severityUserAddrStart && access.Addr < severityUserAddrEnd:
+		sev.raise(min(sev.Tier+1, SeverityHigh),
+			"the accessed address %#x is in the user address range and may be user-controllable", access.Addr)
+	case access.Addr != 0 && access.Addr < severityUserAddrStart:
+		sev.Reasons = append(sev.Reasons, fmt.Sprintf("near-NULL address %#x", access.Addr))
+	}
+	switch {
+	case access.Write && access.Size >= 8:
+		sev.Reasons = append(sev.Reasons, fmt.Sprintf("%v-byte write may overwrite a pointer", access.Size))
+	case access.Write && access.Size > 0:
+		sev.Reasons = append(sev.Reasons, fmt.Sprintf("small %v-byte write limits the corruption", access.Size))
+	case !access.Write && access.Size >= 64:
+		sev.Reasons = append(sev.Reasons, fmt.Sprintf("large %v-byte read may leak lots of data", access.Size))
+	}
+}
+
+func (sev *Severity) raise(tier SeverityTier, msg string, args ...interface{}) {
+	sev.Tier = max(sev.Tier, min(tier, SeverityCritical))
+	sev.Reasons = append(sev.Reasons, fmt.Sprintf(msg, args...))
+}
+
+func (sev *Severity) lower(msg string) {
+	sev.Tier = max(sev.Tier-1, SeverityLow)
+	sev.Reasons = append(sev.Reasons, msg)
+}
+
+func inInterrupt(details *Details) bool {
+	for _, stack := range details.Stacks {
+		if stack.Kind != StackTrace {
+			continue
+		}
+		for _, frame := range stack.Frames {
+			if severityInterruptFrame.MatchString(frame.Func) {
+				return true
+			}
+		}
+		break
+	}
+	return false
+}
+
+const (
+	// Addresses below that are considered NULL-pointer dereferences
+	// (the kernel does not allow to map the first pages by default).
+	severityUserAddrStart = 0x10000
+	// Upper bound of the user address range on 64-bit arches.
+	severityUserAddrEnd = 0x0000800000000000
+)
+
+var (
+	memoryCorruptionTypes = map[crash.Type]bool{
+		crash.KASANUseAfterFreeWrite:  true,
+		crash.KASANWrite:              true,
+		crash.KASANInvalidFree:        true,
+		crash.KFENCEUseAfterFreeWrite: true,
+		crash.KFENCEWrite:             true,
+		crash.KFENCEInvalidFree:       true,
+		crash.KFENCEMemoryCorruption:  true,
+	}
+	memoryReadTypes = map[crash.Type]bool{
+		crash.KASANUseAfterFreeRead:  true,
+		crash.KASANRead:              true,
+		crash.KFENCEUseAfterFreeRead: true,
+		crash.KFENCERead:             true,
+		crash.KMSANUseAfterFreeRead:  true,
+		crash.KMSANInfoLeak:          true,
+		crash.MemorySafetyUBSAN:      true,
+		crash.MemorySafetyBUG:        true,
+	}
+	severityInterruptFrame = regexp.MustCompile(`^(?:__do_softirq|handle_softirqs|__irq_exit_rcu|irq_exit_rcu` +
+		`|(?:asm_)?sysvec_.*|(?:asm_)?common_interrupt|call_timer_fn|__run_timers|run_timer_softirq` +
+		`|rcu_core|rcu_do_batch|net_rx_action|tasklet_action.*|__handle_irq_event_percpu|handle_irq_event)$`)
+)
@@ -0,0 +1,89 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifySeverity(t *testing.T) {
+	irq := &Stack{Kind: StackTrace, Frames: []*Frame{{Func: "foo"}, {Func: "__do_softirq"}}}
+	tests := []struct {
+		title   string
+		details *Details
+		sandbox string
+		tier    SeverityTier
+	}{
+		{
+			title: "KASAN: slab-use-after-free Write in foo",
+			tier:  SeverityHigh,
+		},
+		{
+			title:   "KASAN: slab-use-after-free Write in foo",
+			sandbox: "none",
+			tier:    SeverityHigh,
+		},
+		{
+			title:   "KASAN: slab-use-after-free Write in foo",
+			sandbox: "namespace",
+			tier:    SeverityCritical,
+		},
+		{
+			title: "KASAN: slab-out-of-bounds Read in foo",
+			details: &Details{Access: &MemoryAccess{
+				Bug:  "slab-out-of-bounds",
+				Addr: 0xffff88000969e798,
+				Size: 840,
+			}},
+			tier: SeverityMedium,
+		},
+		{
+			title: "KASAN: slab-use-after-free Read in foo",
+			details: &Details{Access: &MemoryAccess{
+				Bug:  "slab-use-after-free",
+				Addr: 0xffff88000969e798,
+				Size: 8,
+			}},
+			tier: SeverityHigh,
+		},
+		{
+			title: "KASAN: slab-use-after-free Read in foo",
+			details: &Details{
+				Access: &MemoryAccess{Bug: "slab-use-after-free", Addr: 0xffff88000969e798, Size: 8},
+				Stacks: []*Stack{irq},
+			},
+			tier: SeverityMedium,
+		},
+		{
+			title: "KASAN: wild-memory-access Read in foo",
+			details: &Details{Access: &MemoryAccess{
+				Bug:  "wild-memory-access",
+				Addr: 0x20000000,
+				Size: 4,
+			}},
+			tier: SeverityHigh,
+		},
+		{
+			title:   "WARNING in foo",
+			sandbox: "setuid",
+			tier:    SeverityMedium,
+		},
+		{
+			title:   "INFO: task hung in foo",
+			details: &Details{Stacks: []*Stack{irq}},
+			tier:    SeverityLow,
+		},
+		{
+			title: "some unknown crash",
+			tier:  SeverityUnknown,
+		},
+	}
+	for i, test := range tests {
+		sev := ClassifySeverity(test.title, test.details, test.sandbox)
+		assert.Equal(t, test.tier.String(), sev.Tier.String(), "test #%v: %q %+v", i, test.title, sev.Reasons)
+		assert.NotEmpty(t, sev.Reasons, "test #%v", i)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package report
+
+import (
+	"math"
+	"regexp"
+)
+
+const (
+	// Only that many top frames of the normalized stack are compared.
+	similarityMaxFrames = 20
+	// Weight of the frame at depth i is similarityDecay^i, so top frames matter most.
+	similarityDecay = 0.85
+)
+
+// DuplicateSimilarity is the minimal StackSimilarity score for reports to be considered probable duplicates.
+const DuplicateSimilarity = 0.75
+
+// StackSimilarity compares the main stack traces of two reports and returns a score in the [0, 1] range,
+// where 1 means identical normalized stacks and 0 means no common frames.
+// Unlike titles, it's not affected by the choice of the guilty frame, so it can detect
+// duplicates among reports with different titles (and distinct bugs with the same title).
+func StackSimilarity(a, b *Details) float64 {
+	return FramesSimilarity(a.NormalizedStack(), b.NormalizedStack())
+}
+
+// FramesSimilarity is StackSimilarity for stacks that were already normalized with NormalizedStack.
+// It allows to compare stacks without keeping the whole report details around.
+func FramesSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	return weightedStackSimilarity(a, b)
+}
+
+// NormalizedStack returns function names of the main stack with the reporting machinery
+// (dump_stack, kasan_report, etc) and compiler-generated name suffixes removed.
+func (details *Details) NormalizedStack() []string {
+	if details == nil {
+		return nil
+	}
+	frames := details.IP
+	for _, stack := range details.Stacks {
+		if stack.Kind == StackTrace {
+			frames = append(frames[:len(frames):len(frames)], stack.Frames...)
+			break
+		}
+	}
+	var ret []string
+	for _, frame := range frames {
+		if frame.Questionable || similarityIgnoredFrame.MatchString(frame.Func) {
+			continue
+		}
+		name := similarityNameSuffix.ReplaceAllString(frame.Func, "")
+		// IP usually duplicates the top frame of the stack.
+		if len(ret) != 0 && ret[len(ret)-1] == name {
+			continue
+		}
+		ret = append(ret, name)
+		if len(ret) == similarityMaxFrames {
+			break
+		}
+	}
+	return ret
+}
+
+// weightedStackSimilarity is an edit distance where each frame costs its weight,
+// and matching frames at different depths costs the difference of their weights.
+// The distance is normalized by the total weight of both stacks.
+func weightedStackSimilarity(a, b []string) float64 {
+	weight := func(i int) float64 {
+		return math.Pow(similarityDecay, float64(i))
+	}
+	prev := make([]float64, len(b)+1)
+	cur := make([]float64, len(b)+1)
+	for j := range b {
+		prev[j+1] = prev[j] + weight(j)
+	}
+	total := prev[len(b)]
+	for i := range a {
+		total += weight(i)
+		cur[0] = prev[0] + weight(i)
+		for j := range b {
+			cost := math.Min(prev[j+1]+weight(i), cur[j]+weight(j))
+			if a[i] == b[j] {
+				cost = math.Min(cost, prev[j]+math.Abs(weight(i)-weight(j)))
+			}
+			cur[j+1] = cost
+		}
+		prev, cur = cur, prev
+	}
+	return 1 - prev[len(b)]/total
+}
+
+var (
+	similarityIgnoredFrame = regexp.MustCompile(`^(?:__)?(?:` +
+		`dump_stack|dump_stack_lvl|show_stack|print_address_description|print_report` +
+		`|kasan_report|kasan_check_range|check_memory_region|__asan_.*|__kasan_.*|kmsan_report` +
+		`|__msan_.*|kcsan_report.*|report_bug|handle_bug|exc_invalid_op|asm_exc_.*|__warn|warn_slowpath.*` +
+		`|panic|ubsan_epilogue|__ubsan_handle_.*|lockdep_.*|print_.*_bug|__might_resched|__might_sleep)$`)
+	similarityNameSuffix = regexp.MustCompile(`(?:\.(?:isra|constprop|part|cold|llvm)(?:\.[0-9]+)*)+$|\.[0-9]+$`)
+)
@@ -0,0 +1,45 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackSimilarity(t *testing.T) {
+	stack := func(funcs string) *Details {
+		trace := &Stack{Kind: StackTrace}
+		for _, fn := range strings.Fields(funcs) {
+			frame := &Frame{Func: strings.TrimPrefix(fn, "?")}
+			frame.Questionable = frame.Func != fn
+			trace.Frames = append(trace.Frames, frame)
+		}
+		return &Details{Stacks: []*Stack{trace}}
+	}
+	base := stack("dump_stack kasan_report foo bar baz syscall_entry")
+	tests := []struct {
+		a, b     *Details
+		min, max float64
+	}{
+		{base, base, 1, 1},
+		{base, stack("foo bar baz syscall_entry"), 1, 1},
+		{base, stack("foo.isra.0 ?qux bar.constprop.1.isra.2 baz syscall_entry"), 1, 1},
+		// Same bug reached via a different caller.
+		{base, stack("foo bar other_caller syscall_entry"), 0.7, 0.9},
+		// Same caller, but a different top frame.
+		{base, stack("other bar baz syscall_entry"), 0.5, 0.7},
+		{base, stack("a b c d"), 0, 0},
+		{base, nil, 0, 0},
+		{base, &Details{}, 0, 0},
+	}
+	for i, test := range tests {
+		score := StackSimilarity(test.a, test.b)
+		assert.Equal(t, score, StackSimilarity(test.b, test.a), "test #%v: not symmetric", i)
+		assert.GreaterOrEqual(t, score, test.min, "test #%v", i)
+		assert.LessOrEqual(t, score, test.max, "test #%v", i)
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package repro
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/syzkaller/pkg/csource"
+	"github.com/google/syzkaller/prog"
+)
+
+// Some bugs are triggered only when two programs are executed concurrently by different procs
+// (e.g. one program uses an object, while another one concurrently destroys it).
+// Such crashes can't be reproduced by executing the programs one by one or by concatenating them,
+// so if neither single programs nor bisection reproduce the crash, we try to execute pairs of programs
+// that were running right before the crash in parallel.
+
+// Only the last programs of that many most recently active procs are paired,
+// which bounds the parallel stage to 3 test runs.
+const maxParallelCandidates = 3
+
+// parallelCandidates returns pairs of programs executed by different procs right before the crash.
+func (ctx *reproContext) parallelCandidates(entries []*prog.LogEntry) [][]*prog.LogEntry {
+	last := lastEntries(entries)
+	if len(last) < 2 {
+		return nil
+	}
+	// If the crash report mentions the program, it must be one of the pair.
+	var fixed *prog.LogEntry
+	if ctx.crashExecutor != nil {
+		for i, ent := range last {
+			if ent.ID == ctx.crashExecutor.ExecID {
+				fixed = ent
+				last = append([]*prog.LogEntry{ent}, append(last[:i:i], last[i+1:]...)...)
+				break
+			}
+		}
+	}
+	last = last[:min(len(last), maxParallelCandidates)]
+	var ret [][]*prog.LogEntry
+	for i, a := range last {
+		for _, b := range last[i+1:] {
+			if fixed != nil && a != fixed {
+				continue
+			}
+			ret = append(ret, []*prog.LogEntry{a, b})
+		}
+	}
+	return ret
+}
+
+func (ctx *reproContext) extractProgParallel(entries []*prog.LogEntry, duration time.Duration) (*Result, error) {
+	candidates := ctx.parallelCandidates(entries)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	ctx.reproLogf(3, "parallel: executing %d pairs of programs concurrently with timeout %s",
+		len(candidates), duration)
+	for _, pair := range candidates {
+		res, err := ctx.tryParallel([]*prog.Prog{pair[0].P, pair[1].P}, duration)
+		if err != nil || res != nil {
+			return res, err
+		}
+	}
+	ctx.reproLogf(3, "parallel: failed to extract reproducer")
+	return nil, nil
+}
+
+func (ctx *reproContext) tryParallel(progs []*prog.Prog, duration time.Duration) (*Result, error) {
+	opts := parallelOpts(ctx.startOpts, len(progs))
+	ret, err := ctx.testParallelProgs(progs, duration, opts, false)
+	if err != nil {
+		return nil, err
+	}
+	if !ret.Crashed {
+		return nil, nil
+	}
+	ctx.reproLogf(3, "parallel: successfully extracted reproducer")
+	res := &Result{
+		Progs:    progs,
+		Duration: max(duration, ret.Duration*3/2),
+		Opts:     opts,
+	}
+	res.concatenateProgs()
+	return res, nil
+}
+
+// minimizeParallel minimizes each of the parallel programs while keeping the rest intact.
+func (ctx *reproContext) minimizeParallel(res *Result, mode prog.MinimizeMode) {
+	var testErr error
+	for i := range res.Progs {
+		res.Progs[i], _ = prog.Minimize(res.Progs[i], -1, mode, func(p1 *prog.Prog, _ int) bool {
+			if testErr != nil || len(p1.Calls) == 0 {
+				return false
+			}
+			progs := slices.Clone(res.Progs)
+			progs[i] = p1
+			ret, err := ctx.testParallelProgs(progs, res.Duration, res.Opts, false)
+			if err != nil {
+				ctx.reproLogf(2, "minimization failed with %v", err)
+				testErr = err
+				return false
+			}
+			return ret.Crashed
+		})
+	}
+	res.concatenateProgs()
+}
+
+// testParallelProgs executes each program in a separate proc.
+// syz-execprog takes programs from a shared queue, so with Repeat and enough procs
+// all programs are executing concurrently most of the time.
+func (ctx *reproContext) testParallelProgs(progs []*prog.Prog, duration time.Duration, opts csource.Options,
+	strict bool) (verdict, error) {
+	return ctx.testProgs(parallelEntries(progs), duration, opts, strict)
+}
+
+func parallelEntries(progs []*prog.Prog) []*prog.LogEntry {
+	var entries []*prog.LogEntry
+	for i, p := range progs {
+		entries = append(entries, &prog.LogEntry{P: p, Proc: i})
+	}
+	return entries
+}
+
+func parallelOpts(opts csource.Options, progs int) csource.Options {
+	opts.Procs = max(opts.Procs, progs)
+	return opts
+}
+
+// concatenateProgs sets Prog to the concatenation of the parallel programs
+// for the consumers that are not aware of parallel reproducers.
+func (repro *Result) concatenateProgs() {
+	repro.Prog = &prog.Prog{
+		Target: repro.Progs[0].Target,
+	}
+	for _, p := range repro.Progs {
+		repro.Prog.Calls = append(repro.Prog.Calls, p.Clone().Calls...)
+	}
+}
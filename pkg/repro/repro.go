@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
 	"sort"
 	"time"
 
@@ -26,7 +28,10 @@ import (
 )
 
 type Result struct {
-	Prog     *prog.Prog
+	Prog *prog.Prog
+	// Progs is set if the crash is reproduced only by several programs executed concurrently
+	// in separate procs. Prog is then the concatenation of the programs.
+	Progs    []*prog.Prog
 	Duration time.Duration
 	Opts     csource.Options
 	CRepro   bool
@@ -36,6 +41,9 @@ type Result struct {
 	// A very rough estimate of the probability with which the resulting syz
 	// reproducer crashes the kernel.
 	Reliability float64
+	// Explanation says which call arguments are essential for triggering the crash.
+	// It's not computed in the fast mode and for parallel reproducers.
+	Explanation []prog.ArgExplanation
 }
 
 type Stats struct {
@@ -46,6 +54,7 @@ type Stats struct {
 	SimplifyProgTime time.Duration
 	ExtractCTime     time.Duration
 	SimplifyCTime    time.Duration
+	ExplainTime      time.Duration
 }
 
 type reproContext struct {
@@ -165,9 +174,9 @@ func (ctx *reproContext) run() (*Result, *Stats, error) {
 		for attempts := 0; ctx.report.Corrupted && attempts < 3; attempts++ {
 			ctx.reproLogf(3, "report is corrupted, running repro again")
 			if res.CRepro {
-				_, err = ctx.testCProg(res.Prog, res.Duration, res.Opts, false)
+				_, err = ctx.testCResult(res, res.Opts, false)
 			} else {
-				_, err = ctx.testProg(res.Prog, res.Duration, res.Opts, false)
+				_, err = ctx.testResult(res, res.Opts, false)
 			}
 			if err != nil {
 				return nil, nil, err
@@ -268,7 +277,7 @@ func (ctx *reproContext) repro() (*Result, error) {
 	}
 	// Validate the resulting reproducer - a random rare kernel crash might have diverted the process.
 	res.Reliability, err = calculateReliability(func() (bool, error) {
-		ret, err := ctx.testProg(res.Prog, res.Duration, res.Opts, false)
+		ret, err := ctx.testResult(res, res.Opts, false)
 		if err != nil {
 			return false, err
 		}
@@ -286,6 +295,12 @@ func (ctx *reproContext) repro() (*Result, error) {
 		return nil, err
 	}
 
+	if !ctx.fast && len(res.Progs) == 0 {
+		res, err = ctx.explainProg(res)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return res, nil
 }
 
@@ -367,6 +382,18 @@ func (ctx *reproContext) extractProg(entries []*prog.LogEntry) (*Result, error)
 		}
 	}
 
+	// As a last resort, execute last programs of different procs concurrently to detect races
+	// between programs. This is done only once under the biggest timeout to bound the cost
+	// (see maxParallelCandidates).
+	res, err := ctx.extractProgParallel(entries, ctx.testTimeouts[len(ctx.testTimeouts)-1])
+	if err != nil {
+		return nil, err
+	}
+	if res != nil {
+		ctx.reproLogf(3, "found parallel reproducer with %d syscalls", len(res.Prog.Calls))
+		return res, nil
+	}
+
 	ctx.reproLogf(2, "failed to extract reproducer")
 	return nil, nil
 }
@@ -534,6 +561,10 @@ func (ctx *reproContext) minimizeProg(res *Result) (*Result, error) {
 	if ctx.fast {
 		mode = prog.MinimizeCallsOnly
 	}
+	if len(res.Progs) != 0 {
+		ctx.minimizeParallel(res, mode)
+		return res, nil
+	}
 	var testErr error
 	res.Prog, _ = prog.Minimize(res.Prog, -1, mode, func(p1 *prog.Prog, callIndex int) bool {
 		if testErr != nil {
@@ -569,10 +600,10 @@ func (ctx *reproContext) simplifyProg(res *Result) (*Result, error) {
 	// Do further simplifications.
 	for _, simplify := range progSimplifies {
 		opts := res.Opts
-		if !simplify(&opts) || !checkOpts(&opts, ctx.timeouts, res.Duration) {
+		if !simplify(&opts) || !checkOpts(&opts, ctx.timeouts, res.Duration) || opts.Procs < len(res.Progs) {
 			continue
 		}
-		ret, err := ctx.testProg(res.Prog, res.Duration, opts, true)
+		ret, err := ctx.testResult(res, opts, true)
 		if err != nil {
 			return nil, err
 		}
@@ -604,7 +635,7 @@ func (ctx *reproContext) extractC(res *Result) (*Result, error) {
 		ctx.stats.ExtractCTime = time.Since(start)
 	}()
 
-	ret, err := ctx.testCProg(res.Prog, res.Duration, res.Opts, true)
+	ret, err := ctx.testCResult(res, res.Opts, true)
 	if err != nil {
 		return nil, err
 	}
@@ -622,10 +653,10 @@ func (ctx *reproContext) simplifyC(res *Result) (*Result, error) {
 
 	for _, simplify := range cSimplifies {
 		opts := res.Opts
-		if !simplify(&opts) || !checkOpts(&opts, ctx.timeouts, res.Duration) {
+		if !simplify(&opts) || !checkOpts(&opts, ctx.timeouts, res.Duration) || opts.Procs < len(res.Progs) {
 			continue
 		}
-		ret, err := ctx.testCProg(res.Prog, res.Duration, opts, true)
+		ret, err := ctx.testCResult(res, opts, true)
 		if err != nil {
 			return nil, err
 		}
@@ -637,6 +668,62 @@ func (ctx *reproContext) simplifyC(res *Result) (*Result, error) {
 	return res, nil
 }
 
+// Find out which arguments are essential for triggering the crash.
+// Kernel developers frequently want to know which fields of the reproducer actually matter.
+func (ctx *reproContext) explainProg(res *Result) (*Result, error) {
+	// An argument is deemed essential if the mutated program does not crash,
+	// so for flaky reproducers we would mark random arguments as essential.
+	const minReliability = 0.5
+	if res.Reliability < minReliability {
+		ctx.reproLogf(2, "not explaining program arguments: reliability %.2f is too low", res.Reliability)
+		return res, nil
+	}
+	ctx.reproLogf(2, "explaining guilty program arguments")
+	start := time.Now()
+	defer func() {
+		ctx.stats.ExplainTime = time.Since(start)
+	}()
+
+	// Each argument costs up to runsPerTest test runs, so don't spend too much time on large programs.
+	const (
+		maxRuns = 60
+		maxTime = 30 * time.Minute
+	)
+	runsPerTest := explainRuns(res.Reliability)
+	errTooManyTests := errors.New("too many tests")
+	runs := 0
+	expl, err := prog.Explain(res.Prog, rand.NewSource(time.Now().UnixNano()), func(p *prog.Prog) (bool, error) {
+		for i := 0; i < runsPerTest; i++ {
+			if runs++; runs > maxRuns || time.Since(start) > maxTime {
+				return false, errTooManyTests
+			}
+			ret, err := ctx.testProg(p, res.Duration, res.Opts, true)
+			if err != nil || ret.Crashed {
+				return ret.Crashed, err
+			}
+		}
+		return false, nil
+	})
+	if err == errTooManyTests {
+		ctx.reproLogf(2, "explained only %d arguments", len(expl))
+	} else if err != nil {
+		return nil, err
+	}
+	res.Explanation = expl
+	return res, nil
+}
+
+// explainRuns returns the number of runs of a mutated program needed to be reasonably sure (90%)
+// that the absence of a crash is not just a reproducer flake.
+func explainRuns(reliability float64) int {
+	const maxRuns = 4
+	if reliability >= 1 {
+		return 1
+	}
+	runs := int(math.Ceil(math.Log(0.1) / math.Log(1-reliability)))
+	return max(1, min(runs, maxRuns))
+}
+
 func checkOpts(opts *csource.Options, timeouts targets.Timeouts, timeout time.Duration) bool {
 	if !opts.Repeat && timeout >= time.Minute {
 		// If we have a non-repeating C reproducer with timeout > vm.NoOutputTimeout and it hangs
@@ -766,6 +853,28 @@ func (ctx *reproContext) testCProg(p *prog.Prog, duration time.Duration, opts cs
 	}, strict)
 }
 
+// testResult executes the syz reproducer res with the given options.
+func (ctx *reproContext) testResult(res *Result, opts csource.Options, strict bool) (verdict, error) {
+	if len(res.Progs) != 0 {
+		return ctx.testParallelProgs(res.Progs, res.Duration, opts, strict)
+	}
+	return ctx.testProg(res.Prog, res.Duration, opts, strict)
+}
+
+// testCResult executes the C reproducer for res with the given options.
+func (ctx *reproContext) testCResult(res *Result, opts csource.Options, strict bool) (verdict, error) {
+	if len(res.Progs) == 0 {
+		return ctx.testCProg(res.Prog, res.Duration, opts, strict)
+	}
+	return ctx.getVerdict(func() (*instance.RunResult, error) {
+		return ctx.exec.Run(ctx.ctx, instance.ExecParams{
+			CProgs:   res.Progs,
+			Opts:     opts,
+			Duration: res.Duration,
+		}, ctx.reproLogf)
+	}, strict)
+}
+
 func (ctx *reproContext) reproLogf(level int, format string, args ...interface{}) {
 	if ctx.logf != nil {
 		ctx.logf(format, args...)
@@ -831,7 +940,7 @@ func (pw *poolWrapper) Run(ctx context.Context, params instance.ExecParams,
 	runErr := pw.pool.Run(ctx, func(ctx context.Context, inst *vm.Instance, updInfo dispatcher.UpdateInfo) {
 		updInfo(func(info *dispatcher.Info) {
 			typ := "syz"
-			if params.CProg != nil {
+			if params.CProg != nil || params.CProgs != nil {
 				typ = "C"
 			}
 			info.Status = fmt.Sprintf("reproducing (%s, %.1f min)", typ, params.Duration.Minutes())
@@ -842,7 +951,7 @@ func (pw *poolWrapper) Run(ctx context.Context, params instance.ExecParams,
 		if err != nil {
 			return
 		}
-		if params.CProg != nil {
+		if params.CProg != nil || params.CProgs != nil {
 			result, err = ret.RunCProg(params)
 		} else {
 			result, err = ret.RunSyzProg(params)
@@ -1031,13 +1140,46 @@ func (stats *Stats) FullLog() []byte {
 		return nil
 	}
 	return []byte(fmt.Sprintf("Extracting prog: %v\nMinimizing prog: %v\n"+
-		"Simplifying prog options: %v\nExtracting C: %v\nSimplifying C: %v\n\n\n%s",
+		"Simplifying prog options: %v\nExtracting C: %v\nSimplifying C: %v\nExplaining prog: %v\n\n\n%s",
 		stats.ExtractProgTime, stats.MinimizeProgTime,
-		stats.SimplifyProgTime, stats.ExtractCTime, stats.SimplifyCTime, stats.Log))
+		stats.SimplifyProgTime, stats.ExtractCTime, stats.SimplifyCTime, stats.ExplainTime, stats.Log))
+}
+
+// Serialize returns the syz reproducer text.
+// Calls are annotated with "# essential:" and "# irrelevant:" comments listing their arguments (see Explanation).
+// For parallel reproducers it's the concatenated program (see ParallelLog).
+func (repro *Result) Serialize() []byte {
+	return repro.Prog.SerializeExplained(repro.Explanation)
+}
+
+// ParallelLog returns the programs of a parallel reproducer in the execution log format
+// with one program per proc (syz-execprog executes them concurrently if procs >= len(Progs)),
+// or nil if the crash is reproduced by a single program.
+// Serialize is still a valid syz program (the concatenation), so it's what should be stored or sent
+// where a syz reproducer is expected.
+func (repro *Result) ParallelLog() []byte {
+	if len(repro.Progs) == 0 {
+		return nil
+	}
+	return encodeEntries(parallelEntries(repro.Progs))
+}
+
+// ExecProg returns the syz-execprog input that reproduces the crash.
+func (repro *Result) ExecProg() []byte {
+	if log := repro.ParallelLog(); log != nil {
+		return log
+	}
+	return repro.Serialize()
 }
 
 func (repro *Result) CProgram() ([]byte, error) {
-	cprog, err := csource.Write(repro.Prog, repro.Opts)
+	var cprog []byte
+	var err error
+	if len(repro.Progs) != 0 {
+		cprog, err = csource.WriteParallel(repro.Progs, repro.Opts)
+	} else {
+		cprog, err = csource.Write(repro.Prog, repro.Opts)
+	}
 	if err == nil {
 		formatted, err := csource.Format(cprog)
 		if err == nil {
@@ -204,6 +204,8 @@ func TestPlainRepro(t *testing.T) {
 	if diff := cmp.Diff(expectedReproducer, string(result.Prog.Serialize())); diff != "" {
 		t.Fatal(diff)
 	}
+	// The crash happens only with alarm(0xa), so the argument must be reported as essential.
+	assert.Equal(t, "pause()\n# essential: seconds\nalarm(0xa)\n", string(result.Serialize()))
 }
 
 // There happen to be transient errors like ssh/scp connection failures.
@@ -277,6 +279,68 @@ alarm(0xa)
 	}
 }
 
+// parallelExecInterface crashes only if pause() and alarm(0xa) are executed concurrently by different procs.
+type parallelExecInterface struct {
+	target *prog.Target
+}
+
+func (pei *parallelExecInterface) Run(_ context.Context, params instance.ExecParams,
+	_ instance.ExecutorLogger) (*instance.RunResult, error) {
+	var progs []*prog.Prog
+	switch {
+	case params.CProgs != nil:
+		progs = params.CProgs
+	case params.CProg != nil:
+		progs = []*prog.Prog{params.CProg}
+	default:
+		for _, ent := range pei.target.ParseLog(params.SyzProg, prog.NonStrict) {
+			progs = append(progs, ent.P)
+		}
+	}
+	if params.Opts.Procs < 2 {
+		return fakeCrashResult(""), nil
+	}
+	for i, p1 := range progs {
+		for j, p2 := range progs {
+			if i != j && regexp.MustCompile(`pause\(\)`).Match(p1.Serialize()) &&
+				regexp.MustCompile(`alarm\(0xa\)`).Match(p2.Serialize()) {
+				return fakeCrashResult("crashed"), nil
+			}
+		}
+	}
+	return fakeCrashResult(""), nil
+}
+
+func TestParallelRepro(t *testing.T) {
+	target, err := prog.GetTarget(targets.Linux, targets.AMD64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, _, err := runTestRepro(t, `
+2015/12/21 12:18:05 executing program 1:
+getpid()
+pause()
+2015/12/21 12:18:10 executing program 2:
+alarm(0xa)
+getuid()
+2015/12/21 12:18:15 executing program 3:
+getpid()
+`, &parallelExecInterface{target})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result == nil {
+		t.Fatal("failed to reproduce")
+	}
+	if diff := cmp.Diff("executing program 0:\nalarm(0xa)\nexecuting program 1:\npause()\n",
+		string(result.ParallelLog())); diff != "" {
+		t.Fatal(diff)
+	}
+	assert.Equal(t, "alarm(0xa)\npause()\n", string(result.Prog.Serialize()))
+	assert.True(t, result.CRepro)
+	assert.GreaterOrEqual(t, result.Opts.Procs, 2)
+}
+
 func TestFlakyCrashes(t *testing.T) {
 	t.Parallel()
 	// A single flaky crash may divert the whole process.
@@ -314,6 +378,14 @@ func TestFlakyCrashes(t *testing.T) {
 	assert.Greater(t, success, iters/3*2, "must succeed >2/3 of cases")
 }
 
+func TestExplainRuns(t *testing.T) {
+	assert.Equal(t, 1, explainRuns(1))
+	assert.Equal(t, 1, explainRuns(0.95))
+	assert.Equal(t, 2, explainRuns(0.75))
+	assert.Equal(t, 4, explainRuns(0.5))
+	assert.Equal(t, 4, explainRuns(0.2))
+}
+
 func BenchmarkCalculateReliability(b *testing.B) {
 	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 
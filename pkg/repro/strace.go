@@ -51,10 +51,11 @@ func RunStrace(result *Result, cfg *mgrconfig.Config, reporter *report.Reporter,
 		if result.CRepro {
 			log.Logf(1, "running C repro under strace")
 			params.CProg = result.Prog
+			params.CProgs = result.Progs
 			runRes, err = ret.RunCProg(params)
 		} else {
 			log.Logf(1, "running syz repro under strace")
-			params.SyzProg = result.Prog.Serialize()
+			params.SyzProg = result.ExecProg()
 			runRes, err = ret.RunSyzProg(params)
 		}
 	})
@@ -15,11 +15,13 @@ import (
 
 // LastExecuting keeps the given number of last executed programs
 // for each proc in a VM, and allows to query this set after a crash.
+// It also keeps the calls that were in progress according to the last executor heartbeat.
 type LastExecuting struct {
-	count     int
-	procs     []ExecRecord
-	hanged    []ExecRecord // hanged programs, kept forever
-	positions []int
+	count      int
+	procs      []ExecRecord
+	hanged     []ExecRecord // hanged programs, kept forever
+	positions  []int
+	inProgress map[int][]InProgressCall
 }
 
 type ExecRecord struct {
@@ -27,6 +29,15 @@ type ExecRecord struct {
 	Proc int
 	Prog []byte
 	Time time.Duration
+	// Calls of the program that were still executing at the time of the last executor heartbeat.
+	InProgress []InProgressCall
+}
+
+// InProgressCall describes a call that was executing at the time of an executor heartbeat.
+type InProgressCall struct {
+	Call     int // index of the call in the program
+	Name     string
+	Duration time.Duration // for how long the call was executing
 }
 
 func MakeLastExecuting(procs, count int) *LastExecuting {
@@ -64,14 +75,24 @@ func (last *LastExecuting) Hanged(id, proc int, progData []byte, now time.Durati
 	})
 }
 
+// Note calls that are currently in progress according to an executor heartbeat.
+// The calls are keyed by program ID, and replace the previously noted calls.
+func (last *LastExecuting) Heartbeat(calls map[int][]InProgressCall) {
+	last.inProgress = calls
+}
+
 // Returns a sorted set of last executing programs.
 // The records are sorted by time in ascending order.
 // ExecRecord.Time is the difference in start executing time between this
 // program and the program that started executing last.
 func (last *LastExecuting) Collect() []ExecRecord {
 	procs := append(last.procs, last.hanged...)
+	for i := range procs {
+		procs[i].InProgress = last.inProgress[procs[i].ID]
+	}
 	last.procs = nil // The type must not be used after this.
 	last.hanged = nil
+	last.inProgress = nil
 	sort.Slice(procs, func(i, j int) bool {
 		return procs[i].Time < procs[j].Time
 	})
@@ -90,7 +111,12 @@ func PrependExecuting(rep *report.Report, lastExec []ExecRecord) {
 	buf := new(bytes.Buffer)
 	fmt.Fprintf(buf, "last executing test programs:\n\n")
 	for _, exec := range lastExec {
-		fmt.Fprintf(buf, "%v ago: executing program %v (id=%v):\n%s\n", exec.Time, exec.Proc, exec.ID, exec.Prog)
+		fmt.Fprintf(buf, "%v ago: executing program %v (id=%v):\n%s", exec.Time, exec.Proc, exec.ID, exec.Prog)
+		for _, call := range exec.InProgress {
+			fmt.Fprintf(buf, "call #%v %v was executing for %v at the last heartbeat\n",
+				call.Call, call.Name, call.Duration)
+		}
+		fmt.Fprintf(buf, "\n")
 	}
 	fmt.Fprintf(buf, "kernel console output (not intermixed with test programs):\n\n")
 	rep.Output = append(buf.Bytes(), rep.Output...)
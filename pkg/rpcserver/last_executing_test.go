@@ -5,6 +5,7 @@ package rpcserver
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -76,3 +77,40 @@ func TestLastExecutingHanged(t *testing.T) {
 		{ID: 9, Proc: 0, Prog: []byte("prog9"), Time: 0},
 	})
 }
+
+func TestLastExecutingInProgress(t *testing.T) {
+	last := MakeLastExecuting(2, 3)
+	last.Note(1, 0, []byte("prog1"), 10)
+	last.Note(2, 1, []byte("prog2"), 20)
+	last.Heartbeat(map[int][]InProgressCall{
+		1: {{Call: 0, Name: "foo", Duration: time.Second}},
+	})
+	last.Note(3, 0, []byte("prog3"), 30)
+	last.Heartbeat(map[int][]InProgressCall{
+		2: {{Call: 1, Name: "bar", Duration: time.Minute}},
+		3: {{Call: 0, Name: "foo", Duration: time.Second}, {Call: 2, Name: "baz", Duration: time.Second}},
+	})
+	assert.Equal(t, last.Collect(), []ExecRecord{
+		{ID: 1, Proc: 0, Prog: []byte("prog1"), Time: 20},
+		{ID: 2, Proc: 1, Prog: []byte("prog2"), Time: 10, InProgress: []InProgressCall{
+			{Call: 1, Name: "bar", Duration: time.Minute},
+		}},
+		{ID: 3, Proc: 0, Prog: []byte("prog3"), Time: 0, InProgress: []InProgressCall{
+			{Call: 0, Name: "foo", Duration: time.Second},
+			{Call: 2, Name: "baz", Duration: time.Second},
+		}},
+	})
+}
+
+func TestLastExecutingIdleHeartbeat(t *testing.T) {
+	last := MakeLastExecuting(1, 3)
+	last.Note(1, 0, []byte("prog1"), 10)
+	last.Heartbeat(map[int][]InProgressCall{
+		1: {{Call: 0, Name: "foo", Duration: time.Second}},
+	})
+	// The executor sends an empty heartbeat when nothing is executing.
+	last.Heartbeat(map[int][]InProgressCall{})
+	assert.Equal(t, last.Collect(), []ExecRecord{
+		{ID: 1, Proc: 0, Prog: []byte("prog1"), Time: 0},
+	})
+}
@@ -126,7 +126,7 @@ func (ctx *local) Serve(context context.Context) error {
 }
 
 func (ctx *local) RunInstance(baseCtx context.Context, id int) error {
-	connErr := ctx.serv.CreateInstance(id, nil, nil)
+	connErr := ctx.serv.CreateInstance(id, nil, nil, nil)
 	defer ctx.serv.ShutdownInstance(id, true)
 
 	cfg := ctx.cfg
@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -77,7 +78,7 @@ type Server interface {
 	Port() int
 	TriagedCorpus()
 	Serve(context.Context) error
-	CreateInstance(id int, injectExec chan<- bool, updInfo dispatcher.UpdateInfo) chan error
+	CreateInstance(id int, injectExec, diagnoseHang chan<- bool, updInfo dispatcher.UpdateInfo) chan error
 	ShutdownInstance(id int, crashed bool, extraExecs ...report.ExecutorInfo) ([]ExecRecord, []byte)
 	StopFuzzing(id int)
 	DistributeSignalDelta(plus signal.Signal)
@@ -123,22 +124,24 @@ func NewStats() Stats {
 
 func NewNamedStats(name string) Stats {
 	suffix, linkSuffix := "", ""
+	labels := stat.Labels{}
 	if name != "" {
 		suffix = " [" + name + "]"
 		linkSuffix = "?pool=" + url.QueryEscape(name)
+		labels["pool"] = name
 	}
 	return Stats{
 		StatExecs: stat.New("exec total"+suffix, "Total test program executions",
-			stat.Console, stat.Rate{}, stat.Prometheus("syz_exec_total"+name),
+			stat.Console, stat.Rate{}, stat.Prometheus("syz_exec_total"), labels, stat.LabelNames{"vm"},
 		),
 		StatNumFuzzing: stat.New("fuzzing VMs"+suffix,
 			"Number of VMs that are currently fuzzing", stat.Graph("fuzzing VMs"),
-			stat.Link("/vms"+linkSuffix),
+			stat.Link("/vms"+linkSuffix), stat.Prometheus("syz_fuzzing_vms"), labels,
 		),
 		StatVMRestarts: stat.New("vm restarts"+suffix, "Total number of VM starts",
-			stat.Rate{}, stat.NoGraph),
+			stat.Rate{}, stat.NoGraph, stat.Prometheus("syz_vm_restarts_total"), labels),
 		StatModules: stat.New("modules"+suffix, "Number of loaded kernel modules",
-			stat.NoGraph, stat.Link("/modules"+linkSuffix)),
+			stat.NoGraph, stat.Link("/modules"+linkSuffix), stat.Prometheus("syz_modules"), labels),
 	}
 }
 
@@ -215,6 +218,9 @@ func newImpl(cfg *Config, mgr Manager) *server {
 			statExecs:              cfg.Stats.StatExecs,
 			statNoExecRequests:     queue.StatNoExecRequests,
 			statNoExecDuration:     queue.StatNoExecDuration,
+			statHangDiagnoses: stat.New("hang diagnoses",
+				"Number of times stacks were dumped on a VM because a test program call was hanging",
+				stat.Graph("executor")),
 		},
 	}
 }
@@ -302,7 +308,7 @@ func (serv *server) handleConn(ctx context.Context, conn *flatrpc.Conn) error {
 
 	if serv.cfg.VMLess {
 		// There is no VM loop, so mimic what it would do.
-		serv.CreateInstance(id, nil, nil)
+		serv.CreateInstance(id, nil, nil, nil)
 		defer func() {
 			serv.StopFuzzing(id)
 			serv.ShutdownInstance(id, true)
@@ -532,7 +538,8 @@ func (serv *server) printMachineCheck(checkFilesInfo []*flatrpc.FileInfo, enable
 	log.Logf(0, "machine check:\n%s", buf.Bytes())
 }
 
-func (serv *server) CreateInstance(id int, injectExec chan<- bool, updInfo dispatcher.UpdateInfo) chan error {
+func (serv *server) CreateInstance(id int, injectExec, diagnoseHang chan<- bool,
+	updInfo dispatcher.UpdateInfo) chan error {
 	runner := &Runner{
 		id:            id,
 		source:        serv.execSource,
@@ -543,6 +550,10 @@ func (serv *server) CreateInstance(id int, injectExec chan<- bool, updInfo dispa
 		debugTimeouts: serv.cfg.DebugTimeouts,
 		sysTarget:     serv.sysTarget,
 		injectExec:    injectExec,
+		diagnoseHang:  diagnoseHang,
+		// Give the kernel a chance to detect the hang itself first (hung task timeout is usually 2 minutes),
+		// but dump stacks before we report "no output".
+		hangThreshold: serv.timeouts.NoOutput / 2,
 		infoc:         make(chan chan []byte),
 		requests:      make(map[int64]*queue.Request),
 		executing:     make(map[int64]bool),
@@ -550,9 +561,11 @@ func (serv *server) CreateInstance(id int, injectExec chan<- bool, updInfo dispa
 		// Executor may report proc IDs that are larger than serv.cfg.Procs.
 		lastExec: MakeLastExecuting(prog.MaxPids, 6),
 		stats:    serv.runnerStats,
-		procs:    serv.cfg.Procs,
-		updInfo:  updInfo,
-		resultCh: make(chan error, 1),
+		// The per-VM part of the metric.
+		statExecs: serv.runnerStats.statExecs.With(strconv.Itoa(id)),
+		procs:     serv.cfg.Procs,
+		updInfo:   updInfo,
+		resultCh:  make(chan error, 1),
 	}
 	serv.mu.Lock()
 	defer serv.mu.Unlock()
@@ -217,7 +217,7 @@ func TestHandleConn(t *testing.T) {
 			serv := s.(*server)
 
 			injectExec := make(chan bool)
-			serv.CreateInstance(1, injectExec, nil)
+			serv.CreateInstance(1, injectExec, nil, nil)
 			g := errgroup.Group{}
 			g.Go(func() error {
 				hello, err := flatrpc.Recv[*flatrpc.ConnectHelloRaw](clientConn)
@@ -35,8 +35,12 @@ type Runner struct {
 	debugTimeouts bool
 	sysTarget     *targets.Target
 	stats         *runnerStats
+	statExecs     *stat.Val
 	finished      chan bool
 	injectExec    chan<- bool
+	diagnoseHang  chan<- bool
+	hangThreshold time.Duration
+	hangDiagnosed bool
 	infoc         chan chan []byte
 	canonicalizer *cover.CanonicalizerInstance
 	nextRequestID int64
@@ -61,6 +65,7 @@ type runnerStats struct {
 	statExecBufferTooSmall *stat.Val
 	statNoExecRequests     *stat.Val
 	statNoExecDuration     *stat.Val
+	statHangDiagnoses      *stat.Val
 }
 
 type handshakeConfig struct {
@@ -203,6 +208,8 @@ func (runner *Runner) ConnectionLoop() error {
 			err = runner.handleExecutingMessage(msg)
 		case *flatrpc.ExecResult:
 			err = runner.handleExecResult(msg)
+		case *flatrpc.Heartbeat:
+			runner.handleHeartbeat(msg)
 		case *flatrpc.StateResult:
 			buf := new(bytes.Buffer)
 			fmt.Fprintf(buf, "pending requests on the VM:")
@@ -359,7 +366,7 @@ func (runner *Runner) handleExecutingMessage(msg *flatrpc.ExecutingMessage) erro
 	if proc < 0 || proc >= prog.MaxPids {
 		return fmt.Errorf("got bad proc id %v", proc)
 	}
-	runner.stats.statExecs.Add(1)
+	runner.statExecs.Add(1)
 	if msg.Try == 0 {
 		if msg.WaitDuration != 0 {
 			runner.stats.statNoExecRequests.Add(1)
@@ -390,6 +397,43 @@ func (runner *Runner) handleExecutingMessage(msg *flatrpc.ExecutingMessage) erro
 	return nil
 }
 
+func (runner *Runner) handleHeartbeat(msg *flatrpc.Heartbeat) {
+	calls := make(map[int][]InProgressCall)
+	hanging := false
+	for _, call := range msg.Calls {
+		req := runner.requests[call.Id]
+		if req == nil {
+			// The request has already finished, or was reported as hanged.
+			continue
+		}
+		name := ""
+		if req.Type == flatrpc.RequestTypeProgram && call.Call >= 0 && int(call.Call) < len(req.Prog.Calls) {
+			name = req.Prog.Calls[call.Call].Meta.Name
+		}
+		duration := time.Duration(call.Duration)
+		calls[int(call.Id)] = append(calls[int(call.Id)], InProgressCall{
+			Call:     int(call.Call),
+			Name:     name,
+			Duration: duration,
+		})
+		if duration > runner.hangThreshold {
+			hanging = true
+		}
+	}
+	runner.lastExec.Heartbeat(calls)
+	// We only know how to collect stacks from a live kernel on Linux (sysrq-t),
+	// and we do it only once per VM since the dump is large.
+	if !hanging || runner.hangDiagnosed || runner.sysTarget.OS != targets.Linux {
+		return
+	}
+	runner.hangDiagnosed = true
+	runner.stats.statHangDiagnoses.Add(1)
+	select {
+	case runner.diagnoseHang <- true:
+	default:
+	}
+}
+
 func (runner *Runner) handleExecResult(msg *flatrpc.ExecResult) error {
 	req := runner.requests[msg.Id]
 	if req == nil {
@@ -0,0 +1,166 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/VividCortex/gohistogram"
+	"github.com/google/syzkaller/pkg/osutil"
+)
+
+// SaveHistory saves the graph history to the file, so that it can be restored with LoadHistory
+// after a restart of the process.
+func SaveHistory(file string) error {
+	data, err := global.saveHistory()
+	if err != nil {
+		return err
+	}
+	return osutil.WriteFileAtomically(file, data)
+}
+
+// LoadHistory restores the graph history saved with SaveHistory and marks the restart on the graphs.
+// It should be called early on start, since the history collected before the call is discarded.
+// If the file does not exist, it's a no-op.
+func LoadHistory(file string) error {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := global.loadHistory(data); err != nil {
+		return fmt.Errorf("failed to load stats history from %v: %w", file, err)
+	}
+	return nil
+}
+
+type savedHistory struct {
+	Scale    int                   `json:"scale"`
+	Restarts []int                 `json:"restarts,omitempty"`
+	Lines    map[string]*savedLine `json:"lines"`
+}
+
+type savedLine struct {
+	Graph string    `json:"graph"`
+	Rate  bool      `json:"rate,omitempty"`
+	Data  []float64 `json:"data,omitempty"`
+	// Quantiles are 10/50/90 percentiles of distribution metrics (see RenderGraphs).
+	Quantiles [][3]float64 `json:"quantiles,omitempty"`
+}
+
+var savedQuantiles = [3]float64{0.1, 0.5, 0.9}
+
+func (s *set) saveHistory() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	saved := &savedHistory{
+		Scale:    s.historyScale,
+		Restarts: s.restarts,
+		Lines:    make(map[string]*savedLine),
+	}
+	for title, graph := range s.graphs {
+		for name, ln := range graph.lines {
+			sl := &savedLine{
+				Graph: title,
+				Rate:  ln.rate,
+			}
+			if ln.hist == nil {
+				sl.Data = ln.data[:s.historyPos]
+			} else {
+				sl.Quantiles = make([][3]float64, s.historyPos)
+				for i, hist := range ln.hist[:s.historyPos] {
+					for q, quantile := range savedQuantiles {
+						if hist != nil {
+							sl.Quantiles[i][q] = hist.Quantile(quantile)
+						}
+					}
+				}
+			}
+			saved.Lines[name] = sl
+		}
+	}
+	// Lines that were restored, but were not created since the start (e.g. the metric is registered
+	// only in some modes) are preserved as is.
+	for name, sl := range s.restored {
+		if saved.Lines[name] == nil {
+			saved.Lines[name] = sl
+		}
+	}
+	return json.Marshal(saved)
+}
+
+func (s *set) loadHistory(data []byte) error {
+	saved := new(savedHistory)
+	if err := json.Unmarshal(data, saved); err != nil {
+		return err
+	}
+	points := -1
+	for name, sl := range saved.Lines {
+		n := max(len(sl.Data), len(sl.Quantiles))
+		if points != -1 && n != points || n > s.historySize {
+			return fmt.Errorf("bad number of points %v for %v", n, name)
+		}
+		points = n
+	}
+	if saved.Scale <= 0 || points == -1 {
+		return fmt.Errorf("corrupted history")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Lines are created on the first tick after the metric registration,
+	// at this point they get the restored history (see restoreLine).
+	for _, graph := range s.graphs {
+		graph.lines = make(map[string]*line)
+	}
+	for _, v := range s.vals {
+		v.prev = v.Val()
+	}
+	s.historyPos = points
+	s.historyScale = saved.Scale
+	s.historyTicks = 0
+	s.restored = saved.Lines
+	s.restarts = append(saved.Restarts, points)
+	return nil
+}
+
+// restoreLine fills the line with the restored history, if it matches the metric.
+func (s *set) restoreLine(v *Val, ln *line) {
+	sl := s.restored[v.name]
+	if sl == nil || sl.Graph != v.graph || sl.Rate != v.rate || (sl.Quantiles != nil) != v.hist {
+		return
+	}
+	delete(s.restored, v.name)
+	copy(ln.data, sl.Data)
+	for i, quantiles := range sl.Quantiles {
+		// A histogram of the 3 values has the same 10/50/90 percentiles.
+		hist := gohistogram.NewHistogram(histogramBuckets)
+		for _, val := range quantiles {
+			hist.Add(val)
+		}
+		ln.hist[i] = hist
+	}
+}
+
+// compress halves the restored history the same way set.compress does for the lines.
+func (sl *savedLine) compress() {
+	for i := 0; i < len(sl.Data)/2; i++ {
+		v1, v2 := sl.Data[2*i], sl.Data[2*i+1]
+		if sl.Rate {
+			sl.Data[i] = (v1 + v2) / 2
+		} else {
+			sl.Data[i] = max(v1, v2)
+		}
+	}
+	sl.Data = sl.Data[:len(sl.Data)/2]
+	for i := 0; i < len(sl.Quantiles)/2; i++ {
+		sl.Quantiles[i] = sl.Quantiles[2*i]
+	}
+	sl.Quantiles = sl.Quantiles[:len(sl.Quantiles)/2]
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	a := assert.New(t)
+	set1 := newSet(8, false)
+	v1 := set1.New("v", "desc", Graph("graph"))
+	r1 := set1.New("r", "desc", Rate{}, Graph("graph"))
+	d1 := set1.New("d", "desc", Distribution{}, Graph("dist"))
+	for i := 0; i < 3; i++ {
+		v1.Add(10)
+		r1.Add(5)
+		d1.Add(i)
+		d1.Add(100)
+		set1.tick()
+	}
+	graphs1 := set1.RenderGraphs()
+	data, err := set1.saveHistory()
+	a.NoError(err)
+
+	set2 := newSet(8, false)
+	v2 := set2.New("v", "desc", Graph("graph"))
+	r2 := set2.New("r", "desc", Rate{}, Graph("graph"))
+	d2 := set2.New("d", "desc", Distribution{}, Graph("dist"))
+	// The history collected before the load is discarded.
+	v2.Add(1000)
+	set2.tick()
+	a.NoError(set2.loadHistory(data))
+	v2.Add(1)
+	r2.Add(1)
+	d2.Add(50)
+	set2.tick()
+
+	graphs2 := set2.RenderGraphs()
+	a.Len(graphs2, len(graphs1))
+	for i, g1 := range graphs1 {
+		g2 := graphs2[i]
+		a.Equal(g1.Title, g2.Title)
+		a.Equal(g1.Lines, g2.Lines)
+		a.Len(g2.Points, 4)
+		for j, p := range g1.Points {
+			a.Equal(p, g2.Points[j])
+		}
+		a.True(g2.Points[3].Restart)
+	}
+	a.Equal([]float64{1001, 1}, graphs2[1].Points[3].Y)
+
+	// Restart markers survive history compression and the next restart.
+	for i := 0; i < 5; i++ {
+		set2.tick()
+	}
+	data, err = set2.saveHistory()
+	a.NoError(err)
+	set3 := newSet(8, false)
+	set3.New("v", "desc", Graph("graph"))
+	a.NoError(set3.loadHistory(data))
+	// The scale is 2 after the compression, so it takes 2 ticks to add a point.
+	set3.tick()
+	set3.tick()
+	var restarts []int
+	for i, p := range set3.RenderGraphs()[0].Points {
+		if p.Restart {
+			restarts = append(restarts, i)
+		}
+	}
+	a.Equal([]int{1, 4}, restarts)
+
+	a.Error(set3.loadHistory([]byte("{}")))
+	a.Error(set3.loadHistory([]byte("foo")))
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler serves all metrics in the OpenMetrics text format (or in the Prometheus text format
+// for clients that don't support OpenMetrics). Go runtime and process metrics are included as well.
+//
+// The metric types are:
+//   - counter for Rate metrics (the name ends with "_total"),
+//   - histogram for Distribution metrics (see Buckets),
+//   - gauge for the rest.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(prometheus.Gatherers{prometheus.DefaultGatherer, globalRegistry},
+		promhttp.HandlerOpts{EnableOpenMetrics: true})
+}
+
+var globalRegistry = newRegistry(global)
+
+func newRegistry(s *set) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collector{s})
+	return reg
+}
+
+// collector is an unchecked prometheus.Collector, since the set of metrics is not known in advance.
+type collector struct {
+	s *set
+}
+
+func (c collector) Describe(chan<- *prometheus.Desc) {}
+
+func (c collector) Collect(ch chan<- prometheus.Metric) {
+	c.s.mu.Lock()
+	var vals []*Val
+	for _, v := range c.s.vals {
+		vals = append(vals, v)
+	}
+	c.s.mu.Unlock()
+	sort.Slice(vals, func(i, j int) bool {
+		return vals[i].order < vals[j].order
+	})
+	// Gathering fails on inconsistent metric families, so we skip metrics that would cause it
+	// (e.g. if two metrics end up with the same name and labels). Metrics of the same family
+	// may have different descriptions, then the family has no help.
+	type family struct {
+		help string
+		typ  prometheus.ValueType
+		hist bool
+	}
+	families := make(map[string]*family)
+	for _, v := range vals {
+		name := v.metricName()
+		if fam := families[name]; fam == nil {
+			families[name] = &family{v.desc, v.valueType(), v.hist}
+		} else if fam.help != v.desc {
+			fam.help = ""
+		}
+	}
+	seen := make(map[string]bool)
+	for _, v := range vals {
+		name := v.metricName()
+		fam := families[name]
+		key := name + labelsKey(v.labels)
+		if fam.typ != v.valueType() || fam.hist != v.hist || seen[key] {
+			continue
+		}
+		seen[key] = true
+		desc := prometheus.NewDesc(name, fam.help, v.labelNames, prometheus.Labels(v.labels))
+		if len(v.labelNames) == 0 {
+			ch <- v.export(desc)
+			continue
+		}
+		for _, child := range v.sortedChildren() {
+			ch <- child.export(desc)
+		}
+	}
+}
+
+func (v *Val) export(desc *prometheus.Desc) prometheus.Metric {
+	if v.hist {
+		v.histMu.Lock()
+		defer v.histMu.Unlock()
+		buckets := make(map[float64]uint64)
+		total := uint64(0)
+		for i, bound := range v.buckets {
+			if v.bucketCounts != nil {
+				total += v.bucketCounts[i]
+			}
+			buckets[bound] = total
+		}
+		return prometheus.MustNewConstHistogram(desc, v.histCount, v.histSum, buckets, v.labelValues...)
+	}
+	return prometheus.MustNewConstMetric(desc, v.valueType(), float64(v.Val()), v.labelValues...)
+}
+
+func (v *Val) valueType() prometheus.ValueType {
+	if v.rate {
+		return prometheus.CounterValue
+	}
+	return prometheus.GaugeValue
+}
+
+func (v *Val) sortedChildren() []*Val {
+	v.childMu.Lock()
+	defer v.childMu.Unlock()
+	var res []*Val
+	for _, child := range v.children {
+		res = append(res, child)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return strings.Join(res[i].labelValues, "\x00") < strings.Join(res[j].labelValues, "\x00")
+	})
+	return res
+}
+
+// metricName returns the OpenMetrics name of the metric.
+func (v *Val) metricName() string {
+	if v.metric != "" {
+		return v.metric
+	}
+	name := "syz_" + sanitizeMetricName(v.name)
+	if v.rate && !strings.HasSuffix(name, "_total") {
+		name += "_total"
+	}
+	return name
+}
+
+// sanitizeMetricName converts e.g. "fuzzing VMs" to "fuzzing_vms".
+func sanitizeMetricName(name string) string {
+	var res []byte
+	for _, c := range []byte(strings.ToLower(name)) {
+		if c >= 'a' && c <= 'z' || c >= '0' && c <= '9' {
+			res = append(res, c)
+		} else if len(res) != 0 && res[len(res)-1] != '_' {
+			res = append(res, '_')
+		}
+	}
+	return strings.TrimSuffix(string(res), "_")
+}
+
+func labelsKey(labels Labels) string {
+	var pairs []string
+	for name, val := range labels {
+		pairs = append(pairs, name+"="+val)
+	}
+	sort.Strings(pairs)
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,74 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package stat
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenMetrics(t *testing.T) {
+	set := newSet(4, false)
+	set.New("fuzzing VMs", "Number of VMs", func() int { return 3 })
+	execs := set.New("exec total", "Executions", Rate{}, LabelNames{"vm"})
+	execs.With("0").Add(10)
+	execs.With("1").Add(5)
+	execs.With("0").Add(1)
+	set.New("crashes", "Crashes", Prometheus("syz_crash_total")).Add(2)
+	set.New("jobs [a]", "Jobs", Prometheus("syz_jobs"), Labels{"type": "a"}).Add(1)
+	set.New("jobs [b]", "Jobs", Prometheus("syz_jobs"), Labels{"type": "b"}).Add(2)
+	// The same name and labels as "jobs [b]", should be skipped.
+	set.New("jobs [c]", "Jobs", Prometheus("syz_jobs"), Labels{"type": "b"}).Add(3)
+	hist := set.New("exec time", "Execution time", Distribution{}, Buckets{10, 100})
+	hist.Add(5)
+	hist.Add(50)
+	hist.Add(500)
+	hist.Add(10)
+
+	assert.Equal(t, 16, execs.Val())
+	assert.Equal(t, 11, execs.With("0").Val())
+	assert.Panics(t, func() { execs.With() })
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	w := httptest.NewRecorder()
+	promhttp.HandlerFor(newRegistry(set), promhttp.HandlerOpts{EnableOpenMetrics: true}).ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+	body := w.Body.String()
+	for _, line := range []string{
+		"# TYPE syz_fuzzing_vms gauge",
+		"syz_fuzzing_vms 3.0",
+		"# TYPE syz_exec counter",
+		`syz_exec_total{vm="0"} 11.0`,
+		`syz_exec_total{vm="1"} 5.0`,
+		"syz_crash_total 2.0",
+		"# HELP syz_jobs Jobs",
+		`syz_jobs{type="a"} 1.0`,
+		`syz_jobs{type="b"} 2.0`,
+		"# TYPE syz_exec_time histogram",
+		`syz_exec_time_bucket{le="10.0"} 2`,
+		`syz_exec_time_bucket{le="100.0"} 3`,
+		`syz_exec_time_bucket{le="+Inf"} 4`,
+		"syz_exec_time_sum 565.0",
+		"syz_exec_time_count 4",
+	} {
+		assert.Contains(t, body, line+"\n")
+	}
+	assert.NotContains(t, body, `syz_jobs{type="b"} 3.0`)
+	set.New("jobs [d]", "Other jobs", Prometheus("syz_jobs"), Labels{"type": "d"})
+	w = httptest.NewRecorder()
+	promhttp.HandlerFor(newRegistry(set), promhttp.HandlerOpts{EnableOpenMetrics: true}).ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `syz_jobs{type="d"} 0.0`)
+	assert.NotContains(t, w.Body.String(), "# HELP syz_jobs Jobs")
+	assert.NotContains(t, body, "syz_exec_total 16.0")
+}
+
+func TestSanitizeMetricName(t *testing.T) {
+	assert.Equal(t, "fuzzing_vms", sanitizeMetricName("fuzzing VMs"))
+	assert.Equal(t, "exec_total_base", sanitizeMetricName("exec total [base]"))
+	assert.Equal(t, "max_signal", sanitizeMetricName("max  signal"))
+}
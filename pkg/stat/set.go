@@ -8,12 +8,12 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/VividCortex/gohistogram"
-	"github.com/prometheus/client_golang/prometheus"
 )
 
 // This file provides prometheus/streamz style metrics (Val type) for instrumenting code for monitoring.
@@ -26,7 +26,11 @@ import (
 //
 //	stat.New("metric name", "metric description", LenOf(mySlice, rwMutex))
 //
+//	statBar := stat.New("metric name", "metric description", LabelNames{"vm"})
+//	statBar.With("0").Add(1)
+//
 // Metric visualization code uses Collect/RenderGraphs functions to obtain values of all registered metrics.
+// All metrics are also exported in the OpenMetrics format (see Handler).
 
 type UI struct {
 	Name  string
@@ -61,6 +65,10 @@ type set struct {
 	historyTicks int
 	historyPos   int
 	historyScale int
+	// Point indexes where the process was restarted (see LoadHistory).
+	restarts []int
+	// Restored lines that were not yet created.
+	restored map[string]*savedLine
 }
 
 type graph struct {
@@ -146,9 +154,20 @@ const (
 // Link adds a hyperlink to metric name.
 type Link string
 
-// Prometheus exports the metric to Prometheus under the given name.
+// Prometheus sets the OpenMetrics name of the metric.
+// By default the name is derived from the metric name, e.g. "exec total" becomes "syz_exec_total".
 type Prometheus string
 
+// Labels are constant OpenMetrics labels of the metric. Metrics with the same OpenMetrics name,
+// but different labels are exported as a single metric family (e.g. metrics of different VM pools).
+type Labels map[string]string
+
+// LabelNames says that the metric is partitioned by the values of the labels (see Val.With).
+type LabelNames []string
+
+// Buckets sets upper bounds of the OpenMetrics histogram buckets for Distribution metrics.
+type Buckets []float64
+
 // Rate says to collect/visualize metric rate per unit of time rather then total value.
 type Rate struct{}
 
@@ -185,11 +204,12 @@ func FormatMB(v int, period time.Duration) string {
 
 func (s *set) New(name, desc string, opts ...any) *Val {
 	v := &Val{
-		name:  name,
-		desc:  desc,
-		graph: name,
-		order: s.nextOrder.Add(1),
-		fmt:   func(v int, period time.Duration) string { return strconv.Itoa(v) },
+		name:    name,
+		desc:    desc,
+		graph:   name,
+		order:   s.nextOrder.Add(1),
+		fmt:     func(v int, period time.Duration) string { return strconv.Itoa(v) },
+		buckets: defaultBuckets,
 	}
 	stacked := false
 	for _, o := range opts {
@@ -213,17 +233,20 @@ func (s *set) New(name, desc string, opts ...any) *Val {
 		case func(int, time.Duration) string:
 			v.fmt = opt
 		case Prometheus:
-			// Prometheus Instrumentation https://prometheus.io/docs/guides/go-application.
-			prometheus.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
-				Name: string(opt),
-				Help: desc,
-			},
-				func() float64 { return float64(v.Val()) },
-			))
+			v.metric = string(opt)
+		case Labels:
+			v.labels = opt
+		case LabelNames:
+			v.labelNames = opt
+		case Buckets:
+			v.buckets = opt
 		default:
 			panic(fmt.Sprintf("unknown stats option %#v", o))
 		}
 	}
+	if v.ext != nil && len(v.labelNames) != 0 {
+		panic(fmt.Sprintf("stat %v: external metrics can't have label names", name))
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	s.vals[name] = v
@@ -254,24 +277,84 @@ type Val struct {
 	prev    int
 	histMu  sync.Mutex
 	histVal *gohistogram.NumericHistogram
+	// Cumulative histogram for OpenMetrics, protected by histMu.
+	buckets      []float64
+	bucketCounts []uint64
+	histSum      float64
+	histCount    uint64
+
+	metric      string
+	labels      Labels
+	labelNames  []string
+	labelValues []string
+	parent      *Val
+	childMu     sync.Mutex
+	children    map[string]*Val
 }
 
+// defaultBuckets are histogram buckets for Distribution metrics that cover the typical values
+// (milliseconds, megabytes, counts) with a reasonable precision.
+var defaultBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1e3, 2e3, 5e3, 1e4, 2e4, 5e4, 1e5, 2e5, 5e5, 1e6}
+
 func (v *Val) Add(val int) {
 	if v.ext != nil {
 		panic(fmt.Sprintf("stat %v is in external mode", v.name))
 	}
+	v.add(val)
+	if v.parent != nil {
+		v.parent.add(val)
+	}
+}
+
+func (v *Val) add(val int) {
 	if v.hist {
 		v.histMu.Lock()
 		if v.histVal == nil {
 			v.histVal = gohistogram.NewHistogram(histogramBuckets)
 		}
 		v.histVal.Add(float64(val))
+		if v.bucketCounts == nil {
+			v.bucketCounts = make([]uint64, len(v.buckets)+1)
+		}
+		v.bucketCounts[sort.SearchFloat64s(v.buckets, float64(val))]++
+		v.histSum += float64(val)
+		v.histCount++
 		v.histMu.Unlock()
 		return
 	}
 	v.val.Add(uint64(val))
 }
 
+// With returns the part of the metric with the given values of the labels (see LabelNames).
+// Adding to the part also adds to the metric itself, so the web interface shows the total value,
+// while the OpenMetrics export contains the values of the individual parts.
+func (v *Val) With(values ...string) *Val {
+	if len(values) != len(v.labelNames) {
+		panic(fmt.Sprintf("stat %v has labels %q, but got values %q", v.name, v.labelNames, values))
+	}
+	key := strings.Join(values, "\x00")
+	v.childMu.Lock()
+	defer v.childMu.Unlock()
+	child := v.children[key]
+	if child == nil {
+		child = &Val{
+			name:        v.name,
+			desc:        v.desc,
+			fmt:         v.fmt,
+			rate:        v.rate,
+			hist:        v.hist,
+			buckets:     v.buckets,
+			labelValues: values,
+			parent:      v,
+		}
+		if v.children == nil {
+			v.children = make(map[string]*Val)
+		}
+		v.children[key] = child
+	}
+	return child
+}
+
 func (v *Val) Val() int {
 	if v.ext != nil {
 		return v.ext()
@@ -327,6 +410,7 @@ func (s *set) tick() {
 			} else {
 				ln.data = make([]float64, s.historySize)
 			}
+			s.restoreLine(v, ln)
 			graph.lines[v.name] = ln
 		}
 		if v.hist {
@@ -358,6 +442,16 @@ func (s *set) compress() {
 	half := s.historySize / 2
 	s.historyPos = half
 	s.historyScale *= 2
+	var restarts []int
+	for _, pos := range s.restarts {
+		if len(restarts) == 0 || restarts[len(restarts)-1] != pos/2 {
+			restarts = append(restarts, pos/2)
+		}
+	}
+	s.restarts = restarts
+	for _, sl := range s.restored {
+		sl.compress()
+	}
 	for _, graph := range s.graphs {
 		for _, line := range graph.lines {
 			for i := 0; i < half; i++ {
@@ -397,6 +491,8 @@ type UIGraph struct {
 type UIPoint struct {
 	X int
 	Y []float64
+	// Restart is set for the first point after a process restart.
+	Restart bool
 }
 
 func (s *set) RenderGraphs() []UIGraph {
@@ -425,6 +521,11 @@ func (s *set) RenderGraphs() []UIGraph {
 		for i := 0; i < s.historyPos; i++ {
 			g.Points[i].X = i * tick
 		}
+		for _, pos := range s.restarts {
+			if pos < s.historyPos {
+				g.Points[pos].Restart = true
+			}
+		}
 		for _, ln := range lines {
 			if ln.hist == nil {
 				g.Lines = append(g.Lines, ln.name+": "+ln.desc)
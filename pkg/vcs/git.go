@@ -486,6 +486,18 @@ func (git *gitRepo) bisectInconclusive(output []byte) ([]*Commit, error) {
 	return commits, nil
 }
 
+func (git *gitRepo) CherryPick(commits ...string) error {
+	if len(commits) == 0 {
+		return nil
+	}
+	if _, err := git.Run(append([]string{"cherry-pick", "--no-commit"}, commits...)...); err != nil {
+		git.Run("cherry-pick", "--abort")
+		git.Run("reset", "--hard")
+		return err
+	}
+	return nil
+}
+
 func (git *gitRepo) ReleaseTag(commit string) (string, error) {
 	tags, err := git.previousReleaseTags(commit, true, true, true)
 	if err != nil {
@@ -401,3 +401,49 @@ func TestBisect(t *testing.T) {
 		}
 	}
 }
+
+func TestCherryPick(t *testing.T) {
+	t.Parallel()
+	repoDir := t.TempDir()
+	repo := MakeTestRepo(t, repoDir)
+	file := filepath.Join(repoDir, "file")
+	commitFile := func(data string) string {
+		if err := os.WriteFile(file, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		repo.Git("add", "file")
+		return repo.CommitChange(data).Hash
+	}
+	repo.Git("checkout", "-b", "master")
+	base := repo.CommitChange("base").Hash
+	repo.Git("checkout", "-b", "series")
+	first := commitFile("first")
+	second := commitFile("second")
+	repo.Git("checkout", "-b", "other", base)
+	other := commitFile("other")
+	repo.Git("checkout", "master")
+
+	checkState := func(want string) {
+		t.Helper()
+		head, err := repo.repo.Commit(HEAD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if head.Hash != base {
+			t.Fatalf("HEAD moved to %v", head.Title)
+		}
+		data, _ := os.ReadFile(file)
+		if string(data) != want {
+			t.Fatalf("got file %q, want %q", data, want)
+		}
+	}
+	if err := repo.repo.CherryPick(first, second); err != nil {
+		t.Fatal(err)
+	}
+	checkState("second")
+	repo.Git("reset", "--hard")
+	if err := repo.repo.CherryPick(other, first); err == nil {
+		t.Fatalf("conflicting commits were applied")
+	}
+	checkState("")
+}
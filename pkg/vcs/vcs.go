@@ -104,6 +104,14 @@ type Bisecter interface {
 		kernelConfig []byte, backports []BackportCommit) (*BisectEnv, error)
 }
 
+// CherryPicker may be optionally implemented by Repo.
+type CherryPicker interface {
+	// CherryPick applies changes introduced by the commits on top of the checked out commit
+	// in the given order without creating new commits.
+	// If any of the commits does not apply cleanly, the working tree is restored and an error is returned.
+	CherryPick(commits ...string) error
+}
+
 type ConfigMinimizer interface {
 	Minimize(target *targets.Target, original, baseline []byte, types []crash.Type,
 		dt debugtracer.DebugTracer, pred func(test []byte) (BisectResult, error)) ([]byte, error)
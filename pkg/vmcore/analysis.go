@@ -0,0 +1,175 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package vmcore
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/google/syzkaller/pkg/symbolizer"
+)
+
+type Options struct {
+	// Symbols are text symbols of the kernel binary (see symbolizer.ReadTextSymbols).
+	// Stacks are scanned only if symbols are provided.
+	Symbols map[string][]symbolizer.Symbol
+	// ThreadSize is the kernel stack size.
+	// If not set, it's 16KB, or 32KB if the kernel is built with KASAN.
+	ThreadSize uint64
+}
+
+// Analysis is the information extracted from a crash dump.
+type Analysis struct {
+	OSRelease string
+	Tasks     []*Task
+	Dmesg     []byte
+	// Errors describe parts of the dump that could not be analyzed, the rest of the analysis is still valid.
+	Errors []string
+}
+
+type Frame struct {
+	PC     uint64
+	Func   string
+	Offset uint64
+	Size   uint64
+	// Reliable is set for the frame that corresponds to the PC register.
+	Reliable bool
+}
+
+func AnalyzeFile(file string, opts *Options) (*Analysis, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Analyze(f, opts)
+}
+
+// Analyze extracts the kernel log and the tasks that were running on CPUs from the crash dump.
+func Analyze(r io.ReaderAt, opts *Options) (*Analysis, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	d, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	res := &Analysis{
+		OSRelease: d.OSRelease(),
+		Tasks:     d.Tasks(),
+	}
+	res.Dmesg, err = d.Dmesg()
+	if err != nil {
+		res.Errors = append(res.Errors, fmt.Sprintf("failed to extract kernel log: %v", err))
+	}
+	if len(res.Tasks) == 0 {
+		res.Errors = append(res.Errors, "no NT_PRSTATUS notes in the dump")
+	}
+	if len(opts.Symbols) == 0 {
+		return res, nil
+	}
+	kaslr, _ := d.infoValue("KERNELOFFSET")
+	symtab := newSymtab(opts.Symbols, kaslr)
+	threadSize := opts.ThreadSize
+	if threadSize == 0 {
+		threadSize = 16 << 10
+		// KASAN doubles the stack size on all arches.
+		if _, ok := opts.Symbols["kasan_report"]; ok {
+			threadSize = 32 << 10
+		}
+	}
+	isText := func(pc uint64) bool {
+		_, ok := symtab.lookup(pc)
+		return ok
+	}
+	for _, task := range res.Tasks {
+		if frame, ok := symtab.lookup(task.PC); ok {
+			frame.Reliable = true
+			task.Frames = append(task.Frames, frame)
+		}
+		if task.SP == 0 {
+			continue
+		}
+		for _, pc := range d.scanStack(task.SP, threadSize, isText) {
+			frame, _ := symtab.lookup(pc)
+			task.Frames = append(task.Frames, frame)
+		}
+	}
+	return res, nil
+}
+
+// Report returns a human-readable description of the analysis.
+func (a *Analysis) Report() []byte {
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "kernel release: %v\n", a.OSRelease)
+	for _, err := range a.Errors {
+		fmt.Fprintf(buf, "error: %v\n", err)
+	}
+	for _, task := range a.Tasks {
+		fmt.Fprintf(buf, "\nCPU: %v PID: %v\n", task.CPU, task.PID)
+		if task.PC != 0 || task.SP != 0 {
+			fmt.Fprintf(buf, "PC: %016x SP: %016x\n", task.PC, task.SP)
+		}
+		if len(task.Frames) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "Call Trace:\n")
+		for _, frame := range task.Frames {
+			unreliable := "? "
+			if frame.Reliable {
+				unreliable = ""
+			}
+			fmt.Fprintf(buf, " %v%v+0x%x/0x%x\n", unreliable, frame.Func, frame.Offset, frame.Size)
+		}
+	}
+	if len(a.Dmesg) != 0 {
+		fmt.Fprintf(buf, "\nkernel log:\n%s", a.Dmesg)
+	}
+	return buf.Bytes()
+}
+
+type symtab struct {
+	syms  []symbol
+	kaslr uint64
+}
+
+type symbol struct {
+	name string
+	addr uint64
+	size uint64
+}
+
+func newSymtab(symbols map[string][]symbolizer.Symbol, kaslr uint64) *symtab {
+	t := &symtab{kaslr: kaslr}
+	for name, syms := range symbols {
+		for _, sym := range syms {
+			t.syms = append(t.syms, symbol{name, sym.Addr, uint64(sym.Size)})
+		}
+	}
+	sort.Slice(t.syms, func(i, j int) bool {
+		return t.syms[i].addr < t.syms[j].addr
+	})
+	return t
+}
+
+// lookup symbolizes the runtime address pc.
+func (t *symtab) lookup(pc uint64) (Frame, bool) {
+	addr := pc - t.kaslr
+	i := sort.Search(len(t.syms), func(i int) bool {
+		return t.syms[i].addr > addr
+	}) - 1
+	if i < 0 || addr-t.syms[i].addr >= t.syms[i].size {
+		return Frame{}, false
+	}
+	sym := t.syms[i]
+	return Frame{
+		PC:     pc,
+		Func:   sym.name,
+		Offset: addr - sym.addr,
+		Size:   sym.size,
+	}, true
+}
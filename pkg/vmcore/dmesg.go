@@ -0,0 +1,167 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package vmcore
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Dmesg returns the kernel log from the dump formatted the same way the kernel prints it on the console
+// (with CONFIG_PRINTK_TIME and CONFIG_PRINTK_CALLER), so that the output can be parsed by pkg/report.
+// If the log is partially corrupted, the records extracted so far are returned along with the error.
+func (d *Dump) Dmesg() ([]byte, error) {
+	if _, ok := d.info["SYMBOL(prb)"]; ok {
+		return d.dmesgLockless()
+	}
+	if _, ok := d.info["SYMBOL(log_buf)"]; ok {
+		return d.dmesgLegacy()
+	}
+	return nil, fmt.Errorf("VMCOREINFO does not describe the kernel log buffer")
+}
+
+// Descriptor states of the lockless printk ring buffer (kernel/printk/printk_ringbuffer.h).
+const (
+	descCommitted = 1
+	descFinalized = 2
+)
+
+// dmesgLockless extracts the log from the lockless printk ring buffer (v5.10+).
+// The algorithm follows makedumpfile's dump_lockless_dmesg.
+func (d *Dump) dmesgLockless() ([]byte, error) {
+	r := &memReader{d: d}
+	prb := r.ulong(r.info("SYMBOL(prb)"))
+	counter, _ := d.infoValue("OFFSET(atomic_long_t.counter)")
+	descRing := prb + r.info("OFFSET(printk_ringbuffer.desc_ring)")
+	countBits := r.u32(descRing + r.info("OFFSET(prb_desc_ring.count_bits)"))
+	descs := r.ulong(descRing + r.info("OFFSET(prb_desc_ring.descs)"))
+	infos := r.ulong(descRing + r.info("OFFSET(prb_desc_ring.infos)"))
+	headID := r.ulong(descRing + r.info("OFFSET(prb_desc_ring.head_id)") + counter)
+	tailID := r.ulong(descRing + r.info("OFFSET(prb_desc_ring.tail_id)") + counter)
+	dataRing := prb + r.info("OFFSET(printk_ringbuffer.text_data_ring)")
+	sizeBits := r.u32(dataRing + r.info("OFFSET(prb_data_ring.size_bits)"))
+	data := r.ulong(dataRing + r.info("OFFSET(prb_data_ring.data)"))
+	descSize := r.info("SIZE(prb_desc)")
+	stateOff := r.info("OFFSET(prb_desc.state_var)") + counter
+	lposOff := r.info("OFFSET(prb_desc.text_blk_lpos)")
+	beginOff := lposOff + r.info("OFFSET(prb_data_blk_lpos.begin)")
+	nextOff := lposOff + r.info("OFFSET(prb_data_blk_lpos.next)")
+	infoSize := r.info("SIZE(printk_info)")
+	tsOff := r.info("OFFSET(printk_info.ts_nsec)")
+	lenOff := r.info("OFFSET(printk_info.text_len)")
+	callerOff, hasCaller := d.infoValue("OFFSET(printk_info.caller_id)")
+	if r.err != nil {
+		return nil, r.err
+	}
+	if countBits >= 32 || sizeBits >= 48 {
+		return nil, fmt.Errorf("corrupted printk ring buffer: count_bits=%v size_bits=%v", countBits, sizeBits)
+	}
+	count := uint64(1) << countBits
+	dataSize := uint64(1) << sizeBits
+	flagsShift := d.ptrSize*8 - 2
+	idMask := uint64(1)<<flagsShift - 1
+	out := new(bytes.Buffer)
+	record := func(id uint64) {
+		desc := descs + id%count*descSize
+		stateVar := r.ulong(desc + stateOff)
+		state := stateVar >> flagsShift & 3
+		if stateVar&idMask != id || state != descCommitted && state != descFinalized {
+			return
+		}
+		begin, next := r.ulong(desc+beginOff), r.ulong(desc+nextOff)
+		if begin&1 != 0 {
+			// Data-less record (e.g. an empty line or a record whose data was lost).
+			return
+		}
+		var start, size uint64
+		switch {
+		case begin>>sizeBits == next>>sizeBits && begin < next:
+			start, size = begin&(dataSize-1), next-begin
+		case (begin+dataSize)>>sizeBits == next>>sizeBits:
+			// The data block wraps, it's stored at the beginning of the data ring.
+			start, size = 0, next&(dataSize-1)
+		default:
+			return
+		}
+		// The data block starts with the descriptor id.
+		if size < d.ptrSize {
+			return
+		}
+		info := infos + id%count*infoSize
+		size = min(size-d.ptrSize, uint64(r.u16(info+lenOff)))
+		text := r.bytes(data+start+d.ptrSize, size)
+		ts := r.u64(info + tsOff)
+		var caller uint32
+		if hasCaller {
+			caller = r.u32(info + callerOff)
+		}
+		if r.err == nil {
+			writeRecord(out, ts, hasCaller, caller, text)
+		}
+	}
+	for id, i := tailID, uint64(0); i < count && r.err == nil; id, i = (id+1)&idMask, i+1 {
+		record(id)
+		if id == headID {
+			break
+		}
+	}
+	return out.Bytes(), r.err
+}
+
+// dmesgLegacy extracts the log from the printk log buffer used before v5.10.
+func (d *Dump) dmesgLegacy() ([]byte, error) {
+	r := &memReader{d: d}
+	logBuf := r.ulong(r.info("SYMBOL(log_buf)"))
+	bufLen := uint64(r.u32(r.info("SYMBOL(log_buf_len)")))
+	first := uint64(r.u32(r.info("SYMBOL(log_first_idx)")))
+	next := uint64(r.u32(r.info("SYMBOL(log_next_idx)")))
+	hdrSize := r.info("SIZE(printk_log)")
+	tsOff := r.info("OFFSET(printk_log.ts_nsec)")
+	lenOff := r.info("OFFSET(printk_log.len)")
+	textLenOff := r.info("OFFSET(printk_log.text_len)")
+	callerOff, hasCaller := d.infoValue("OFFSET(printk_log.caller_id)")
+	if r.err != nil {
+		return nil, r.err
+	}
+	out := new(bytes.Buffer)
+	for idx, i := first, uint64(0); idx != next && i < bufLen && r.err == nil; i++ {
+		rec := logBuf + idx
+		recLen := uint64(0)
+		if idx+hdrSize <= bufLen {
+			recLen = uint64(r.u16(rec + lenOff))
+		}
+		if recLen == 0 {
+			// A zero length record marks the end of the buffer, the next record is at the beginning.
+			idx = 0
+			continue
+		}
+		text := r.bytes(rec+hdrSize, uint64(r.u16(rec+textLenOff)))
+		ts := r.u64(rec + tsOff)
+		var caller uint32
+		if hasCaller {
+			caller = r.u32(rec + callerOff)
+		}
+		if r.err == nil {
+			writeRecord(out, ts, hasCaller, caller, text)
+		}
+		idx += recLen
+	}
+	return out.Bytes(), r.err
+}
+
+func writeRecord(out *bytes.Buffer, ts uint64, hasCaller bool, caller uint32, text []byte) {
+	prefix := fmt.Sprintf("[%5d.%06d]", ts/1e9, ts%1e9/1e3)
+	if hasCaller {
+		// See printk_caller_id: the top bit is set for records printed in non-task context.
+		id := fmt.Sprintf("T%d", caller)
+		if caller&0x80000000 != 0 {
+			id = fmt.Sprintf("C%d", caller&^0x80000000)
+		}
+		prefix += fmt.Sprintf("[%6s]", id)
+	}
+	text = bytes.TrimSuffix(text, []byte{'\n'})
+	for _, line := range bytes.Split(text, []byte{'\n'}) {
+		fmt.Fprintf(out, "%s %s\n", prefix, line)
+	}
+}
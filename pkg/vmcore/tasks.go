@@ -0,0 +1,89 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package vmcore
+
+import (
+	"debug/elf"
+)
+
+// Task describes a task that was running on a CPU at the time of the crash.
+// Without kernel debug info it's not possible to walk the list of all tasks,
+// so only the tasks from the per-CPU NT_PRSTATUS notes are available.
+type Task struct {
+	CPU int
+	PID int
+	// PC and SP are zero if registers for the architecture are not supported.
+	PC uint64
+	SP uint64
+	// Frames are filled in by Analyze: the first frame is PC,
+	// the rest are possible return addresses found on the stack.
+	Frames []Frame
+}
+
+// regLayout describes positions of registers in the elf_prstatus.pr_reg array.
+type regLayout struct {
+	pc int
+	sp int
+}
+
+var regLayouts = map[elf.Machine]regLayout{
+	elf.EM_X86_64:  {pc: 16, sp: 19}, // struct user_regs_struct
+	elf.EM_AARCH64: {pc: 32, sp: 31}, // struct user_pt_regs
+}
+
+// Tasks returns the tasks that were running on CPUs at the time of the crash.
+func (d *Dump) Tasks() []*Task {
+	// struct elf_prstatus starts with struct elf_siginfo (3 ints), short pr_cursig (padded to 4 bytes)
+	// and 2 longs (pr_sigpend, pr_sighold). pr_pid is followed by 3 more pids and 4 struct timevals.
+	pidOff := 16 + 2*d.ptrSize
+	regsOff := pidOff + 16 + 8*d.ptrSize
+	var tasks []*Task
+	for cpu, desc := range d.prstatus {
+		if uint64(len(desc)) < pidOff+4 {
+			continue
+		}
+		task := &Task{
+			CPU: cpu,
+			PID: int(d.order.Uint32(desc[pidOff:])),
+		}
+		layout, ok := regLayouts[d.machine]
+		if ok && d.ptrSize == 8 && uint64(len(desc)) >= regsOff+uint64(max(layout.pc, layout.sp)+1)*8 {
+			task.PC = d.order.Uint64(desc[regsOff+uint64(layout.pc)*8:])
+			task.SP = d.order.Uint64(desc[regsOff+uint64(layout.sp)*8:])
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks
+}
+
+const pageSize = 4 << 10
+
+// scanStack returns all values on the stack between sp and the end of the stack (the stack is threadSize
+// bytes and is aligned to threadSize) that satisfy the isText predicate. This is similar to what
+// the kernel unwinder prints with "?" prefix, so some of the values are stale return addresses.
+func (d *Dump) scanStack(sp, threadSize uint64, isText func(uint64) bool) []uint64 {
+	sp = (sp + d.ptrSize - 1) &^ (d.ptrSize - 1)
+	end := (sp + threadSize) &^ (threadSize - 1)
+	var res []uint64
+	r := &memReader{d: d}
+	for addr := sp; addr < end; {
+		// Read page by page to get as much as possible if some stack pages are not in the dump.
+		size := min(end, (addr+pageSize)&^(pageSize-1)) - addr
+		buf := r.bytes(addr, size)
+		if r.err != nil {
+			break
+		}
+		for i := uint64(0); i+d.ptrSize <= size; i += d.ptrSize {
+			val := uint64(d.order.Uint32(buf[i:]))
+			if d.ptrSize == 8 {
+				val = d.order.Uint64(buf[i:])
+			}
+			if isText(val) {
+				res = append(res, val)
+			}
+		}
+		addr += size
+	}
+	return res
+}
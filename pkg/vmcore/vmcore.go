@@ -0,0 +1,313 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package vmcore extracts information from Linux kernel crash dumps
+// (/proc/vmcore saved in a kdump kernel, or ELF dumps produced by makedumpfile -E).
+// Only the information that can be obtained without kernel debug info is extracted:
+// the kernel log, the tasks running on CPUs at the time of the crash and their stacks.
+// The layout of the kernel data structures is taken from the VMCOREINFO note.
+package vmcore
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Dump is a parsed kernel crash dump.
+type Dump struct {
+	order    binary.ByteOrder
+	ptrSize  uint64
+	machine  elf.Machine
+	segments []*segment
+	info     map[string]string
+	prstatus [][]byte
+	// Page table walking state, used for addresses that are not part of any segment
+	// (e.g. vmalloc-ed task stacks).
+	pgd      uint64
+	pgShifts []uint
+	smeMask  uint64
+}
+
+type segment struct {
+	vaddr  uint64
+	paddr  uint64
+	filesz uint64
+	memsz  uint64
+	r      io.ReaderAt
+}
+
+const (
+	noteVmcoreinfo = "VMCOREINFO"
+	noteCore       = "CORE"
+)
+
+// Parse parses an ELF kernel crash dump.
+func Parse(r io.ReaderAt) (*Dump, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse vmcore: %w", err)
+	}
+	if f.Type != elf.ET_CORE {
+		return nil, fmt.Errorf("not a core file: %v", f.Type)
+	}
+	d := &Dump{
+		order:   f.ByteOrder,
+		ptrSize: 4,
+		machine: f.Machine,
+		info:    make(map[string]string),
+	}
+	if f.Class == elf.ELFCLASS64 {
+		d.ptrSize = 8
+	}
+	for _, prog := range f.Progs {
+		switch prog.Type {
+		case elf.PT_LOAD:
+			d.segments = append(d.segments, &segment{
+				vaddr:  prog.Vaddr,
+				paddr:  prog.Paddr,
+				filesz: prog.Filesz,
+				memsz:  max(prog.Memsz, prog.Filesz),
+				r:      prog,
+			})
+		case elf.PT_NOTE:
+			data := make([]byte, prog.Filesz)
+			if _, err := prog.ReadAt(data, 0); err != nil {
+				return nil, fmt.Errorf("failed to read notes: %w", err)
+			}
+			if err := d.parseNotes(data); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(d.info) == 0 {
+		return nil, fmt.Errorf("no VMCOREINFO note in the dump")
+	}
+	if err := d.setupPageTables(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Dump) parseNotes(data []byte) error {
+	align := func(v uint64) uint64 { return (v + 3) &^ 3 }
+	for len(data) != 0 {
+		if len(data) < 12 {
+			return fmt.Errorf("truncated note header")
+		}
+		nameSize := uint64(d.order.Uint32(data[0:]))
+		descSize := uint64(d.order.Uint32(data[4:]))
+		typ := elf.NType(d.order.Uint32(data[8:]))
+		data = data[12:]
+		if align(nameSize)+align(descSize) > uint64(len(data)) {
+			return fmt.Errorf("truncated note")
+		}
+		name := strings.TrimRight(string(data[:nameSize]), "\x00")
+		desc := data[align(nameSize) : align(nameSize)+descSize]
+		data = data[align(nameSize)+align(descSize):]
+		switch {
+		case name == noteVmcoreinfo:
+			d.parseVmcoreinfo(string(desc))
+		case name == noteCore && typ == elf.NT_PRSTATUS:
+			d.prstatus = append(d.prstatus, desc)
+		}
+	}
+	return nil
+}
+
+func (d *Dump) parseVmcoreinfo(info string) {
+	for _, line := range strings.Split(info, "\n") {
+		key, val, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if ok {
+			d.info[key] = val
+		}
+	}
+}
+
+// OSRelease returns the release of the crashed kernel (uname -r).
+func (d *Dump) OSRelease() string {
+	return d.info["OSRELEASE"]
+}
+
+// infoValue returns a numeric VMCOREINFO value, e.g. "SYMBOL(prb)" or "OFFSET(printk_info.seq)".
+func (d *Dump) infoValue(key string) (uint64, bool) {
+	str, ok := d.info[key]
+	if !ok {
+		return 0, false
+	}
+	// Symbol addresses and the kernel offset are printed in hex without the 0x prefix.
+	if strings.HasPrefix(key, "SYMBOL(") || key == "KERNELOFFSET" {
+		val, err := strconv.ParseUint(str, 16, 64)
+		return val, err == nil
+	}
+	val, err := strconv.ParseInt(str, 10, 64)
+	return uint64(val), err == nil
+}
+
+func (d *Dump) findSegment(addr uint64, virt bool) *segment {
+	for _, seg := range d.segments {
+		start := seg.paddr
+		if virt {
+			start = seg.vaddr
+		}
+		if addr >= start && addr-start < seg.memsz {
+			return seg
+		}
+	}
+	return nil
+}
+
+// read reads kernel memory at the virtual address addr.
+func (d *Dump) read(addr uint64, buf []byte) error {
+	for len(buf) != 0 {
+		n, err := d.readChunk(addr, buf)
+		if err != nil {
+			return err
+		}
+		addr += n
+		buf = buf[n:]
+	}
+	return nil
+}
+
+func (d *Dump) readChunk(addr uint64, buf []byte) (uint64, error) {
+	if seg := d.findSegment(addr, true); seg != nil {
+		return seg.read(addr-seg.vaddr, buf)
+	}
+	if d.pgd == 0 {
+		return 0, fmt.Errorf("address 0x%x is not present in the dump", addr)
+	}
+	phys, avail, err := d.translate(addr)
+	if err != nil {
+		return 0, err
+	}
+	return d.readPhys(phys, buf[:min(uint64(len(buf)), avail)])
+}
+
+func (d *Dump) readPhys(addr uint64, buf []byte) (uint64, error) {
+	seg := d.findSegment(addr, false)
+	if seg == nil {
+		return 0, fmt.Errorf("physical address 0x%x is not present in the dump", addr)
+	}
+	return seg.read(addr-seg.paddr, buf)
+}
+
+func (seg *segment) read(off uint64, buf []byte) (uint64, error) {
+	n := min(uint64(len(buf)), seg.memsz-off)
+	buf = buf[:n]
+	if off < seg.filesz {
+		// Read only what's present in the file, the rest of the segment is zeros.
+		m := min(n, seg.filesz-off)
+		if _, err := seg.r.ReadAt(buf[:m], int64(off)); err != nil {
+			return 0, err
+		}
+		buf = buf[m:]
+	}
+	clear(buf)
+	return n, nil
+}
+
+const (
+	pagePresent  = 1 << 0
+	pageHuge     = 1 << 7
+	pageAddrMask = 0x000ffffffffff000
+)
+
+// setupPageTables prepares translation of addresses that are not covered by the dump segments.
+// Only x86-64 page tables are supported at the moment.
+func (d *Dump) setupPageTables() error {
+	pgt, ok := d.infoValue("SYMBOL(init_top_pgt)")
+	if d.machine != elf.EM_X86_64 || !ok {
+		return nil
+	}
+	seg := d.findSegment(pgt, true)
+	if seg == nil {
+		return fmt.Errorf("init_top_pgt 0x%x is not present in the dump", pgt)
+	}
+	d.pgd = seg.paddr + pgt - seg.vaddr
+	d.pgShifts = []uint{39, 30, 21, 12}
+	if l5, _ := d.infoValue("NUMBER(pgtable_l5_enabled)"); l5 != 0 {
+		d.pgShifts = []uint{48, 39, 30, 21, 12}
+	}
+	d.smeMask, _ = d.infoValue("NUMBER(sme_mask)")
+	return nil
+}
+
+// translate translates the virtual address to a physical address by walking the page tables.
+// It also returns the number of bytes till the end of the page.
+func (d *Dump) translate(addr uint64) (uint64, uint64, error) {
+	table := d.pgd
+	for _, shift := range d.pgShifts {
+		var buf [8]byte
+		if _, err := d.readPhys(table+(addr>>shift&511)*8, buf[:]); err != nil {
+			return 0, 0, err
+		}
+		entry := d.order.Uint64(buf[:]) &^ d.smeMask
+		if entry&pagePresent == 0 {
+			return 0, 0, fmt.Errorf("address 0x%x is not mapped", addr)
+		}
+		if shift == 12 || (shift <= 30 && entry&pageHuge != 0) {
+			size := uint64(1) << shift
+			phys := entry&pageAddrMask&^(size-1) | addr&(size-1)
+			return phys, size - addr&(size-1), nil
+		}
+		table = entry & pageAddrMask
+	}
+	panic("unreachable")
+}
+
+// memReader reads kernel memory and VMCOREINFO values and remembers the first error,
+// so that a sequence of reads needs to be checked for errors only once.
+type memReader struct {
+	d   *Dump
+	err error
+}
+
+func (r *memReader) info(key string) uint64 {
+	val, ok := r.d.infoValue(key)
+	if !ok && r.err == nil {
+		r.err = fmt.Errorf("VMCOREINFO does not contain %v", key)
+	}
+	return val
+}
+
+func (r *memReader) bytes(addr, size uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+	buf := make([]byte, size)
+	r.err = r.d.read(addr, buf)
+	return buf
+}
+
+func (r *memReader) u16(addr uint64) uint16 {
+	if buf := r.bytes(addr, 2); r.err == nil {
+		return r.d.order.Uint16(buf)
+	}
+	return 0
+}
+
+func (r *memReader) u32(addr uint64) uint32 {
+	if buf := r.bytes(addr, 4); r.err == nil {
+		return r.d.order.Uint32(buf)
+	}
+	return 0
+}
+
+func (r *memReader) u64(addr uint64) uint64 {
+	if buf := r.bytes(addr, 8); r.err == nil {
+		return r.d.order.Uint64(buf)
+	}
+	return 0
+}
+
+// ulong reads a C long (or a pointer).
+func (r *memReader) ulong(addr uint64) uint64 {
+	if r.d.ptrSize == 4 {
+		return uint64(r.u32(addr))
+	}
+	return r.u64(addr)
+}
@@ -0,0 +1,311 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package vmcore
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/symbolizer"
+	"github.com/stretchr/testify/assert"
+)
+
+// testDump builds a synthetic x86-64 ELF crash dump.
+type testDump struct {
+	info     []string
+	prstatus [][]byte
+	segments []*testSegment
+}
+
+type testSegment struct {
+	vaddr uint64
+	paddr uint64
+	data  []byte
+}
+
+func (td *testDump) segment(vaddr, paddr uint64, size int) *testSegment {
+	seg := &testSegment{vaddr, paddr, make([]byte, size)}
+	td.segments = append(td.segments, seg)
+	return seg
+}
+
+func (seg *testSegment) put(addr uint64, vals ...any) {
+	buf := new(bytes.Buffer)
+	for _, val := range vals {
+		if err := binary.Write(buf, binary.LittleEndian, val); err != nil {
+			panic(err)
+		}
+	}
+	copy(seg.data[addr-seg.vaddr:], buf.Bytes())
+}
+
+func (td *testDump) build() []byte {
+	var notes []byte
+	addNote := func(name string, typ elf.NType, desc []byte) {
+		pad := func(data []byte) []byte {
+			return append(data, make([]byte, (4-len(data)%4)%4)...)
+		}
+		hdr := make([]byte, 12)
+		binary.LittleEndian.PutUint32(hdr[0:], uint32(len(name)+1))
+		binary.LittleEndian.PutUint32(hdr[4:], uint32(len(desc)))
+		binary.LittleEndian.PutUint32(hdr[8:], uint32(typ))
+		notes = append(notes, hdr...)
+		notes = append(notes, pad(append([]byte(name), 0))...)
+		notes = append(notes, pad(desc)...)
+	}
+	for _, desc := range td.prstatus {
+		addNote(noteCore, elf.NT_PRSTATUS, desc)
+	}
+	addNote(noteVmcoreinfo, 0, []byte(strings.Join(td.info, "\n")+"\n"))
+
+	const ehdrSize, phdrSize = 64, 56
+	progs := []elf.Prog64{{
+		Type:   uint32(elf.PT_NOTE),
+		Off:    uint64(ehdrSize + phdrSize*(len(td.segments)+1)),
+		Filesz: uint64(len(notes)),
+	}}
+	data := notes
+	for _, seg := range td.segments {
+		off := progs[0].Off + uint64(len(data))
+		progs = append(progs, elf.Prog64{
+			Type:   uint32(elf.PT_LOAD),
+			Off:    off,
+			Vaddr:  seg.vaddr,
+			Paddr:  seg.paddr,
+			Filesz: uint64(len(seg.data)),
+			Memsz:  uint64(len(seg.data)),
+		})
+		data = append(data, seg.data...)
+	}
+	hdr := elf.Header64{
+		Type:      uint16(elf.ET_CORE),
+		Machine:   uint16(elf.EM_X86_64),
+		Version:   uint32(elf.EV_CURRENT),
+		Phoff:     ehdrSize,
+		Ehsize:    ehdrSize,
+		Phentsize: phdrSize,
+		Phnum:     uint16(len(progs)),
+	}
+	copy(hdr.Ident[:], elf.ELFMAG)
+	hdr.Ident[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	hdr.Ident[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	hdr.Ident[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	buf := new(bytes.Buffer)
+	binary.Write(buf, binary.LittleEndian, hdr)
+	binary.Write(buf, binary.LittleEndian, progs)
+	buf.Write(data)
+	return buf.Bytes()
+}
+
+const directMap uint64 = 0xffff888000000000
+
+func TestDmesgLockless(t *testing.T) {
+	td := &testDump{
+		info: []string{
+			"OSRELEASE=6.10.0",
+			fmt.Sprintf("SYMBOL(prb)=%x", directMap),
+			"OFFSET(atomic_long_t.counter)=0",
+			"OFFSET(printk_ringbuffer.desc_ring)=0",
+			"OFFSET(printk_ringbuffer.text_data_ring)=64",
+			"OFFSET(prb_desc_ring.count_bits)=0",
+			"OFFSET(prb_desc_ring.descs)=8",
+			"OFFSET(prb_desc_ring.infos)=16",
+			"OFFSET(prb_desc_ring.head_id)=24",
+			"OFFSET(prb_desc_ring.tail_id)=32",
+			"OFFSET(prb_data_ring.size_bits)=0",
+			"OFFSET(prb_data_ring.data)=8",
+			"SIZE(prb_desc)=24",
+			"OFFSET(prb_desc.state_var)=0",
+			"OFFSET(prb_desc.text_blk_lpos)=8",
+			"OFFSET(prb_data_blk_lpos.begin)=0",
+			"OFFSET(prb_data_blk_lpos.next)=8",
+			"SIZE(printk_info)=32",
+			"OFFSET(printk_info.seq)=0",
+			"OFFSET(printk_info.ts_nsec)=8",
+			"OFFSET(printk_info.text_len)=16",
+			"OFFSET(printk_info.caller_id)=20",
+		},
+	}
+	const (
+		countBits = 2
+		sizeBits  = 7
+		prbAddr   = directMap + 0x100
+		descs     = directMap + 0x200
+		infos     = directMap + 0x300
+		data      = directMap + 0x400
+	)
+	seg := td.segment(directMap, 0, 0x1000)
+	seg.put(directMap, uint64(prbAddr))
+	seg.put(prbAddr, uint32(countBits), uint32(0), uint64(descs), uint64(infos))
+	seg.put(prbAddr+64, uint32(sizeBits), uint32(0), uint64(data))
+	// Emulate what the kernel does when it adds records, the ring wraps several times.
+	var lpos, id uint64
+	add := func(text string, state, ts uint64, caller uint32) {
+		size := (8 + uint64(len(text)) + 7) &^ 7
+		begin, next := lpos, lpos+size
+		if begin>>sizeBits != (next-1)>>sizeBits {
+			next = (begin>>sizeBits+1)<<sizeBits + size
+		}
+		seg.put(data+(next-size)%(1<<sizeBits), id, []byte(text))
+		desc := descs + id%(1<<countBits)*24
+		seg.put(desc, state<<62|id, begin, next)
+		info := infos + id%(1<<countBits)*32
+		seg.put(info, id, ts, uint16(len(text)), uint16(0), caller)
+		lpos = next
+		id++
+	}
+	add("overwritten 1", descFinalized, 1000, 1)
+	add("overwritten 2", descFinalized, 2000, 1)
+	add("first line", descFinalized, 1234567891, 1)
+	add("second\nmultiline", descCommitted, 1234567892, 0x80000002)
+	add("third", descFinalized, 20000000000, 123)
+	add("reserved", 0, 20000000001, 123)
+	seg.put(prbAddr+24, id-1, id-4)
+
+	d, err := Parse(bytes.NewReader(td.build()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "6.10.0", d.OSRelease())
+	dmesg, err := d.Dmesg()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `[    1.234567][    T1] first line
+[    1.234567][    C2] second
+[    1.234567][    C2] multiline
+[   20.000000][  T123] third
+`, string(dmesg))
+}
+
+func TestDmesgLegacy(t *testing.T) {
+	const (
+		logBuf    = directMap + 0x100
+		bufLen    = 0x60
+		bufPtr    = directMap + 0x10
+		lenSym    = directMap + 0x18
+		firstSym  = directMap + 0x1c
+		nextSym   = directMap + 0x20
+		hdrSize   = 16
+		firstRec  = 0x38
+		secondRec = 0x0
+		nextRec   = 0x18
+	)
+	td := &testDump{
+		info: []string{
+			fmt.Sprintf("SYMBOL(log_buf)=%x", bufPtr),
+			fmt.Sprintf("SYMBOL(log_buf_len)=%x", lenSym),
+			fmt.Sprintf("SYMBOL(log_first_idx)=%x", firstSym),
+			fmt.Sprintf("SYMBOL(log_next_idx)=%x", nextSym),
+			"SIZE(printk_log)=16",
+			"OFFSET(printk_log.ts_nsec)=0",
+			"OFFSET(printk_log.len)=8",
+			"OFFSET(printk_log.text_len)=10",
+		},
+	}
+	seg := td.segment(directMap, 0, 0x1000)
+	seg.put(bufPtr, uint64(logBuf))
+	seg.put(lenSym, uint32(bufLen), uint32(firstRec), uint32(nextRec))
+	seg.put(logBuf+firstRec, uint64(5e9), uint16(0x18), uint16(5), uint32(0), []byte("first"))
+	// Wrap marker.
+	seg.put(logBuf+firstRec+0x18, uint64(0), uint16(0))
+	seg.put(logBuf+secondRec, uint64(6e9), uint16(0x18), uint16(6), uint32(0), []byte("second"))
+
+	d, err := Parse(bytes.NewReader(td.build()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dmesg, err := d.Dmesg()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "[    5.000000] first\n[    6.000000] second\n", string(dmesg))
+}
+
+func TestAnalyze(t *testing.T) {
+	const (
+		kaslr            = 0x200000
+		pgd              = 0x1000
+		stackPage uint64 = 0xffffc9000000b000
+		stackPhys        = 0x8000
+		sp               = stackPage + 0xf00
+		foo              = 0xffffffff81000100
+		bar              = 0xffffffff81000200
+	)
+	td := &testDump{
+		info: []string{
+			"OSRELEASE=6.10.0",
+			fmt.Sprintf("KERNELOFFSET=%x", kaslr),
+			fmt.Sprintf("SYMBOL(init_top_pgt)=%x", directMap+pgd),
+			"NUMBER(pgtable_l5_enabled)=0",
+		},
+	}
+	mem := td.segment(directMap, 0, 0x10000)
+	// Map the stack page with 4-level page tables: pgd -> pud -> pmd -> pte.
+	table := uint64(pgd)
+	for i, shift := range []uint{39, 30, 21, 12} {
+		next := uint64(pgd + 0x1000*(i+1))
+		if shift == 12 {
+			next = stackPhys
+		}
+		mem.put(directMap+table+(stackPage>>shift&511)*8, next|pagePresent)
+		table = next
+	}
+	mem.put(directMap+stackPhys+0xf00,
+		uint64(0x1234), uint64(foo+kaslr+0x23), uint64(directMap), uint64(bar+kaslr+0x10))
+	prstatus := func(pid uint32, pc, sp uint64) []byte {
+		desc := make([]byte, 112+27*8)
+		binary.LittleEndian.PutUint32(desc[32:], pid)
+		binary.LittleEndian.PutUint64(desc[112+16*8:], pc)
+		binary.LittleEndian.PutUint64(desc[112+19*8:], sp)
+		return desc
+	}
+	td.prstatus = [][]byte{
+		prstatus(42, bar+kaslr+0x15, sp),
+		// The stack of the second CPU is not present in the dump.
+		prstatus(0, foo+kaslr, 0xffffc90000100000),
+	}
+
+	res, err := Analyze(bytes.NewReader(td.build()), &Options{
+		Symbols: map[string][]symbolizer.Symbol{
+			"foo": {{Addr: foo, Size: 0x40}},
+			"bar": {{Addr: bar, Size: 0x100}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `kernel release: 6.10.0
+error: failed to extract kernel log: VMCOREINFO does not describe the kernel log buffer
+
+CPU: 0 PID: 42
+PC: ffffffff81200215 SP: ffffc9000000bf00
+Call Trace:
+ bar+0x15/0x100
+ ? foo+0x23/0x40
+ ? bar+0x10/0x100
+
+CPU: 1 PID: 0
+PC: ffffffff81200100 SP: ffffc90000100000
+Call Trace:
+ foo+0x0/0x40
+`, string(res.Report()))
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse(bytes.NewReader([]byte("not an elf file")))
+	assert.Error(t, err)
+	td := &testDump{info: []string{"OSRELEASE=6.10.0"}}
+	_, err = Parse(bytes.NewReader(td.build()))
+	assert.NoError(t, err)
+	data := td.build()
+	// Replace the VMCOREINFO note name.
+	data = bytes.Replace(data, []byte(noteVmcoreinfo), []byte("VMCOREINFX"), 1)
+	_, err = Parse(bytes.NewReader(data))
+	assert.ErrorContains(t, err, "no VMCOREINFO")
+}
@@ -0,0 +1,164 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// ArgExplanation says if a call argument is essential for a program property (e.g. triggering a crash).
+type ArgExplanation struct {
+	Call int
+	// Path of the argument within the call, e.g. "arg.flags" or "iov[1].len".
+	Path      string
+	Essential bool
+}
+
+// Explain determines which arguments of the program calls are essential for the property checked by pred.
+// Each argument is in turn replaced with the default value (or with a random value if it already
+// has the default value), and the argument is considered essential if pred does not hold for the changed program.
+// If pred returns an error, Explain stops and returns the explanations collected so far along with the error.
+func Explain(p0 *Prog, rs rand.Source, pred func(*Prog) (bool, error)) ([]ArgExplanation, error) {
+	r := newRand(p0.Target, rs)
+	var ret []ArgExplanation
+	for ci, c0 := range p0.Calls {
+		if c0.Meta.Attrs.NoMinimize {
+			continue
+		}
+		for ai, arg0 := range explainArgs(c0) {
+			p := p0.Clone()
+			c := p.Calls[ci]
+			if !r.neutralizeArg(p, c, explainArgs(c)[ai].arg) {
+				continue
+			}
+			p.Target.assignSizesCall(c)
+			p.sanitizeFix()
+			p.debugValidate()
+			ok, err := pred(p)
+			if err != nil {
+				return ret, err
+			}
+			ret = append(ret, ArgExplanation{
+				Call:      ci,
+				Path:      arg0.path,
+				Essential: !ok,
+			})
+		}
+	}
+	return ret, nil
+}
+
+type explainArg struct {
+	arg  Arg
+	path string
+}
+
+// explainArgs returns leaf arguments that can be changed without changing the program structure.
+// The order is deterministic, so it's the same for a program and its clone.
+func explainArgs(c *Call) []explainArg {
+	var ret []explainArg
+	var walk func(arg Arg, path string)
+	walk = func(arg Arg, path string) {
+		switch a := arg.(type) {
+		case *GroupArg:
+			switch typ := a.Type().(type) {
+			case *StructType:
+				for i, inner := range a.Inner {
+					if !IsPad(inner.Type()) {
+						walk(inner, path+"."+typ.Fields[i].Name)
+					}
+				}
+			case *ArrayType:
+				for i, inner := range a.Inner {
+					walk(inner, fmt.Sprintf("%v[%v]", path, i))
+				}
+			}
+		case *UnionArg:
+			walk(a.Option, path+"."+a.Type().(*UnionType).Fields[a.Index].Name)
+		case *PointerArg:
+			if a.Res != nil {
+				walk(a.Res, path)
+			}
+		case *ConstArg:
+			switch a.Type().(type) {
+			case *IntType, *FlagsType, *ProcType:
+				if a.Dir() != DirOut {
+					ret = append(ret, explainArg{arg, path})
+				}
+			}
+		case *DataArg:
+			if a.Dir() != DirOut && !a.Type().(*BufferType).IsCompressed() && len(a.Data()) != 0 {
+				ret = append(ret, explainArg{arg, path})
+			}
+		case *ResultArg:
+			if a.Res != nil {
+				ret = append(ret, explainArg{arg, path})
+			}
+		}
+	}
+	for i, arg := range c.Args {
+		walk(arg, c.Meta.Args[i].Name)
+	}
+	return ret
+}
+
+func (r *randGen) neutralizeArg(p *Prog, c *Call, arg Arg) bool {
+	switch a := arg.(type) {
+	case *ConstArg:
+		val := a.Type().DefaultArg(a.Dir()).(*ConstArg).Val
+		if val == a.Val {
+			arg1, _ := r.generateArg(analyze(nil, nil, p, c), a.Type(), a.Dir())
+			val = arg1.(*ConstArg).Val
+		}
+		if val == a.Val {
+			return false
+		}
+		a.Val = val
+	case *DataArg:
+		data := make([]byte, len(a.Data()))
+		if bytes.Equal(data, a.Data()) {
+			r.Read(data)
+		}
+		a.SetData(data)
+	case *ResultArg:
+		delete(a.Res.uses, a)
+		a.Res, a.Val = nil, a.Type().(*ResourceType).Default()
+	default:
+		return false
+	}
+	return true
+}
+
+// SerializeExplained serializes the program with comments before each call
+// that list its essential and irrelevant arguments.
+func (p *Prog) SerializeExplained(explanation []ArgExplanation) []byte {
+	essential := make(map[int][]string)
+	irrelevant := make(map[int][]string)
+	for _, expl := range explanation {
+		if expl.Essential {
+			essential[expl.Call] = append(essential[expl.Call], expl.Path)
+		} else {
+			irrelevant[expl.Call] = append(irrelevant[expl.Call], expl.Path)
+		}
+	}
+	p.debugValidate()
+	ctx := &serializer{
+		target: p.Target,
+		buf:    new(bytes.Buffer),
+		vars:   make(map[*ResultArg]int),
+	}
+	for i, c := range p.Calls {
+		if paths := essential[i]; len(paths) != 0 {
+			ctx.printf("# essential: %v\n", strings.Join(paths, ", "))
+		}
+		if paths := irrelevant[i]; len(paths) != 0 {
+			ctx.printf("# irrelevant: %v\n", strings.Join(paths, ", "))
+		}
+		ctx.call(c)
+	}
+	return ctx.buf.Bytes()
+}
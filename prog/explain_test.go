@@ -0,0 +1,70 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package prog
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExplain(t *testing.T) {
+	target, rs, _ := initRandomTargetTest(t, "test", "64")
+	p, err := target.Deserialize([]byte(`r0 = test$res0()
+test$res1(r0)
+test$int(0x1, 0x2, 0x0, 0x4, 0x5)
+test$struct(&(0x7f0000000000)={0x10, {0x20}})
+test$blob0(&(0x7f0000000000)="0102")
+`), Strict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The "crash" needs the resource to be passed, test$int's a0, a1 and a3, syz_struct1.f0 and the blob.
+	crashes := func(p *Prog) bool {
+		text := string(p.Serialize())
+		return strings.Contains(text, "test$res1(r0)") &&
+			regexp.MustCompile(`test\$int\(0x1, 0x2, 0x[0-9a-f]+, 0x4,`).MatchString(text) &&
+			strings.Contains(text, "{0x20}}") &&
+			strings.Contains(text, `"0102"`)
+	}
+	expl, err := Explain(p, rs, func(p1 *Prog) (bool, error) {
+		if err := p1.validate(); err != nil {
+			t.Fatal(err)
+		}
+		return crashes(p1), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `# essential: a0
+test$res1(r0)
+# essential: a0, a1, a3
+# irrelevant: a2, a4
+test$int(0x1, 0x2, 0x0, 0x4, 0x5)
+# essential: a0.f1.f0
+# irrelevant: a0.f0
+test$struct(&(0x7f0000000000)={0x10, {0x20}})
+# essential: a
+test$blob0(&(0x7f0000000000)="0102")
+`
+	got := string(p.SerializeExplained(expl))
+	if diff := cmp.Diff("r0 = test$res0()\n"+want, got); diff != "" {
+		t.Fatal(diff)
+	}
+	// The annotated program can be parsed back.
+	if _, err := target.Deserialize([]byte(got), Strict); err != nil {
+		t.Fatal(err)
+	}
+
+	stop := errors.New("stop")
+	expl, err = Explain(p, rs, func(p1 *Prog) (bool, error) {
+		return false, stop
+	})
+	if err != stop || len(expl) != 0 {
+		t.Fatalf("got %v/%v, want no explanations and the pred error", expl, err)
+	}
+}
@@ -327,7 +327,10 @@ func (jp *JobProcessor) processJob(job *Job) {
 	req := job.req
 	jp.Logf(0, "starting job %v type %v for manager %v on %v/%v",
 		req.ID, req.Type, req.Manager, req.KernelRepo, req.KernelBranch)
+	start := time.Now()
 	resp := jp.process(job)
+	statJobs.With(jobTypeLabel(req.Type), resultLabel(len(resp.Error) != 0)).Add(1)
+	statJobTime.With(jobTypeLabel(req.Type)).Add(int(time.Since(start).Seconds()))
 	jp.Logf(0, "done job %v: commit %v, crash %q, error: %s",
 		resp.ID, resp.Build.KernelCommit, resp.CrashTitle, resp.Error)
 	select {
@@ -523,6 +526,13 @@ func (jp *JobProcessor) bisect(job *Job, mgrcfg *mgrconfig.Config) error {
 		}
 		return err
 	}
+	resp.Confidence = res.Confidence
+	if res.NonMonotonic {
+		resp.Flags |= dashapi.BisectResultNonMonotonic
+	}
+	if len(res.Titles) > 1 {
+		resp.Flags |= dashapi.BisectResultTitleChanged
+	}
 	for _, com := range res.Commits {
 		resp.Commits = append(resp.Commits, dashapi.Commit{
 			Hash:       com.Hash,
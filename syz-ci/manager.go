@@ -290,7 +290,9 @@ func (mgr *Manager) pollAndBuild(ctx context.Context, lastCommit string, latestI
 			select {
 			case <-buildSem.WaitC():
 				log.Logf(0, "%v: building kernel...", mgr.name)
-				if err := mgr.build(commit); err != nil {
+				err := mgr.build(commit)
+				statBuilds.With(mgr.name, resultLabel(err != nil)).Add(1)
+				if err != nil {
 					log.Logf(0, "%v: %v", mgr.name, err)
 				} else {
 					log.Logf(0, "%v: build successful", mgr.name)
@@ -482,6 +484,7 @@ func (mgr *Manager) restartManager() {
 	}
 	mgr.cmd = NewManagerCmd(mgr.name, logFile, benchFile, mgr.Errorf, bin, args...)
 	mgr.lastRestarted = time.Now()
+	statManagerStarts.With(mgr.name).Add(1)
 }
 
 func (mgr *Manager) testImage(imageDir string, info *BuildInfo) error {
@@ -0,0 +1,41 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/stat"
+)
+
+// The metrics are exported at /metrics.
+var (
+	statBuilds = stat.New("ci kernel builds", "Number of kernel builds",
+		stat.Rate{}, stat.LabelNames{"manager", "result"})
+	statManagerStarts = stat.New("ci manager starts", "Number of syz-manager starts",
+		stat.Rate{}, stat.LabelNames{"manager"})
+	statJobs = stat.New("ci jobs", "Number of processed dashboard jobs",
+		stat.Rate{}, stat.LabelNames{"type", "result"})
+	statJobTime = stat.New("ci job time", "Dashboard job processing time (sec)",
+		stat.Distribution{}, stat.LabelNames{"type"})
+)
+
+func jobTypeLabel(typ dashapi.JobType) string {
+	switch typ {
+	case dashapi.JobTestPatch:
+		return "test_patch"
+	case dashapi.JobBisectCause:
+		return "bisect_cause"
+	case dashapi.JobBisectFix:
+		return "bisect_fix"
+	default:
+		return "unknown"
+	}
+}
+
+func resultLabel(failed bool) string {
+	if failed {
+		return "error"
+	}
+	return "ok"
+}
@@ -71,6 +71,7 @@ import (
 	"github.com/google/syzkaller/pkg/log"
 	"github.com/google/syzkaller/pkg/mgrconfig"
 	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/stat"
 	"github.com/google/syzkaller/pkg/vcs"
 )
 
@@ -378,6 +379,7 @@ loop:
 }
 
 func serveHTTP(cfg *Config) {
+	http.Handle("/metrics", stat.Handler())
 	ln, err := net.Listen("tcp4", cfg.HTTP)
 	if err != nil {
 		log.Fatalf("failed to listen on %v: %v", cfg.HTTP, err)
@@ -274,7 +274,7 @@ func reportFinding(ctx context.Context, client *api.Client, bug *manager.UniqueB
 	}
 	if repro := bug.Repro; repro != nil {
 		if repro.Prog != nil {
-			finding.SyzRepro = repro.Prog.Serialize()
+			finding.SyzRepro = repro.Serialize()
 			finding.SyzReproOpts = repro.Opts.Serialize()
 		}
 		if repro.CRepro {
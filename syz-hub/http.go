@@ -12,10 +12,12 @@ import (
 	"strings"
 
 	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/stat"
 )
 
 func (hub *Hub) initHTTP(addr string) {
 	http.HandleFunc("/", hub.httpSummary)
+	http.Handle("/metrics", stat.Handler())
 
 	ln, err := net.Listen("tcp4", addr)
 	if err != nil {
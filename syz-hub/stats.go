@@ -0,0 +1,42 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"github.com/google/syzkaller/pkg/stat"
+)
+
+type Stats struct {
+	statSyncs      *stat.Val
+	statRecvInputs *stat.Val
+	statSentInputs *stat.Val
+	statRecvRepros *stat.Val
+	statSentRepros *stat.Val
+}
+
+func (hub *Hub) initStats() {
+	locked := func(f func() int) func() int {
+		return func() int {
+			hub.mu.Lock()
+			defer hub.mu.Unlock()
+			return f()
+		}
+	}
+	stat.New("hub corpus", "Number of programs in the hub corpus", stat.Simple,
+		locked(func() int { return len(hub.st.Corpus.Records) }))
+	stat.New("hub repros", "Number of reproducers in the hub", stat.Simple,
+		locked(func() int { return len(hub.st.Repros.Records) }))
+	stat.New("hub managers", "Number of connected managers", stat.Simple,
+		locked(func() int { return len(hub.st.Managers) }))
+	hub.statSyncs = stat.New("hub syncs", "Number of syncs with managers",
+		stat.Rate{}, stat.LabelNames{"manager"})
+	hub.statRecvInputs = stat.New("hub recv inputs", "Number of programs received from managers",
+		stat.Rate{}, stat.LabelNames{"manager"})
+	hub.statSentInputs = stat.New("hub sent inputs", "Number of programs sent to managers",
+		stat.Rate{}, stat.LabelNames{"manager"})
+	hub.statRecvRepros = stat.New("hub recv repros", "Number of reproducers received from managers",
+		stat.Rate{}, stat.LabelNames{"manager"})
+	hub.statSentRepros = stat.New("hub sent repros", "Number of reproducers sent to managers",
+		stat.Rate{}, stat.LabelNames{"manager"})
+}
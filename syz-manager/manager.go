@@ -17,6 +17,8 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -29,6 +31,7 @@ import (
 	"github.com/google/syzkaller/pkg/fuzzer"
 	"github.com/google/syzkaller/pkg/fuzzer/queue"
 	"github.com/google/syzkaller/pkg/gce"
+	"github.com/google/syzkaller/pkg/hash"
 	"github.com/google/syzkaller/pkg/ifaceprobe"
 	"github.com/google/syzkaller/pkg/image"
 	"github.com/google/syzkaller/pkg/log"
@@ -42,11 +45,13 @@ import (
 	"github.com/google/syzkaller/pkg/runtest"
 	"github.com/google/syzkaller/pkg/signal"
 	"github.com/google/syzkaller/pkg/stat"
+	"github.com/google/syzkaller/pkg/symbolizer"
 	"github.com/google/syzkaller/pkg/vminfo"
 	"github.com/google/syzkaller/prog"
 	"github.com/google/syzkaller/sys/targets"
 	"github.com/google/syzkaller/vm"
 	"github.com/google/syzkaller/vm/dispatcher"
+	"github.com/google/syzkaller/vm/vmimpl"
 )
 
 var (
@@ -75,11 +80,13 @@ type Manager struct {
 	corpusPreload   chan []fuzzer.Candidate
 	firstConnect    atomic.Int64 // unix time, or 0 if not connected
 	crashTypes      map[string]bool
+	vmcoreTitles    map[string]bool // crash titles for which a crash dump was uploaded
 	enabledFeatures flatrpc.Feature
 	checkDone       atomic.Bool
 	reportGenerator *manager.ReportGeneratorWrapper
 	fresh           bool
 	coverFilters    manager.CoverageFilters
+	targetDistances map[uint64]float64
 
 	dash *dashapi.Dashboard
 	// This is specifically separated from dash, so that we can keep dash = nil when
@@ -107,6 +114,9 @@ type Manager struct {
 	assetStorage *asset.Storage
 	fsckChecker  image.FsckChecker
 
+	vmcoreSymbolsOnce  sync.Once
+	vmcoreSymbolsCache map[string][]symbolizer.Symbol
+
 	reproLoop *manager.ReproLoop
 
 	Stats
@@ -272,6 +282,7 @@ func RunManager(mode *Mode, cfg *mgrconfig.Config) {
 		reporter:           reporter,
 		crashStore:         manager.NewCrashStore(cfg),
 		crashTypes:         make(map[string]bool),
+		vmcoreTitles:       make(map[string]bool),
 		disabledHashes:     make(map[string]struct{}),
 		memoryLeakFrames:   make(map[string]bool),
 		dataRaceFrames:     make(map[string]bool),
@@ -347,6 +358,9 @@ func RunManager(mode *Mode, cfg *mgrconfig.Config) {
 	if *flagBench != "" {
 		mgr.initBench()
 	}
+	if mgr.mode == ModeFuzzing {
+		mgr.initStatsHistory()
+	}
 
 	go mgr.heartbeatLoop()
 	if mgr.mode != ModeSmokeTest {
@@ -418,6 +432,28 @@ func (mgr *Manager) initBench() {
 	}()
 }
 
+// initStatsHistory restores graphs shown on the stats page from the previous run
+// and periodically saves them to workdir/stats.json.
+func (mgr *Manager) initStatsHistory() {
+	file := filepath.Join(mgr.cfg.Workdir, "stats.json")
+	if err := stat.LoadHistory(file); err != nil {
+		log.Errorf("%v", err)
+	}
+	go func() {
+		ticker := time.NewTicker(10 * time.Minute)
+		for done := false; !done; {
+			select {
+			case <-ticker.C:
+			case <-vm.Shutdown:
+				done = true
+			}
+			if err := stat.SaveHistory(file); err != nil {
+				log.Errorf("failed to save stats history: %v", err)
+			}
+		}
+	}()
+}
+
 func (mgr *Manager) writeBench() {
 	if mgr.benchFile == nil {
 		return
@@ -595,14 +631,19 @@ func (mgr *Manager) fuzzerInstance(ctx context.Context, inst *vm.Instance, updIn
 		return
 	}
 	injectExec := make(chan bool, 10)
-	serv.CreateInstance(inst.Index(), injectExec, updInfo)
+	diagnoseHang := make(chan bool, 1)
+	serv.CreateInstance(inst.Index(), injectExec, diagnoseHang, updInfo)
 
-	rep, vmInfo, err := mgr.runInstanceInner(ctx, inst, injectExec, vm.EarlyFinishCb(func() {
+	rep, vmInfo, err := mgr.runInstanceInner(ctx, inst, injectExec, diagnoseHang, vm.EarlyFinishCb(func() {
 		// Depending on the crash type and kernel config, fuzzing may continue
 		// running for several seconds even after kernel has printed a crash report.
 		// This litters the log and we want to prevent it.
 		serv.StopFuzzing(inst.Index())
 	}))
+	var vmcore *manager.Vmcore
+	if err == nil && rep != nil {
+		rep, vmcore = mgr.collectVmcore(ctx, inst, rep)
+	}
 	var extraExecs []report.ExecutorInfo
 	if rep != nil && rep.Executor != nil {
 		extraExecs = []report.ExecutorInfo{*rep.Executor}
@@ -618,6 +659,7 @@ func (mgr *Manager) fuzzerInstance(ctx context.Context, inst *vm.Instance, updIn
 	if err == nil && rep != nil {
 		mgr.crashes <- &manager.Crash{
 			InstanceIndex: inst.Index(),
+			Vmcore:        vmcore,
 			Report:        rep,
 		}
 	}
@@ -626,7 +668,7 @@ func (mgr *Manager) fuzzerInstance(ctx context.Context, inst *vm.Instance, updIn
 	}
 }
 
-func (mgr *Manager) runInstanceInner(ctx context.Context, inst *vm.Instance, injectExec <-chan bool,
+func (mgr *Manager) runInstanceInner(ctx context.Context, inst *vm.Instance, injectExec, diagnoseHang <-chan bool,
 	finishCb vm.EarlyFinishCb) (*report.Report, []byte, error) {
 	fwdAddr, err := inst.Forward(mgr.serv.Port())
 	if err != nil {
@@ -653,10 +695,19 @@ func (mgr *Manager) runInstanceInner(ctx context.Context, inst *vm.Instance, inj
 	cmd := fmt.Sprintf("%v runner %v %v %v", executorBin, inst.Index(), host, port)
 	ctxTimeout, cancel := context.WithTimeout(ctx, mgr.cfg.Timeouts.VMRunningTime)
 	defer cancel()
-	_, rep, err := inst.Run(ctxTimeout, mgr.reporter, cmd,
-		vm.ExitTimeout, vm.InjectExecuting(injectExec),
-		finishCb,
-	)
+	opts := []any{vm.ExitTimeout, vm.InjectExecuting(injectExec), vm.DiagnoseHang(diagnoseHang), finishCb}
+	var recorder *vmimpl.Recorder
+	if mgr.cfg.Experimental.RecordConsole {
+		recorder, err = mgr.createRecorder(inst.Index())
+		if err != nil {
+			return nil, nil, err
+		}
+		opts = append(opts, vm.RecordOutput{Recorder: recorder})
+	}
+	_, rep, err := inst.Run(ctxTimeout, mgr.reporter, cmd, opts...)
+	if recorder != nil {
+		mgr.saveRecording(inst.Index(), recorder, rep)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to run fuzzer: %w", err)
 	}
@@ -672,6 +723,61 @@ func (mgr *Manager) runInstanceInner(ctx context.Context, inst *vm.Instance, inj
 	return rep, vmInfo, nil
 }
 
+func (mgr *Manager) recordingFile(index int) string {
+	return filepath.Join(mgr.cfg.Workdir, "recordings", fmt.Sprintf("vm%v", index))
+}
+
+func (mgr *Manager) createRecorder(index int) (*vmimpl.Recorder, error) {
+	file := mgr.recordingFile(index)
+	if err := osutil.MkdirAll(filepath.Dir(file)); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create console recording: %w", err)
+	}
+	return vmimpl.NewRecorder(f), nil
+}
+
+// saveRecording keeps the console recording if the session ended with a crash.
+// Otherwise the recording will be overwritten by the next session on the same VM.
+func (mgr *Manager) saveRecording(index int, recorder *vmimpl.Recorder, rep *report.Report) {
+	file := mgr.recordingFile(index)
+	if err := recorder.Close(); err != nil {
+		log.Logf(0, "VM %v: failed to write console recording: %v", index, err)
+		return
+	}
+	if rep == nil {
+		return
+	}
+	saved := fmt.Sprintf("%v-%v-%v", file, hash.String([]byte(rep.Title)), time.Now().Unix())
+	if err := osutil.Rename(file, saved); err != nil {
+		log.Logf(0, "VM %v: failed to save console recording: %v", index, err)
+		return
+	}
+	log.Logf(0, "VM %v: saved console recording for %q to %v", index, rep.Title, saved)
+	mgr.removeOldRecordings(rep.Title)
+}
+
+// removeOldRecordings keeps only the MaxCrashLogs most recent recordings for the crash title.
+func (mgr *Manager) removeOldRecordings(title string) {
+	pattern := filepath.Join(mgr.cfg.Workdir, "recordings", fmt.Sprintf("vm*-%v-*", hash.String([]byte(title))))
+	files, err := filepath.Glob(pattern)
+	if err != nil || len(files) <= mgr.cfg.MaxCrashLogs {
+		return
+	}
+	created := func(file string) int64 {
+		ts, _ := strconv.ParseInt(file[strings.LastIndexByte(file, '-')+1:], 10, 64)
+		return ts
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return created(files[i]) < created(files[j])
+	})
+	for _, file := range files[:len(files)-mgr.cfg.MaxCrashLogs] {
+		os.Remove(file)
+	}
+}
+
 func (mgr *Manager) emailCrash(crash *manager.Crash) {
 	if len(mgr.cfg.EmailAddrs) == 0 {
 		return
@@ -725,7 +831,7 @@ func (mgr *Manager) saveCrash(crash *manager.Crash) bool {
 		mgr.statSuppressed.Add(1)
 	}
 
-	mgr.statCrashes.Add(1)
+	mgr.statCrashes.With(crash.Type.String()).Add(1)
 	mgr.mu.Lock()
 	if !mgr.crashTypes[crash.Title] {
 		mgr.crashTypes[crash.Title] = true
@@ -747,8 +853,10 @@ func (mgr *Manager) saveCrash(crash *manager.Crash) bool {
 			Log:         crash.Output,
 			Report:      crash.Report.Report,
 			MachineInfo: crash.MachineInfo,
+			Assets:      mgr.uploadVmcore(crash),
 		}
 		setGuiltyFiles(dc, crash.Report)
+		dc.StackFrames = crash.Report.Details.NormalizedStack()
 		resp, err := mgr.dash.ReportCrash(dc)
 		if err != nil {
 			log.Logf(0, "failed to report crash to dashboard: %v", err)
@@ -850,7 +958,7 @@ func (mgr *Manager) saveFailedRepro(rep *report.Report, stats *repro.Stats) {
 func (mgr *Manager) saveRepro(res *manager.ReproResult) {
 	repro := res.Repro
 	opts := fmt.Sprintf("# %+v\n", repro.Opts)
-	progText := repro.Prog.Serialize()
+	progText := repro.Serialize()
 
 	// Append this repro to repro list to send to hub if it didn't come from hub originally.
 	if !res.Crash.FromHub {
@@ -906,6 +1014,7 @@ func (mgr *Manager) saveRepro(res *manager.ReproResult) {
 			OriginalTitle: res.Crash.Title,
 		}
 		setGuiltyFiles(dc, report)
+		dc.StackFrames = report.Details.NormalizedStack()
 		if _, err := mgr.dash.ReportCrash(dc); err != nil {
 			log.Logf(0, "failed to report repro to dashboard: %v", err)
 		} else {
@@ -1129,18 +1238,27 @@ func (mgr *Manager) MachineChecked(features flatrpc.Feature,
 		mgr.corpus = corpus.NewFocusedCorpus(context.Background(),
 			corpusUpdates, mgr.coverFilters.Areas)
 		mgr.http.Corpus.Store(mgr.corpus)
+		if mgr.cfg.Experimental.AutoFocus.Mode != mgrconfig.AutoFocusOff && mgr.mode == ModeFuzzing {
+			autoFocus := manager.NewAutoFocus(mgr.cfg, mgr.reportGenerator, mgr.coverFilters.ExecutorFilter)
+			mgr.http.AutoFocus.Store(autoFocus)
+			go autoFocus.Loop(vm.ShutdownCtx(), mgr.corpus)
+		}
 
 		rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
 		fuzzerObj := fuzzer.NewFuzzer(context.Background(), &fuzzer.Config{
-			Corpus:         mgr.corpus,
-			Snapshot:       mgr.cfg.Snapshot,
-			Coverage:       mgr.cfg.Cover,
-			FaultInjection: features&flatrpc.FeatureFault != 0,
-			Comparisons:    features&flatrpc.FeatureComparisons != 0,
-			Collide:        true,
-			EnabledCalls:   enabledSyscalls,
-			NoMutateCalls:  mgr.cfg.NoMutateCalls,
-			FetchRawCover:  mgr.cfg.RawCover,
+			Corpus:           mgr.corpus,
+			Snapshot:         mgr.cfg.Snapshot,
+			Coverage:         mgr.cfg.Cover,
+			FaultInjection:   features&flatrpc.FeatureFault != 0,
+			Comparisons:      features&flatrpc.FeatureComparisons != 0,
+			Collide:          true,
+			EnabledCalls:     enabledSyscalls,
+			NoMutateCalls:    mgr.cfg.NoMutateCalls,
+			FetchRawCover:    mgr.cfg.RawCover,
+			TargetDistances:  mgr.targetDistances,
+			HitCountSampling: mgr.cfg.Experimental.CoverHitSampling,
+			MaxProgPeakRSS:   uint64(mgr.cfg.Experimental.ProgMaxRSS) << 20,
+			MaxProgOpenFds:   uint32(mgr.cfg.Experimental.ProgMaxFds),
 			Logf: func(level int, msg string, args ...interface{}) {
 				if level != 0 {
 					return
@@ -1450,6 +1568,10 @@ func (mgr *Manager) CoverageFilter(modules []*vminfo.KernelModule) ([]uint64, er
 		return nil, fmt.Errorf("failed to init coverage filter: %w", err)
 	}
 	mgr.coverFilters = filters
+	mgr.targetDistances, err = manager.TargetDistances(mgr.reportGenerator, mgr.cfg)
+	if err != nil {
+		return nil, err
+	}
 	mgr.http.Cover.Store(&manager.CoverageInfo{
 		Modules:         modules,
 		ReportGenerator: mgr.reportGenerator,
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	flatbuffers "github.com/google/flatbuffers/go"
@@ -49,8 +50,9 @@ func (mgr *Manager) snapshotLoop(ctx context.Context, inst *vm.Instance) error {
 
 	builder := flatbuffers.NewBuilder(0)
 	var envFlags flatrpc.ExecEnv
+	statExecs := mgr.servStats.StatExecs.With(strconv.Itoa(inst.Index()))
 	for first := true; ctx.Err() == nil; first = false {
-		mgr.servStats.StatExecs.Add(1)
+		statExecs.Add(1)
 		req := mgr.snapshotSource.Next(inst.Index())
 		if first {
 			envFlags = req.ExecOpts.EnvFlags
@@ -24,7 +24,7 @@ type Stats struct {
 
 func (mgr *Manager) initStats() {
 	mgr.statCrashes = stat.New("crashes", "Total number of VM crashes",
-		stat.Simple, stat.Prometheus("syz_crash_total"))
+		stat.Simple, stat.Prometheus("syz_crash_total"), stat.LabelNames{"type"})
 	mgr.statCrashTypes = stat.New("crash types", "Number of unique crashes types",
 		stat.Simple, stat.NoGraph)
 	mgr.statSuppressed = stat.New("suppressed", "Total number of suppressed VM crashes",
@@ -0,0 +1,109 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/syzkaller/dashboard/dashapi"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/manager"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/pkg/symbolizer"
+	"github.com/google/syzkaller/pkg/vmcore"
+	"github.com/google/syzkaller/vm"
+)
+
+// collectVmcore retrieves and analyzes the kernel crash dump, if kdump is enabled for the VM type.
+// Crash dumps are as large as the VM memory and take long to collect, so they are collected
+// only if the console report is corrupted or truncated. If the kernel log in the dump contains
+// a proper report, the report from the dump is returned instead.
+func (mgr *Manager) collectVmcore(ctx context.Context, inst *vm.Instance, rep *report.Report) (
+	*report.Report, *manager.Vmcore) {
+	if !rep.Corrupted {
+		return rep, nil
+	}
+	dir := filepath.Join(mgr.cfg.Workdir, "vmcore")
+	if err := osutil.MkdirAll(dir); err != nil {
+		log.Logf(0, "VM %v: failed to create vmcore dir: %v", inst.Index(), err)
+		return rep, nil
+	}
+	file := filepath.Join(dir, fmt.Sprintf("vm%v", inst.Index()))
+	ctx, cancel := context.WithTimeout(ctx, time.Hour)
+	defer cancel()
+	ok, err := inst.CollectVmcore(ctx, file)
+	if !ok {
+		return rep, nil
+	}
+	if err != nil {
+		log.Logf(0, "VM %v: failed to collect vmcore: %v", inst.Index(), err)
+		return rep, nil
+	}
+	res := &manager.Vmcore{File: file}
+	analysis, err := vmcore.AnalyzeFile(file, &vmcore.Options{Symbols: mgr.vmcoreSymbols()})
+	if err != nil {
+		log.Logf(0, "VM %v: failed to analyze vmcore: %v", inst.Index(), err)
+		return rep, res
+	}
+	res.Analysis = analysis.Report()
+	if len(analysis.Dmesg) == 0 {
+		return rep, res
+	}
+	if rep1 := mgr.reporter.Parse(analysis.Dmesg); rep1 != nil && !rep1.Corrupted {
+		log.Logf(0, "VM %v: recovered %q from vmcore, the console report was %q",
+			inst.Index(), rep1.Title, rep.Title)
+		res.Recovered = true
+		return rep1, res
+	}
+	return rep, res
+}
+
+func (mgr *Manager) vmcoreSymbols() map[string][]symbolizer.Symbol {
+	mgr.vmcoreSymbolsOnce.Do(func() {
+		if mgr.cfg.KernelObj == "" {
+			return
+		}
+		vmlinux := filepath.Join(mgr.cfg.KernelObj, mgr.sysTarget.KernelObject)
+		symbols, err := symbolizer.ReadTextSymbols(vmlinux)
+		if err != nil {
+			log.Logf(0, "failed to read kernel symbols, vmcore stacks won't be symbolized: %v", err)
+			return
+		}
+		mgr.vmcoreSymbolsCache = symbols
+	})
+	return mgr.vmcoreSymbolsCache
+}
+
+// uploadVmcore uploads the crash dump to the asset storage, at most one dump per bug is uploaded.
+func (mgr *Manager) uploadVmcore(crash *manager.Crash) []dashapi.NewAsset {
+	if mgr.assetStorage == nil || crash.Vmcore == nil {
+		return nil
+	}
+	mgr.mu.Lock()
+	uploaded := mgr.vmcoreTitles[crash.Title]
+	mgr.mu.Unlock()
+	if uploaded {
+		return nil
+	}
+	f, err := os.Open(crash.Vmcore.File)
+	if err != nil {
+		log.Logf(0, "failed to open vmcore: %v", err)
+		return nil
+	}
+	defer f.Close()
+	asset, err := mgr.assetStorage.UploadCrashAsset(f, "vmcore", dashapi.KernelCrashDump, nil)
+	if err != nil {
+		log.Logf(0, "failed to upload vmcore: %v", err)
+		return nil
+	}
+	mgr.mu.Lock()
+	mgr.vmcoreTitles[crash.Title] = true
+	mgr.mu.Unlock()
+	return []dashapi.NewAsset{asset}
+}
@@ -10,6 +10,8 @@
 // If -fix flag is specified, it does fix bisection. Otherwise it does cause bisection. Also
 // wanted syzkaller and kernel commits can be specified using -syzkaller_commit and
 // -kernel_commit. HEAD is used if commits are not specified.
+// If -series flag is specified, the tool instead finds the minimal subset of the given commits
+// that introduces (or fixes with -fix) the crash when cherry-picked on top of the kernel commit.
 //
 // The crash dir should contain the following files:
 //   - repro.cprog or repro.prog: reproducer for the crash
@@ -24,6 +26,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/syzkaller/pkg/bisect"
 	"github.com/google/syzkaller/pkg/config"
@@ -40,6 +43,7 @@ var (
 	flagKernelCommit      = flag.String("kernel_commit", "", "original kernel commit")
 	flagKernelCommitTitle = flag.String("kernel_commit_title", "", "original kernel commit title")
 	flagSyzkallerCommit   = flag.String("syzkaller_commit", "", "original syzkaller commit")
+	flagSeries            = flag.String("series", "", "file with candidate commits to bisect (one per line)")
 )
 
 type Config struct {
@@ -143,6 +147,11 @@ func main() {
 		cfg.Kernel.Commit = vcs.HEAD
 	}
 
+	if *flagSeries != "" {
+		runSeries(cfg)
+		return
+	}
+
 	result, err := bisect.Run(cfg)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "bisection failed: %v\n", err)
@@ -152,6 +161,21 @@ func main() {
 	saveResultCommits(result.Commits)
 }
 
+func runSeries(cfg *bisect.Config) {
+	data, err := os.ReadFile(*flagSeries)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	cfg.Kernel.Series = strings.Fields(string(data))
+	result, err := bisect.RunSeries(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "series bisection failed: %v\n", err)
+		os.Exit(1)
+	}
+	saveResultCommits(result.Commits)
+}
+
 func loadFile(path, file string, dst *[]byte, mandatory bool) {
 	filename := filepath.Join(path, file)
 	if !mandatory && !osutil.IsExist(filename) {
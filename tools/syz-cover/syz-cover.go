@@ -18,10 +18,17 @@
 // or use all pcs in rg.Symbols
 //
 //	syz-cover -config config_file
+//
+// or compare coverage with the base coverage (e.g. before a descriptions change):
+//
+//	syz-cover -config config_file -diff-base base.rawcover rawcover.file*
+//
+// or compare coverage of two coveragedb time periods (works across kernel versions):
+//
+//	syz-cover -namespace upstream -period month -diff-from 2026-08-31 -to 2026-09-30
 package main
 
 import (
-	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -30,7 +37,6 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"strconv"
 	"strings"
 	"time"
 
@@ -38,6 +44,7 @@ import (
 	"github.com/google/syzkaller/pkg/cover"
 	"github.com/google/syzkaller/pkg/cover/backend"
 	"github.com/google/syzkaller/pkg/coveragedb"
+	"github.com/google/syzkaller/pkg/coveragedb/spannerclient"
 	"github.com/google/syzkaller/pkg/covermerger"
 	"github.com/google/syzkaller/pkg/log"
 	"github.com/google/syzkaller/pkg/mgrconfig"
@@ -62,9 +69,16 @@ var (
 	flagSourceCommit = flag.String("source-commit", "", "[optional] filter input commit")
 	flagExports      = flag.String("exports", "cover",
 		"[optional] comma separated list of exports for which we want to generate coverage, "+
-			"possible values are: cover, subsystem, module, funccover, json, jsonl, rawcover, rawcoverfiles, all")
+			"possible values are: cover, subsystem, module, funccover, json, jsonl, lcov, cobertura, rawcover, rawcoverfiles, all")
 	flagForce = flag.Bool("force", false, "[optional] create coverage report when "+
 		"there are missing coverage callbacks")
+	flagDiffBase = flag.String("diff-base", "", "[optional] comma separated list of raw coverage files "+
+		"to compare the coverage with, generates a coverage diff report instead of -exports")
+	flagDiffFrom = flag.String("diff-from", "", "[optional] compare coveragedb coverage for the -period "+
+		"ending at this date with the -period ending at -to date")
+	flagProject       = flag.String("project", "syzkaller", "[optional] coveragedb GCP project used by -diff-from")
+	flagCoverageDBDir = flag.String("coveragedb-dir", "", "[optional] use the local coveragedb in the dir "+
+		"(see syz-covermerger -to-coveragedb-dir) instead of the -project one")
 )
 
 func toolFileCover() {
@@ -103,6 +117,52 @@ func toolFileCover() {
 	fmt.Println(details)
 }
 
+func toolDBCoverDiff() {
+	periods := make(map[string]coveragedb.TimePeriod)
+	for _, date := range []string{*flagDiffFrom, *flagDateTo} {
+		d, err := civil.ParseDate(date)
+		if err != nil {
+			tool.Failf("failed to parse date %q: %v", date, err)
+		}
+		periods[date], err = coveragedb.MakeTimePeriod(d, *flagPeriod)
+		if err != nil {
+			tool.Fail(err)
+		}
+	}
+	ctx := context.Background()
+	var storage coveragedb.Storage
+	if *flagCoverageDBDir != "" {
+		var err error
+		if storage, err = coveragedb.NewLocalStorage(*flagCoverageDBDir); err != nil {
+			tool.Fail(err)
+		}
+	} else {
+		client, err := spannerclient.NewClient(ctx, *flagProject)
+		if err != nil {
+			tool.Fail(err)
+		}
+		storage = coveragedb.NewSpannerStorage(client)
+	}
+	defer storage.Close()
+	diff, err := cover.DBCoverDiff(ctx, storage, *flagNamespace, periods[*flagDiffFrom], periods[*flagDateTo])
+	if err != nil {
+		tool.Fail(err)
+	}
+	for fname, write := range map[string]func(io.Writer) error{
+		"syz-cover-diff.html": diff.WriteHTML,
+		"syz-cover-diff.json": diff.WriteJSON,
+	} {
+		buf := new(bytes.Buffer)
+		if err := write(buf); err != nil {
+			tool.Fail(err)
+		}
+		log.Logf(0, "write to %v", fname)
+		if err := osutil.WriteFile(fname, buf.Bytes()); err != nil {
+			tool.Fail(err)
+		}
+	}
+}
+
 func initModules(cfg *mgrconfig.Config) []*vminfo.KernelModule {
 	modules, err := backend.DiscoverModules(cfg.SysTarget, cfg.KernelObj, cfg.ModuleObj)
 	if err != nil {
@@ -124,6 +184,10 @@ func main() {
 		toolFileCover()
 		return
 	}
+	if *flagDiffFrom != "" {
+		toolDBCoverDiff()
+		return
+	}
 	cfg, err := mgrconfig.LoadFile(*flagConfig)
 	if err != nil {
 		tool.Fail(err)
@@ -141,6 +205,16 @@ func main() {
 		Force: *flagForce,
 	}
 
+	if *flagDiffBase != "" {
+		basePCs, err := readPCs(strings.Split(*flagDiffBase, ","))
+		if err != nil {
+			tool.Fail(err)
+		}
+		params.BaseProgs = []cover.Prog{{PCs: basePCs}}
+		doReport(params, "syz-cover-diff.html", rg.DoCoverDiffHTML)
+		doReport(params, "syz-cover-diff.json", rg.DoCoverDiffJSON)
+		return
+	}
 	if *flagExports == "all" {
 		*flagExports = "cover,subsystem,module,funccover,rawcover,rawcoverfiles"
 	}
@@ -164,6 +238,10 @@ func main() {
 			doReport(params, "json", rg.DoLineJSON)
 		case "jsonl":
 			doReport(params, "jsonl", rg.DoCoverJSONL)
+		case "lcov":
+			doReport(params, "syz-cover.lcov", rg.DoLCOV)
+		case "cobertura":
+			doReport(params, "syz-cover-cobertura.xml", rg.DoCobertura)
 		default:
 			tool.Failf("unknown export type: %q", export)
 		}
@@ -203,17 +281,11 @@ func readPCs(files []string) ([]uint64, error) {
 		if err != nil {
 			return nil, err
 		}
-		for s := bufio.NewScanner(bytes.NewReader(data)); s.Scan(); {
-			line := strings.TrimSpace(s.Text())
-			if line == "" {
-				continue
-			}
-			pc, err := strconv.ParseUint(line, 0, 64)
-			if err != nil {
-				return nil, err
-			}
-			pcs = append(pcs, pc)
+		filePCs, err := cover.ParseRawCover(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", file, err)
 		}
+		pcs = append(pcs, filePCs...)
 	}
 	return pcs, nil
 }
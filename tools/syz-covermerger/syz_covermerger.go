@@ -4,10 +4,13 @@
 package main
 
 import (
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
+	"os"
 	"runtime"
 	"strings"
 
@@ -17,6 +20,7 @@ import (
 	"github.com/google/syzkaller/pkg/covermerger"
 	"github.com/google/syzkaller/pkg/gcs"
 	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/subsystem"
 	_ "github.com/google/syzkaller/pkg/subsystem/lists"
 	"github.com/google/syzkaller/pkg/tool"
 )
@@ -35,6 +39,10 @@ var (
 	flagSrcProvider         = flag.String("provider", "git-clone", "[optional] git-clone or web-git")
 	flagFilePathPrefix      = flag.String("file-path-prefix", "", "[optional] kernel file path prefix")
 	flagToGCS               = flag.String("to-gcs", "", "[optional] gcs destination to save jsonl to")
+	flagFromCSV             = flag.String("from-csv", "", "[optional] read coverage csv from the file instead of BigQuery")
+	flagToCoverageDBDir     = flag.String("to-coveragedb-dir", "",
+		"[optional] save the merge result to the local coveragedb in the dir")
+	flagSubsystems = flag.String("subsystems", "linux", "[optional] subsystem list used by -to-coveragedb-dir")
 )
 
 func makeProvider() covermerger.FileVersProvider {
@@ -71,18 +79,32 @@ func do() error {
 		panic(fmt.Sprintf("failed to parse time_to: %s", err.Error()))
 	}
 	dateFrom = dateTo.AddDays(-int(*flagDuration))
-	csvReader, err := covermerger.InitNsRecords(context.Background(),
-		*flagNamespace,
-		*flagFilePathPrefix,
-		"",
-		dateFrom,
-		dateTo,
-	)
-	if err != nil {
-		panic(fmt.Sprintf("failed to dbReader.InitNsRecords: %v", err.Error()))
+	var csvReader io.ReadCloser
+	if *flagFromCSV != "" {
+		if csvReader, err = os.Open(*flagFromCSV); err != nil {
+			return err
+		}
+	} else {
+		csvReader, err = covermerger.InitNsRecords(context.Background(),
+			*flagNamespace,
+			*flagFilePathPrefix,
+			"",
+			dateFrom,
+			dateTo,
+		)
+		if err != nil {
+			panic(fmt.Sprintf("failed to dbReader.InitNsRecords: %v", err.Error()))
+		}
 	}
 	defer csvReader.Close()
 	var wc io.WriteCloser
+	var saveErr chan error
+	if *flagToCoverageDBDir != "" {
+		if *flagToGCS != "" || *flagToDashAPI != "" {
+			return fmt.Errorf("-to-coveragedb-dir can't be used with -to-gcs or -to-dashapi")
+		}
+		wc, saveErr = saveToLocalDB(*flagToCoverageDBDir)
+	}
 	url := *flagToGCS
 	if *flagToDashAPI != "" {
 		dash, err := dashapi.New(*flagDashboardClientName, *flagToDashAPI, "")
@@ -125,6 +147,11 @@ func do() error {
 			return fmt.Errorf("wc.Close: %w", err)
 		}
 	}
+	if saveErr != nil {
+		if err := <-saveErr; err != nil {
+			return err
+		}
+	}
 	printCoverage(totalInstrumentedLines, totalCoveredLines)
 	if *flagToDashAPI != "" {
 		// Merging may take hours. It is better to create new connection instead of reuse.
@@ -141,6 +168,42 @@ func do() error {
 	return nil
 }
 
+// saveToLocalDB returns the writer for the gzipped merge result jsonl that is saved to the local coveragedb
+// in the dir, the same way the dashboard saves it to Spanner (see apiSaveCoverage).
+func saveToLocalDB(dir string) (io.WriteCloser, chan error) {
+	pr, pw := io.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		err := func() error {
+			storage, err := coveragedb.NewLocalStorage(dir)
+			if err != nil {
+				return fmt.Errorf("coveragedb.NewLocalStorage: %w", err)
+			}
+			defer storage.Close()
+			gzr, err := gzip.NewReader(pr)
+			if err != nil {
+				return fmt.Errorf("gzip.NewReader: %w", err)
+			}
+			descr := new(coveragedb.HistoryRecord)
+			dec := json.NewDecoder(gzr)
+			if err := dec.Decode(descr); err != nil {
+				return fmt.Errorf("json.Decode(coveragedb.HistoryRecord): %w", err)
+			}
+			rowsCreated, err := storage.SaveMergeResult(context.Background(), descr, dec,
+				subsystem.GetList(*flagSubsystems))
+			if err != nil {
+				return fmt.Errorf("storage.SaveMergeResult: %w", err)
+			}
+			fmt.Printf("created %d local DB rows\n", rowsCreated)
+			return nil
+		}()
+		// Unblock the writer if saving failed.
+		pr.CloseWithError(err)
+		errc <- err
+	}()
+	return pw, errc
+}
+
 func printCoverage(instrumented, covered int) {
 	coverage := 0.0
 	if instrumented != 0 {
@@ -0,0 +1,79 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-proxyapp is a reference proxyapp VM provider (see vm/proxyapp).
+// It runs commands either as local processes or in VMs of any in-tree type (e.g. qemu).
+// Use it with the following manager VM config:
+//
+//	"type": "proxyapp",
+//	"vm": {
+//		"cmd": "bin/syz-proxyapp -workdir=/tmp/proxyapp",
+//		"config": {"type": "local", "count": 4}
+//	}
+//
+// or start it with -listen=localhost:1234 and use "rpc_server_uri": "localhost:1234" instead of "cmd".
+// For the provider config format see proxyserver.Config.
+package main
+
+import (
+	"flag"
+	"io"
+	"net"
+	"os"
+
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/tool"
+	_ "github.com/google/syzkaller/vm" // register in-tree VM types
+	"github.com/google/syzkaller/vm/proxyapp/proxyserver"
+)
+
+var (
+	flagListen  = flag.String("listen", "", "TCP address to serve on (serve on stdin/stdout if empty)")
+	flagWorkdir = flag.String("workdir", "", "directory for instance files (temp dir if empty)")
+)
+
+func main() {
+	flag.Parse()
+	workdir := *flagWorkdir
+	if workdir == "" {
+		dir, err := os.MkdirTemp("", "syz-proxyapp")
+		if err != nil {
+			tool.Fail(err)
+		}
+		defer os.RemoveAll(dir)
+		workdir = dir
+	} else if err := osutil.MkdirAll(workdir); err != nil {
+		tool.Fail(err)
+	}
+	srv := proxyserver.NewServer(workdir)
+	defer srv.Shutdown()
+	if *flagListen == "" {
+		// Stdout is used for RPC, all logging goes to stderr.
+		srv.ServeConn(stdio{os.Stdin, os.Stdout})
+		return
+	}
+	l, err := net.Listen("tcp", *flagListen)
+	if err != nil {
+		tool.Fail(err)
+	}
+	log.Logf(0, "serving on %v", l.Addr())
+	shutdown := make(chan struct{})
+	osutil.HandleInterrupts(shutdown)
+	go func() {
+		<-shutdown
+		l.Close()
+	}()
+	if err := srv.Serve(l); err != nil {
+		log.Logf(0, "%v", err)
+	}
+}
+
+type stdio struct {
+	io.Reader
+	io.Writer
+}
+
+func (stdio) Close() error {
+	return nil
+}
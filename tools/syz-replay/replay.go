@@ -0,0 +1,76 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-replay feeds VM console recordings (see record_console manager config option)
+// through the crash detection and report parsing logic. Usage:
+//
+//	syz-replay -config=manager.cfg workdir/recordings/vm0-*
+//
+// It's intended for regression testing of crash detection and title extraction
+// against real historic fuzzing sessions.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/pkg/tool"
+	"github.com/google/syzkaller/vm"
+	"github.com/google/syzkaller/vm/vmimpl"
+)
+
+var (
+	flagConfig = flag.String("config", "", "manager configuration file")
+	flagReport = flag.Bool("report", false, "print full crash reports")
+	flagOutput = flag.Bool("output", false, "print the replayed console output")
+)
+
+func main() {
+	flag.Parse()
+	if len(flag.Args()) == 0 || *flagConfig == "" {
+		fmt.Fprintf(os.Stderr, "usage: syz-replay -config=manager.cfg recording...\n")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	cfg, err := mgrconfig.LoadPartialFile(*flagConfig)
+	if err != nil {
+		tool.Fail(err)
+	}
+	cfg.CompleteKernelDirs()
+	reporter, err := report.NewReporter(cfg)
+	if err != nil {
+		tool.Failf("failed to create reporter: %v", err)
+	}
+	for _, file := range flag.Args() {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			tool.Fail(err)
+		}
+		recording, err := vmimpl.ReadRecording(data)
+		if err != nil {
+			tool.Failf("%v: %v", file, err)
+		}
+		// Recordings are made by syz-manager, so use the same exit condition.
+		output, rep := vm.Replay(cfg, reporter, recording, vm.ExitTimeout)
+		if *flagOutput {
+			os.Stdout.Write(output)
+		}
+		if rep == nil {
+			fmt.Printf("%v: no crash\n", file)
+			continue
+		}
+		if err := reporter.Symbolize(rep); err != nil {
+			fmt.Fprintf(os.Stderr, "%v: failed to symbolize report: %v\n", file, err)
+		}
+		fmt.Printf("%v: %v [%v]\n", file, rep.Title, rep.Type)
+		for _, title := range rep.AltTitles {
+			fmt.Printf("\talt title: %v\n", title)
+		}
+		if *flagReport {
+			fmt.Printf("%s\n", rep.Report)
+		}
+	}
+}
@@ -0,0 +1,322 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// syz-report-diff replays a corpus of console logs through pkg/report and shows how
+// the parsing results differ between two syzkaller revisions.
+//
+// Since a binary contains only one version of pkg/report, the comparison is done in two steps.
+// First, parse the logs with the old revision and save the results:
+//
+//	syz-report-diff -os linux -arch amd64 -logs logs_dir -save old.json
+//
+// Then, parse the same logs with the new revision and compare with the saved results:
+//
+//	syz-report-diff -os linux -arch amd64 -logs logs_dir -base old.json
+//
+// Two saved results can also be compared directly:
+//
+//	syz-report-diff -base old.json -new new.json
+//
+// The changes are grouped into categories (title changed, now corrupted, new suppression, etc).
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/pkg/tool"
+)
+
+var (
+	flagOS     = flag.String("os", runtime.GOOS, "target os")
+	flagArch   = flag.String("arch", runtime.GOARCH, "target arch")
+	flagConfig = flag.String("config", "", "optional manager configuration file")
+	flagLogs   = flag.String("logs", "", "directory with console logs (searched recursively)")
+	flagSave   = flag.String("save", "", "save parsing results to this file")
+	flagBase   = flag.String("base", "", "parsing results to compare with")
+	flagNew    = flag.String("new", "", "parsing results to compare with -base (instead of parsing -logs)")
+)
+
+func main() {
+	flag.Parse()
+	if *flagBase == "" && *flagSave == "" || *flagLogs == "" && *flagNew == "" {
+		fmt.Fprintf(os.Stderr, "usage: syz-report-diff -logs dir -save results.json\n")
+		fmt.Fprintf(os.Stderr, "       syz-report-diff -logs dir -base results.json\n")
+		fmt.Fprintf(os.Stderr, "       syz-report-diff -base results.json -new results.json\n")
+		flag.PrintDefaults()
+		os.Exit(1)
+	}
+	var results Results
+	var err error
+	if *flagNew != "" {
+		results, err = loadResults(*flagNew)
+	} else {
+		results, err = parseLogs(*flagLogs)
+	}
+	if err != nil {
+		tool.Fail(err)
+	}
+	if *flagSave != "" {
+		data, err := json.MarshalIndent(results, "", "\t")
+		if err != nil {
+			tool.Fail(err)
+		}
+		if err := osutil.WriteFile(*flagSave, data); err != nil {
+			tool.Fail(err)
+		}
+	}
+	if *flagBase == "" {
+		return
+	}
+	base, err := loadResults(*flagBase)
+	if err != nil {
+		tool.Fail(err)
+	}
+	printChanges(os.Stdout, len(results), diffResults(base, results))
+}
+
+// Results maps log file names (relative to the logs dir) to the reports found in them.
+type Results map[string][]*Summary
+
+// Summary contains the parsing result properties we want to compare.
+type Summary struct {
+	Title           string   `json:"title"`
+	AltTitles       []string `json:"alt_titles,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	Frame           string   `json:"frame,omitempty"`
+	Corrupted       bool     `json:"corrupted,omitempty"`
+	CorruptedReason string   `json:"corrupted_reason,omitempty"`
+	Suppressed      bool     `json:"suppressed,omitempty"`
+}
+
+func newReporter() (*report.Reporter, error) {
+	var cfg *mgrconfig.Config
+	var err error
+	if *flagConfig != "" {
+		cfg, err = mgrconfig.LoadPartialFile(*flagConfig)
+	} else {
+		cfg, err = mgrconfig.LoadPartialData([]byte(`{
+			"target": "` + *flagOS + "/" + *flagArch + `"
+		}`))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return report.NewReporter(cfg)
+}
+
+func parseLogs(dir string) (Results, error) {
+	reporter, err := newReporter()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reporter: %w", err)
+	}
+	var files []string
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	results := make(Results)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var firstErr error
+	names := make(chan string)
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range names {
+				res, err := parseLog(reporter, file)
+				rel, _ := filepath.Rel(dir, file)
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				results[rel] = res
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, file := range files {
+		names <- file
+	}
+	close(names)
+	wg.Wait()
+	return results, firstErr
+}
+
+func parseLog(reporter *report.Reporter, file string) ([]*Summary, error) {
+	output, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var ret []*Summary
+	for _, rep := range report.ParseAll(reporter, output) {
+		ret = append(ret, &Summary{
+			Title:           rep.Title,
+			AltTitles:       rep.AltTitles,
+			Type:            rep.Type.String(),
+			Frame:           rep.Frame,
+			Corrupted:       rep.Corrupted,
+			CorruptedReason: rep.CorruptedReason,
+			Suppressed:      rep.Suppressed,
+		})
+	}
+	return ret, nil
+}
+
+func loadResults(file string) (Results, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	results := make(Results)
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse %v: %w", file, err)
+	}
+	return results, nil
+}
+
+type Category string
+
+const (
+	CategoryNewCrash          Category = "crash is now detected"
+	CategoryLostCrash         Category = "crash is no longer detected"
+	CategoryTitle             Category = "title changed"
+	CategoryNowCorrupted      Category = "now corrupted"
+	CategoryNoLongerCorrupted Category = "no longer corrupted"
+	CategoryNewSuppression    Category = "new suppression"
+	CategoryLostSuppression   Category = "no longer suppressed"
+	CategoryFrame             Category = "frame changed"
+	CategoryType              Category = "type changed"
+	CategoryReports           Category = "number of reports changed"
+	CategoryMissing           Category = "log is missing"
+)
+
+// categories lists all categories in the order they are printed.
+var categories = []Category{
+	CategoryNewCrash,
+	CategoryLostCrash,
+	CategoryTitle,
+	CategoryNowCorrupted,
+	CategoryNoLongerCorrupted,
+	CategoryNewSuppression,
+	CategoryLostSuppression,
+	CategoryFrame,
+	CategoryType,
+	CategoryReports,
+	CategoryMissing,
+}
+
+// Change describes a single difference for a log file.
+type Change struct {
+	Category Category
+	File     string
+	Old      string
+	New      string
+}
+
+// diffResults compares parsing results for all logs present in base.
+// The changes are sorted by category and file name.
+func diffResults(base, cur Results) []*Change {
+	var changes []*Change
+	add := func(category Category, file, before, after string) {
+		changes = append(changes, &Change{category, file, before, after})
+	}
+	for file, oldReps := range base {
+		newReps, ok := cur[file]
+		if !ok {
+			add(CategoryMissing, file, "", "")
+			continue
+		}
+		if len(oldReps) == 0 && len(newReps) != 0 {
+			add(CategoryNewCrash, file, "", newReps[0].Title)
+			continue
+		}
+		if len(oldReps) != 0 && len(newReps) == 0 {
+			add(CategoryLostCrash, file, oldReps[0].Title, "")
+			continue
+		}
+		if len(oldReps) != len(newReps) {
+			add(CategoryReports, file, fmt.Sprint(len(oldReps)), fmt.Sprint(len(newReps)))
+		}
+		for i := 0; i < min(len(oldReps), len(newReps)); i++ {
+			before, after := oldReps[i], newReps[i]
+			if before.Title != after.Title {
+				add(CategoryTitle, file, before.Title, after.Title)
+			}
+			if !before.Corrupted && after.Corrupted {
+				add(CategoryNowCorrupted, file, after.Title, after.CorruptedReason)
+			}
+			if before.Corrupted && !after.Corrupted {
+				add(CategoryNoLongerCorrupted, file, before.CorruptedReason, after.Title)
+			}
+			if !before.Suppressed && after.Suppressed {
+				add(CategoryNewSuppression, file, "", after.Title)
+			}
+			if before.Suppressed && !after.Suppressed {
+				add(CategoryLostSuppression, file, before.Title, "")
+			}
+			if before.Frame != after.Frame {
+				add(CategoryFrame, file, before.Frame, after.Frame)
+			}
+			if before.Type != after.Type {
+				add(CategoryType, file, before.Type, after.Type)
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Category != changes[j].Category {
+			return slices.Index(categories, changes[i].Category) < slices.Index(categories, changes[j].Category)
+		}
+		return changes[i].File < changes[j].File
+	})
+	return changes
+}
+
+func printChanges(w io.Writer, total int, changes []*Change) {
+	byCategory := make(map[Category][]*Change)
+	files := make(map[string]bool)
+	for _, change := range changes {
+		byCategory[change.Category] = append(byCategory[change.Category], change)
+		files[change.File] = true
+	}
+	fmt.Fprintf(w, "%v out of %v logs changed\n", len(files), total)
+	for _, category := range categories {
+		if len(byCategory[category]) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%v (%v):\n", category, len(byCategory[category]))
+		for _, change := range byCategory[category] {
+			switch {
+			case change.Old == "" && change.New == "":
+				fmt.Fprintf(w, "\t%v\n", change.File)
+			case change.Old == "":
+				fmt.Fprintf(w, "\t%v: %q\n", change.File, change.New)
+			case change.New == "":
+				fmt.Fprintf(w, "\t%v: %q\n", change.File, change.Old)
+			default:
+				fmt.Fprintf(w, "\t%v: %q -> %q\n", change.File, change.Old, change.New)
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffResults(t *testing.T) {
+	base := Results{
+		"same":      {{Title: "KASAN: use-after-free Read in foo", Frame: "foo"}},
+		"title":     {{Title: "WARNING in bar", Frame: "bar"}},
+		"corrupted": {{Title: "general protection fault in baz", Frame: "baz"}},
+		"lost":      {{Title: "INFO: task hung in qux"}},
+		"found":     nil,
+		"suppress":  {{Title: "lost connection to test machine"}},
+		"missing":   nil,
+	}
+	cur := Results{
+		"same":      {{Title: "KASAN: use-after-free Read in foo", Frame: "foo"}},
+		"title":     {{Title: "WARNING in baz", Frame: "baz"}},
+		"corrupted": {{Title: "general protection fault in baz", Frame: "baz", Corrupted: true, CorruptedReason: "no stack"}},
+		"lost":      nil,
+		"found":     {{Title: "BUG: soft lockup in foo"}},
+		"suppress":  {{Title: "lost connection to test machine", Suppressed: true}},
+		"new":       {{Title: "not in base"}},
+	}
+	changes := diffResults(base, cur)
+	buf := new(bytes.Buffer)
+	printChanges(buf, len(cur), changes)
+	want := `6 out of 7 logs changed
+
+crash is now detected (1):
+	found: "BUG: soft lockup in foo"
+
+crash is no longer detected (1):
+	lost: "INFO: task hung in qux"
+
+title changed (1):
+	title: "WARNING in bar" -> "WARNING in baz"
+
+now corrupted (1):
+	corrupted: "general protection fault in baz" -> "no stack"
+
+new suppression (1):
+	suppress: "lost connection to test machine"
+
+frame changed (1):
+	title: "bar" -> "baz"
+
+log is missing (1):
+	missing
+`
+	if diff := cmp.Diff(want, buf.String()); diff != "" {
+		t.Fatal(diff)
+	}
+}
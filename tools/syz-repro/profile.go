@@ -0,0 +1,117 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/syzkaller/pkg/csource"
+	"github.com/google/syzkaller/pkg/instance"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/mgrconfig"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/vm"
+)
+
+var (
+	flagProfile = flag.Bool("profile", false, "profile reliability of an existing syz reproducer "+
+		"(the input file is a syz repro with the '# {options}' header instead of an execution log)")
+	flagProfileRuns      = flag.Int("profile_runs", 10, "number of runs for each profiled setting")
+	flagProfileDuration  = flag.Duration("profile_duration", 5*time.Minute, "duration of each reproducer run")
+	flagProfileConfigs   = flag.String("profile_configs", "", "comma-separated additional manager configs (kernels)")
+	flagProfileVMs       = flag.String("profile_vms", "", "comma-separated VM counts to profile")
+	flagProfileCPUs      = flag.String("profile_cpus", "", "comma-separated per-VM CPU counts to profile")
+	flagProfileSandboxes = flag.String("profile_sandboxes", "", "comma-separated sandboxes to profile")
+	flagProfileThreaded  = flag.String("profile_threaded", "", "comma-separated threaded values to profile")
+	flagProfileCollide   = flag.String("profile_collide", "", "comma-separated collide values to profile")
+	flagProfileProcs     = flag.String("profile_procs", "", "comma-separated procs values to profile")
+)
+
+func runProfile(cfg *mgrconfig.Config, data []byte) {
+	opts, prog := parseReproOpts(cfg, data)
+	matrix := instance.ProfileMatrix{
+		Configs: []instance.ProfileConfig{{ID: *flagConfig, Config: cfg}},
+		VMs:     parseListFlag("profile_vms", *flagProfileVMs, strconv.Atoi),
+		CPUs:    parseListFlag("profile_cpus", *flagProfileCPUs, strconv.Atoi),
+		Sandboxes: parseListFlag("profile_sandboxes", *flagProfileSandboxes, func(s string) (string, error) {
+			return s, nil
+		}),
+		Threaded: parseListFlag("profile_threaded", *flagProfileThreaded, strconv.ParseBool),
+		Collide:  parseListFlag("profile_collide", *flagProfileCollide, strconv.ParseBool),
+		Procs:    parseListFlag("profile_procs", *flagProfileProcs, strconv.Atoi),
+	}
+	for _, file := range parseListFlag("profile_configs", *flagProfileConfigs, func(s string) (string, error) {
+		return s, nil
+	}) {
+		extra, err := mgrconfig.LoadFile(file)
+		if err != nil {
+			log.Fatalf("%v: %v", file, err)
+		}
+		matrix.Configs = append(matrix.Configs, instance.ProfileConfig{ID: file, Config: extra})
+	}
+	if *flagCount > 0 {
+		for _, config := range matrix.Configs {
+			if err := instance.OverrideVMCount(config.Config, *flagCount); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	osutil.HandleInterrupts(vm.Shutdown)
+	results, err := instance.ProfileRepro(instance.ProfileParams{
+		Matrix:   matrix,
+		ReproSyz: prog,
+		Opts:     opts,
+		Runs:     *flagProfileRuns,
+		Duration: *flagProfileDuration,
+		Logf:     log.Logf,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("\n%s\n", instance.FormatProfile(results))
+	reqs := instance.ProfileRequirements(results)
+	switch {
+	case reqs == nil:
+		fmt.Printf("the reproducer did not crash the kernel in any setting\n")
+	case len(reqs) == 0:
+		fmt.Printf("the reproducer does not depend on the profiled settings\n")
+	default:
+		fmt.Printf("required settings: %v\n", strings.Join(reqs, " "))
+	}
+}
+
+// parseReproOpts splits the options header saved by syz-manager from the program.
+// If there is no header, the default options for the config are used.
+func parseReproOpts(cfg *mgrconfig.Config, data []byte) (csource.Options, []byte) {
+	if header, rest, ok := bytes.Cut(data, []byte{'\n'}); ok && bytes.HasPrefix(header, []byte("# {")) {
+		opts, err := csource.DeserializeOptions(bytes.TrimPrefix(header, []byte("# ")))
+		if err == nil {
+			return opts, rest
+		}
+		log.Logf(0, "failed to parse repro options %q: %v", header, err)
+	}
+	return csource.DefaultOpts(cfg), data
+}
+
+func parseListFlag[T any](name, value string, parse func(string) (T, error)) []T {
+	if value == "" {
+		return nil
+	}
+	var ret []T
+	for _, s := range strings.Split(value, ",") {
+		v, err := parse(strings.TrimSpace(s))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "bad -%v value %q: %v\n", name, s, err)
+			os.Exit(1)
+		}
+		ret = append(ret, v)
+	}
+	return ret
+}
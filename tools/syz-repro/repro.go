@@ -10,7 +10,6 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/google/syzkaller/pkg/csource"
 	"github.com/google/syzkaller/pkg/flatrpc"
 	"github.com/google/syzkaller/pkg/log"
 	"github.com/google/syzkaller/pkg/mgrconfig"
@@ -45,6 +44,10 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to open log file %v: %v", logFile, err)
 	}
+	if *flagProfile {
+		runProfile(cfg, data)
+		return
+	}
 	vmPool, err := vm.Create(cfg, *flagDebug)
 	if err != nil {
 		log.Fatalf("%v", err)
@@ -82,19 +85,31 @@ func main() {
 			fmt.Printf("simplifying prog options: %v\n", stats.SimplifyProgTime)
 			fmt.Printf("extracting C: %v\n", stats.ExtractCTime)
 			fmt.Printf("simplifying C: %v\n", stats.SimplifyCTime)
+			fmt.Printf("explaining prog: %v\n", stats.ExplainTime)
 		}
 		if res == nil {
 			return
 		}
 
 		fmt.Printf("opts: %+v crepro: %v\n\n", res.Opts, res.CRepro)
-		progSerialized := res.Prog.Serialize()
+		progSerialized := res.Serialize()
 		fmt.Printf("%s\n", progSerialized)
 		if err = osutil.WriteFile(*flagOutput, progSerialized); err == nil {
 			fmt.Printf("program saved to %s\n", *flagOutput)
 		} else {
 			log.Logf(0, "failed to write prog to file: %v", err)
 		}
+		if parallelLog := res.ParallelLog(); parallelLog != nil {
+			// The concatenated program above may not reproduce the crash on its own.
+			parallelFile := *flagOutput + ".parallel"
+			fmt.Printf("the crash requires concurrent execution of programs:\n%s\n", parallelLog)
+			if err = osutil.WriteFile(parallelFile, parallelLog); err == nil {
+				fmt.Printf("parallel programs saved to %s (run with syz-execprog -procs=%v)\n",
+					parallelFile, res.Opts.Procs)
+			} else {
+				log.Logf(0, "failed to write parallel programs to file: %v", err)
+			}
+		}
 
 		if res.Report != nil && *flagTitle != "" {
 			recordTitle(res, *flagTitle)
@@ -119,13 +134,10 @@ func recordTitle(res *repro.Result, fileName string) {
 }
 
 func recordCRepro(res *repro.Result, fileName string) {
-	src, err := csource.Write(res.Prog, res.Opts)
+	src, err := res.CProgram()
 	if err != nil {
 		log.Fatalf("failed to generate C repro: %v", err)
 	}
-	if formatted, err := csource.Format(src); err == nil {
-		src = formatted
-	}
 	fmt.Printf("%s\n", src)
 
 	if err := osutil.WriteFile(fileName, src); err == nil {
@@ -564,6 +564,7 @@ func (inst *instance) Run(ctx context.Context, command string) (
 		tee = os.Stdout
 	}
 	merger := vmimpl.NewOutputMerger(tee)
+	merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	merger.Add("console", tty)
 	merger.Add("adb", adbRpipe)
 
@@ -331,6 +331,7 @@ func (inst *instance) Run(ctx context.Context, command string) (
 	if err != nil {
 		return nil, nil, err
 	}
+	inst.merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	inst.merger.Add("ssh", rpipe)
 
 	var sshargs []string
@@ -37,9 +37,10 @@ import (
 
 func init() {
 	vmimpl.Register("gce", vmimpl.Type{
-		Ctor:        ctor,
-		Overcommit:  true,
-		Preemptible: true,
+		Ctor:         ctor,
+		Overcommit:   true,
+		Preemptible:  true,
+		LiveDiagnose: true,
 	})
 }
 
@@ -310,6 +311,7 @@ func (inst *instance) Run(ctx context.Context, command string) (
 		tee = os.Stdout
 	}
 	merger := vmimpl.NewOutputMerger(tee)
+	merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	var decoder func(data []byte) (int, int, []byte)
 	if inst.env.OS == targets.Windows {
 		decoder = kd.Decode
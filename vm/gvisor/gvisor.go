@@ -310,6 +310,7 @@ func (inst *instance) Run(ctx context.Context, command string) (
 		return nil, nil, err
 	}
 	defer wpipe.Close()
+	inst.merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	inst.merger.Add("cmd", rpipe)
 	cmd.Stdout = wpipe
 	cmd.Stderr = wpipe
@@ -39,6 +39,10 @@ type Config struct {
 	StartupScript string   `json:"startup_script"` // script to execute after each startup
 	Pstore        bool     `json:"pstore"`         // use crashlogs from pstore
 	SystemSSHCfg  bool     `json:"system_ssh_cfg"` // whether to allow system-wide SSH configuration
+	// Crash (kdump) kernel image on the target, enables collection of kernel crash dumps.
+	// The tested kernel must be booted with crashkernel= to reserve memory for the crash kernel.
+	KdumpKernel string `json:"kdump_kernel"`
+	KdumpInitrd string `json:"kdump_initrd"` // initrd for the crash kernel on the target (optional)
 }
 
 type Pool struct {
@@ -81,6 +85,9 @@ func ctor(env *vmimpl.Env) (vmimpl.Pool, error) {
 			return nil, fmt.Errorf("the number of Targets and the number of USBDevNums should be same")
 		}
 	}
+	if cfg.Pstore && cfg.KdumpKernel != "" {
+		return nil, fmt.Errorf("pstore and kdump_kernel can't be used together")
+	}
 	pool := &Pool{
 		cfg: cfg,
 		env: env,
@@ -132,6 +139,15 @@ func (pool *Pool) Create(workdir string, index int) (vmimpl.Instance, error) {
 		inst.ssh(fmt.Sprintf("rm %v", pstoreConsoleFile))
 	}
 
+	if inst.cfg.KdumpKernel != "" {
+		ssh := func(args ...string) ([]byte, error) {
+			return nil, inst.ssh(strings.Join(args, " "))
+		}
+		if err := vmimpl.LoadCrashKernel(ssh, inst.cfg.KdumpKernel, inst.cfg.KdumpInitrd); err != nil {
+			return nil, err
+		}
+	}
+
 	closeInst = nil
 	return inst, nil
 }
@@ -352,6 +368,7 @@ func (inst *instance) Run(ctx context.Context, command string) (
 		tee = os.Stdout
 	}
 	merger := vmimpl.NewOutputMerger(tee)
+	merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	merger.Add("dmesg", dmesg)
 	merger.Add("ssh", rpipe)
 
@@ -380,6 +397,22 @@ func (inst *instance) readPstoreContents() ([]byte, error) {
 	return stdout.Bytes(), nil
 }
 
+func (inst *instance) CollectVmcore(ctx context.Context, dst string) (bool, error) {
+	if inst.cfg.KdumpKernel == "" {
+		return false, nil
+	}
+	if err := vmimpl.CollectVmcore(ctx, 10*time.Minute*inst.timeouts.Scale, inst.SSHOptions, nil,
+		inst.cfg.SystemSSHCfg, inst.debug, dst); err != nil {
+		return true, err
+	}
+	// The machine runs the crash kernel now, reboot it back into the tested kernel.
+	inst.ssh("reboot") // reboot will return an error, ignore it
+	if err := inst.waitRebootAndSSH(5*60, 30*time.Minute); err != nil {
+		return true, fmt.Errorf("failed to reboot after collecting vmcore: %w", err)
+	}
+	return true, nil
+}
+
 func (inst *instance) Diagnose(rep *report.Report) ([]byte, bool) {
 	if !inst.cfg.Pstore {
 		return nil, false
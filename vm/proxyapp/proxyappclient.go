@@ -479,6 +479,7 @@ func buildMerger(names ...string) (*vmimpl.OutputMerger, []io.Writer) {
 
 func (inst *instance) Run(ctx context.Context, command string) (<-chan []byte, <-chan error, error) {
 	merger, wPipes := buildMerger("stdout", "stderr", "console")
+	merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	receivedStdoutChunks := wPipes[0]
 	receivedStderrChunks := wPipes[1]
 	receivedConsoleChunks := wPipes[2]
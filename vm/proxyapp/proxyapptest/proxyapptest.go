@@ -0,0 +1,190 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package proxyapptest contains a conformance test suite for proxyapp VM providers.
+// Provider implementations can run it from their own tests to check that they behave
+// the way syz-manager expects, e.g.:
+//
+//	func TestConformance(t *testing.T) {
+//		cfg := []byte(`{"rpc_server_uri": "localhost:1234", "security": "none"}`)
+//		proxyapptest.TestProvider(t, cfg, "/path/to/image")
+//	}
+//
+// The suite assumes that the VMs provide a POSIX shell and the standard echo, sleep and cat utilities.
+package proxyapptest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/report"
+	_ "github.com/google/syzkaller/vm/proxyapp" // register the proxyapp VM type
+	"github.com/google/syzkaller/vm/vmimpl"
+)
+
+const (
+	// How long we wait for remaining output after a command has finished.
+	outputDrainTimeout = time.Second
+	// Upper bound on the duration of any single operation in the suite.
+	opTimeout = 2 * time.Minute
+)
+
+// TestProvider runs the conformance suite against the provider described by config.
+// The config has the format of the "vm" section of a manager config with "type": "proxyapp",
+// image is the kernel image file passed to the provider (the manager "image" config parameter).
+func TestProvider(t *testing.T, config []byte, image string) {
+	typ, ok := vmimpl.Types["proxyapp"]
+	if !ok {
+		t.Fatalf("proxyapp VM type is not registered")
+	}
+	pool, err := typ.Ctor(&vmimpl.Env{
+		Name:    "proxyapptest",
+		Workdir: t.TempDir(),
+		Image:   image,
+		Config:  config,
+	})
+	if err != nil {
+		t.Fatalf("failed to create pool: %v", err)
+	}
+	if closer, ok := pool.(io.Closer); ok {
+		t.Cleanup(func() { closer.Close() })
+	}
+	if pool.Count() <= 0 {
+		t.Fatalf("bad pool size %v", pool.Count())
+	}
+	inst, err := pool.Create(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("failed to create instance: %v", err)
+	}
+	t.Cleanup(func() { inst.Close() })
+
+	t.Run("Run", func(t *testing.T) {
+		output, err := runCommand(context.Background(), inst, "echo hello; echo world 1>&2")
+		if err != nil {
+			t.Fatalf("command failed: %v\n%s", err, output)
+		}
+		for _, want := range []string{"hello", "world"} {
+			if !bytes.Contains(output, []byte(want)) {
+				t.Errorf("output does not contain %q:\n%s", want, output)
+			}
+		}
+	})
+	t.Run("Copy", func(t *testing.T) {
+		script := filepath.Join(t.TempDir(), "proxyapptest.sh")
+		if err := osutil.WriteExecFile(script, []byte("#!/bin/sh\necho copied script $1\n")); err != nil {
+			t.Fatal(err)
+		}
+		vmScript, err := inst.Copy(script)
+		if err != nil {
+			t.Fatalf("failed to copy file: %v", err)
+		}
+		output, err := runCommand(context.Background(), inst, vmScript+" works")
+		if err != nil {
+			t.Fatalf("command failed: %v\n%s", err, output)
+		}
+		if !bytes.Contains(output, []byte("copied script works")) {
+			t.Errorf("unexpected output of the copied script:\n%s", output)
+		}
+	})
+	t.Run("Forward", func(t *testing.T) {
+		addr, err := inst.Forward(1234)
+		if err != nil {
+			t.Fatalf("failed to forward port: %v", err)
+		}
+		if addr == "" {
+			t.Fatalf("empty forwarded address")
+		}
+	})
+	t.Run("Timeout", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		start := time.Now()
+		output, err := runCommand(ctx, inst, "echo started; sleep 1000")
+		if !errors.Is(err, vmimpl.ErrTimeout) {
+			t.Fatalf("got error %v, want %v", err, vmimpl.ErrTimeout)
+		}
+		if time.Since(start) > opTimeout {
+			t.Errorf("the command was not stopped in time")
+		}
+		if !bytes.Contains(output, []byte("started")) {
+			t.Errorf("output before the timeout was lost:\n%s", output)
+		}
+		// The instance must be still usable after the stopped command.
+		output, err = runCommand(context.Background(), inst, "echo alive")
+		if err != nil || !bytes.Contains(output, []byte("alive")) {
+			t.Fatalf("instance is not usable after timeout: %v\n%s", err, output)
+		}
+	})
+	t.Run("Diagnose", func(t *testing.T) {
+		done := make(chan struct{})
+		go func() {
+			inst.Diagnose(&report.Report{Title: "proxyapptest"})
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(opTimeout):
+			t.Fatalf("Diagnose did not return in %v", opTimeout)
+		}
+	})
+	t.Run("Parallel", func(t *testing.T) {
+		inst1, err := pool.Create(t.TempDir(), pool.Count()-1)
+		if err != nil {
+			t.Fatalf("failed to create instance: %v", err)
+		}
+		defer inst1.Close()
+		errs := make(chan error, 2)
+		for i, inst := range []vmimpl.Instance{inst, inst1} {
+			go func() {
+				want := fmt.Sprintf("instance-%v", i)
+				output, err := runCommand(context.Background(), inst, "sleep 1; echo "+want)
+				if err == nil && !bytes.Contains(output, []byte(want)) {
+					err = fmt.Errorf("output does not contain %q:\n%s", want, output)
+				}
+				errs <- err
+			}()
+		}
+		for i := 0; i < 2; i++ {
+			if err := <-errs; err != nil {
+				t.Error(err)
+			}
+		}
+	})
+}
+
+// runCommand runs the command on the instance and returns its output.
+func runCommand(ctx context.Context, inst vmimpl.Instance, command string) ([]byte, error) {
+	outc, errc, err := inst.Run(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	output := new(bytes.Buffer)
+	deadline := time.After(opTimeout)
+	for {
+		select {
+		case out := <-outc:
+			output.Write(out)
+		case err := <-errc:
+			// The output channel is not closed when the command finishes,
+			// so collect whatever arrives shortly after that.
+			drain := time.After(outputDrainTimeout)
+			for {
+				select {
+				case out := <-outc:
+					output.Write(out)
+				case <-drain:
+					return output.Bytes(), err
+				}
+			}
+		case <-deadline:
+			return output.Bytes(), fmt.Errorf("the command did not finish in %v", opTimeout)
+		}
+	}
+}
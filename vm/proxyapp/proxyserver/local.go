@@ -0,0 +1,139 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package proxyserver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/vm/vmimpl"
+)
+
+// localPool runs commands as local processes, each instance gets own working directory.
+// It does not provide any isolation, so it's only suitable for testing of the proxyapp infrastructure
+// and for fuzzing of targets that don't need a VM (e.g. the test OS).
+type localPool struct {
+	count int
+}
+
+type localInstance struct {
+	dir  string
+	mu   sync.Mutex
+	cmds map[*exec.Cmd]localCmd
+}
+
+type localCmd struct {
+	command string
+	started time.Time
+}
+
+func (pool *localPool) Count() int {
+	return pool.count
+}
+
+func (pool *localPool) Create(workdir string, index int) (vmimpl.Instance, error) {
+	dir := filepath.Join(workdir, "root")
+	if err := osutil.MkdirAll(dir); err != nil {
+		return nil, err
+	}
+	return &localInstance{
+		dir:  dir,
+		cmds: make(map[*exec.Cmd]localCmd),
+	}, nil
+}
+
+func (pool *localPool) Close() error {
+	return nil
+}
+
+func (inst *localInstance) Copy(hostSrc string) (string, error) {
+	vmDst := filepath.Join(inst.dir, filepath.Base(hostSrc))
+	if err := osutil.CopyFile(hostSrc, vmDst); err != nil {
+		return "", err
+	}
+	return vmDst, nil
+}
+
+func (inst *localInstance) Forward(port int) (string, error) {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(port)), nil
+}
+
+func (inst *localInstance) Run(ctx context.Context, command string) (<-chan []byte, <-chan error, error) {
+	rpipe, wpipe, err := osutil.LongPipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Note: osutil.Command puts the command into a separate process group.
+	cmd := osutil.Command("sh", "-c", command)
+	cmd.Dir = inst.dir
+	cmd.Stdout = wpipe
+	cmd.Stderr = wpipe
+	if err := cmd.Start(); err != nil {
+		rpipe.Close()
+		wpipe.Close()
+		return nil, nil, err
+	}
+	wpipe.Close()
+	merger := vmimpl.NewOutputMerger(nil)
+	merger.Add("cmd", rpipe)
+	inst.mu.Lock()
+	inst.cmds[cmd] = localCmd{command, time.Now()}
+	inst.mu.Unlock()
+	errc := make(chan error, 1)
+	go func() {
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		var err error
+		select {
+		case <-ctx.Done():
+			killGroup(cmd)
+			<-done
+			err = vmimpl.ErrTimeout
+		case err = <-done:
+			// Kill any leftover background processes, otherwise they may hold the output pipe open.
+			killGroup(cmd)
+		}
+		inst.mu.Lock()
+		delete(inst.cmds, cmd)
+		inst.mu.Unlock()
+		merger.Wait()
+		errc <- err
+	}()
+	return merger.Output, errc, nil
+}
+
+func (inst *localInstance) Diagnose(rep *report.Report) ([]byte, bool) {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "%v running commands:\n", len(inst.cmds))
+	for cmd, info := range inst.cmds {
+		fmt.Fprintf(buf, "pid %v: running for %v: %v\n",
+			cmd.Process.Pid, time.Since(info.started).Round(time.Second), info.command)
+	}
+	return buf.Bytes(), false
+}
+
+func (inst *localInstance) Close() error {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	for cmd := range inst.cmds {
+		killGroup(cmd)
+	}
+	return nil
+}
+
+func killGroup(cmd *exec.Cmd) {
+	syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
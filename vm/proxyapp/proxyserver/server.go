@@ -0,0 +1,455 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+// Package proxyserver is a reference implementation of the proxyapp VM provider protocol (see vm/proxyapp).
+// It serves proxyrpc requests on top of a local process pool or any in-tree VM type (e.g. qemu).
+// It's meant to be used as a template for out-of-tree providers and as a reference for conformance testing.
+package proxyserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/syzkaller/pkg/config"
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/pkg/report"
+	"github.com/google/syzkaller/sys/targets"
+	"github.com/google/syzkaller/vm/proxyapp/proxyrpc"
+	"github.com/google/syzkaller/vm/vmimpl"
+)
+
+// Config is the provider-specific config passed in the "config" section of the proxyapp VM config.
+type Config struct {
+	// Type of the VM backend: "local" (default) runs commands as local processes,
+	// any other value is the name of an in-tree VM type (e.g. "qemu").
+	Type string `json:"type"`
+	// Number of instances for the local backend.
+	Count int `json:"count"`
+	// The following fields are used only for in-tree VM types
+	// and have the same meaning as the corresponding manager config fields.
+	Target    string          `json:"target"`
+	SSHKey    string          `json:"sshkey"`
+	SSHUser   string          `json:"ssh_user"`
+	KernelSrc string          `json:"kernel_src"`
+	VM        json.RawMessage `json:"vm"`
+}
+
+const (
+	typeLocal = "local"
+	// RunReadProgress/PoolLogs block for at most this long waiting for new data.
+	pollPeriod = time.Second
+	// Some backends never close the output channel (e.g. VM console),
+	// so after the command exits we wait that long for the remaining output.
+	outputDrainTimeout = time.Second
+)
+
+type Server struct {
+	workdir   string
+	rpc       *rpc.Server
+	logs      chan proxyrpc.PoolLogsReply
+	stop      chan struct{}
+	stopOnce  sync.Once
+	mu        sync.Mutex
+	pool      vmimpl.Pool
+	instances map[string]*instance
+	seq       int
+}
+
+type instance struct {
+	inst    vmimpl.Instance
+	workdir string
+	runs    map[string]*run
+}
+
+type run struct {
+	cancel   context.CancelFunc
+	updated  chan struct{}
+	mu       sync.Mutex
+	stdout   []byte
+	stderr   []byte
+	finished bool
+}
+
+// NewServer creates a server that keeps all instance files in workdir.
+func NewServer(workdir string) *Server {
+	s := &Server{
+		workdir:   workdir,
+		rpc:       rpc.NewServer(),
+		logs:      make(chan proxyrpc.PoolLogsReply, 1000),
+		stop:      make(chan struct{}),
+		instances: make(map[string]*instance),
+	}
+	if err := s.rpc.RegisterName("ProxyVM", struct{ proxyrpc.ProxyAppInterface }{s}); err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// Serve accepts connections on the listener and serves requests on them until the listener is closed.
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.ServeConn(conn)
+	}
+}
+
+// ServeConn serves requests on a single connection (e.g. stdin/stdout) until the connection is closed.
+func (s *Server) ServeConn(conn io.ReadWriteCloser) {
+	s.rpc.ServeCodec(jsonrpc.NewServerCodec(conn))
+}
+
+// Shutdown closes all instances and the pool and unblocks all pending requests.
+func (s *Server) Shutdown() {
+	s.stopOnce.Do(func() { close(s.stop) })
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, inst := range s.instances {
+		s.closeInstance(inst)
+		delete(s.instances, id)
+	}
+	if closer, ok := s.pool.(io.Closer); ok {
+		closer.Close()
+	}
+	s.pool = nil
+}
+
+func (s *Server) CreatePool(in proxyrpc.CreatePoolParams, out *proxyrpc.CreatePoolResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The client calls CreatePool after every reconnect, so we keep the pool for the server lifetime.
+	if s.pool == nil {
+		pool, err := s.createPool(in)
+		if err != nil {
+			return err
+		}
+		s.pool = pool
+	}
+	out.Count = s.pool.Count()
+	return nil
+}
+
+func (s *Server) createPool(in proxyrpc.CreatePoolParams) (vmimpl.Pool, error) {
+	cfg := &Config{
+		Type:  typeLocal,
+		Count: 1,
+	}
+	if in.Param != "" {
+		if err := config.LoadData([]byte(in.Param), cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse config: %w", err)
+		}
+	}
+	s.logf(0, "creating %v pool", cfg.Type)
+	if cfg.Type == typeLocal {
+		if cfg.Count <= 0 {
+			return nil, fmt.Errorf("bad local instance count %v", cfg.Count)
+		}
+		return &localPool{count: cfg.Count}, nil
+	}
+	typ, ok := vmimpl.Types[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown VM type %q", cfg.Type)
+	}
+	targetOS, targetArch, ok := strings.Cut(cfg.Target, "/")
+	target := targets.Get(targetOS, targetArch)
+	if !ok || target == nil {
+		return nil, fmt.Errorf("bad target %q", cfg.Target)
+	}
+	workdir := filepath.Join(s.workdir, "pool")
+	if err := osutil.MkdirAll(workdir); err != nil {
+		return nil, err
+	}
+	image := in.Image
+	if len(in.ImageData) != 0 {
+		image = filepath.Join(workdir, "image")
+		if err := osutil.WriteFile(image, in.ImageData); err != nil {
+			return nil, err
+		}
+	}
+	env := &vmimpl.Env{
+		Name:      "proxyapp",
+		OS:        targetOS,
+		Arch:      targetArch,
+		Workdir:   workdir,
+		Image:     image,
+		SSHKey:    cfg.SSHKey,
+		SSHUser:   cfg.SSHUser,
+		Timeouts:  target.Timeouts(1),
+		Debug:     in.Debug,
+		Config:    cfg.VM,
+		KernelSrc: cfg.KernelSrc,
+	}
+	return typ.Ctor(env)
+}
+
+func (s *Server) CreateInstance(in proxyrpc.CreateInstanceParams, out *proxyrpc.CreateInstanceResult) error {
+	s.mu.Lock()
+	pool := s.pool
+	s.seq++
+	id := fmt.Sprintf("vm%v-%v", in.Index, s.seq)
+	s.mu.Unlock()
+	if pool == nil {
+		return fmt.Errorf("the pool is not created")
+	}
+	if in.Index < 0 || in.Index >= pool.Count() {
+		return fmt.Errorf("bad instance index %v", in.Index)
+	}
+	// The manager may run on a different host, so we don't use in.Workdir directly.
+	workdir := filepath.Join(s.workdir, id)
+	if err := osutil.MkdirAll(workdir); err != nil {
+		return err
+	}
+	for name, data := range in.WorkdirData {
+		file := filepath.Join(workdir, filepath.Clean("/"+name))
+		if err := osutil.MkdirAll(filepath.Dir(file)); err != nil {
+			return err
+		}
+		if err := osutil.WriteFile(file, data); err != nil {
+			return err
+		}
+	}
+	inst, err := pool.Create(workdir, in.Index)
+	if err != nil {
+		os.RemoveAll(workdir)
+		return err
+	}
+	s.mu.Lock()
+	s.instances[id] = &instance{
+		inst:    inst,
+		workdir: workdir,
+		runs:    make(map[string]*run),
+	}
+	s.mu.Unlock()
+	s.logf(1, "created instance %v", id)
+	out.ID = id
+	return nil
+}
+
+func (s *Server) Copy(in proxyrpc.CopyParams, out *proxyrpc.CopyResult) error {
+	inst, err := s.getInstance(in.ID)
+	if err != nil {
+		return err
+	}
+	hostSrc := in.HostSrc
+	if in.Data != nil {
+		// The file is on the manager host, so we need to recreate it locally first.
+		hostSrc = filepath.Join(inst.workdir, filepath.Base(in.HostSrc))
+		if err := osutil.WriteExecFile(hostSrc, in.Data); err != nil {
+			return err
+		}
+	}
+	out.VMFileName, err = inst.inst.Copy(hostSrc)
+	return err
+}
+
+func (s *Server) Forward(in proxyrpc.ForwardParams, out *proxyrpc.ForwardResult) error {
+	inst, err := s.getInstance(in.ID)
+	if err != nil {
+		return err
+	}
+	out.ManagerAddress, err = inst.inst.Forward(in.Port)
+	return err
+}
+
+func (s *Server) RunStart(in proxyrpc.RunStartParams, out *proxyrpc.RunStartReply) error {
+	inst, err := s.getInstance(in.ID)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	outc, errc, err := inst.inst.Run(ctx, in.Command)
+	if err != nil {
+		cancel()
+		return err
+	}
+	r := &run{
+		cancel:  cancel,
+		updated: make(chan struct{}, 1),
+	}
+	go r.loop(outc, errc)
+	s.mu.Lock()
+	s.seq++
+	out.RunID = fmt.Sprintf("run%v", s.seq)
+	inst.runs[out.RunID] = r
+	s.mu.Unlock()
+	s.logf(1, "%v: started %v: %v", in.ID, out.RunID, in.Command)
+	return nil
+}
+
+func (s *Server) RunStop(in proxyrpc.RunStopParams, out *proxyrpc.RunStopReply) error {
+	r, err := s.getRun(in.ID, in.RunID, true)
+	if err != nil {
+		return err
+	}
+	r.cancel()
+	s.logf(1, "%v: stopped %v", in.ID, in.RunID)
+	return nil
+}
+
+func (s *Server) RunReadProgress(in proxyrpc.RunReadProgressParams, out *proxyrpc.RunReadProgressReply) error {
+	r, err := s.getRun(in.ID, in.RunID, false)
+	if err != nil {
+		return err
+	}
+	// The client calls RunReadProgress in a loop, so we wait for new data to avoid busy looping.
+	if !r.pending() {
+		select {
+		case <-r.updated:
+		case <-time.After(pollPeriod):
+		case <-s.stop:
+			return fmt.Errorf("the server is shutting down")
+		}
+	}
+	r.mu.Lock()
+	out.StdoutChunk = string(r.stdout)
+	out.StderrChunk = string(r.stderr)
+	out.Finished = r.finished
+	r.stdout, r.stderr = nil, nil
+	r.mu.Unlock()
+	if out.Finished {
+		// The run has finished on its own, release its context.
+		s.getRun(in.ID, in.RunID, true)
+		r.cancel()
+	}
+	return nil
+}
+
+func (s *Server) Diagnose(in proxyrpc.DiagnoseParams, out *proxyrpc.DiagnoseReply) error {
+	inst, err := s.getInstance(in.ID)
+	if err != nil {
+		return err
+	}
+	// If the backend asks to wait, the diagnosis will appear in the console output of the current run.
+	diagnosis, _ := inst.inst.Diagnose(&report.Report{Title: in.ReasonTitle})
+	out.Diagnosis = string(diagnosis)
+	return nil
+}
+
+func (s *Server) Close(in proxyrpc.CloseParams, out *proxyrpc.CloseReply) error {
+	s.mu.Lock()
+	inst := s.instances[in.ID]
+	delete(s.instances, in.ID)
+	if inst != nil {
+		s.closeInstance(inst)
+	}
+	s.mu.Unlock()
+	if inst == nil {
+		return fmt.Errorf("unknown instance %q", in.ID)
+	}
+	s.logf(1, "closed instance %v", in.ID)
+	return nil
+}
+
+func (s *Server) PoolLogs(in proxyrpc.PoolLogsParam, out *proxyrpc.PoolLogsReply) error {
+	// The client polls logs in a loop, so we block until there is something to return.
+	select {
+	case msg := <-s.logs:
+		*out = msg
+		return nil
+	case <-s.stop:
+		return fmt.Errorf("the server is shutting down")
+	}
+}
+
+func (s *Server) closeInstance(inst *instance) {
+	for _, r := range inst.runs {
+		r.cancel()
+	}
+	inst.inst.Close()
+	os.RemoveAll(inst.workdir)
+}
+
+func (s *Server) getInstance(id string) (*instance, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inst := s.instances[id]
+	if inst == nil {
+		return nil, fmt.Errorf("unknown instance %q", id)
+	}
+	return inst, nil
+}
+
+func (s *Server) getRun(id, runID string, remove bool) (*run, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inst := s.instances[id]
+	if inst == nil {
+		return nil, fmt.Errorf("unknown instance %q", id)
+	}
+	r := inst.runs[runID]
+	if r == nil {
+		return nil, fmt.Errorf("unknown run %q", runID)
+	}
+	if remove {
+		delete(inst.runs, runID)
+	}
+	return r, nil
+}
+
+// logf logs the message locally and sends it to the client.
+func (s *Server) logf(v int, msg string, args ...interface{}) {
+	log.Logf(v, msg, args...)
+	select {
+	case s.logs <- proxyrpc.PoolLogsReply{Log: fmt.Sprintf(msg, args...) + "\n", Verbosity: v}:
+	default:
+	}
+}
+
+func (r *run) loop(outc <-chan []byte, errc <-chan error) {
+	var err error
+	var drain <-chan time.Time
+	for outc != nil || errc != nil {
+		select {
+		case out, ok := <-outc:
+			if !ok {
+				outc = nil
+				continue
+			}
+			r.append(out, nil, false)
+		case err = <-errc:
+			errc = nil
+			drain = time.After(outputDrainTimeout)
+		case <-drain:
+			outc = nil
+		}
+	}
+	var stderr []byte
+	if err != nil && err != vmimpl.ErrTimeout {
+		// The client treats RunReadProgressReply.Error as an infrastructure error,
+		// while this is just a failed command (e.g. lost connection to the VM).
+		stderr = []byte(fmt.Sprintf("command failed: %v\n", err))
+	}
+	r.append(nil, stderr, true)
+}
+
+func (r *run) append(stdout, stderr []byte, finished bool) {
+	r.mu.Lock()
+	// vmimpl merges all output streams, so we return everything as stdout.
+	r.stdout = append(r.stdout, stdout...)
+	r.stderr = append(r.stderr, stderr...)
+	r.finished = r.finished || finished
+	r.mu.Unlock()
+	select {
+	case r.updated <- struct{}{}:
+	default:
+	}
+}
+
+func (r *run) pending() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.stdout) != 0 || len(r.stderr) != 0 || r.finished
+}
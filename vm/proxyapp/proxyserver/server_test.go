@@ -0,0 +1,46 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package proxyserver
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/google/syzkaller/pkg/osutil"
+	"github.com/google/syzkaller/vm/proxyapp/proxyapptest"
+)
+
+func TestLocalProvider(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skipf("local pool is tested only on linux")
+	}
+	srv := NewServer(t.TempDir())
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.Serve(l)
+	t.Cleanup(func() {
+		l.Close()
+		srv.Shutdown()
+	})
+	// The local pool does not use the image, but the client sends it in the file transfer mode.
+	image := filepath.Join(t.TempDir(), "image")
+	if err := osutil.WriteFile(image, []byte("image")); err != nil {
+		t.Fatal(err)
+	}
+	for _, transfer := range []bool{false, true} {
+		t.Run(fmt.Sprintf("transfer=%v", transfer), func(t *testing.T) {
+			proxyapptest.TestProvider(t, []byte(fmt.Sprintf(`{
+				"rpc_server_uri": %q,
+				"security": "none",
+				"transfer_file_content": %v,
+				"config": {"type": "local", "count": 2}
+			}`, l.Addr().String(), transfer)), image)
+		})
+	}
+}
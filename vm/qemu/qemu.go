@@ -29,8 +29,9 @@ import (
 func init() {
 	var _ vmimpl.Infoer = (*instance)(nil)
 	vmimpl.Register("qemu", vmimpl.Type{
-		Ctor:       ctor,
-		Overcommit: true,
+		Ctor:         ctor,
+		Overcommit:   true,
+		LiveDiagnose: true,
 	})
 }
 
@@ -77,6 +78,10 @@ type Config struct {
 	Snapshot bool `json:"snapshot"`
 	// Magic key used to dongle macOS to the device.
 	AppleSmcOsk string `json:"apple_smc_osk"`
+	// Load the kernel as the crash (kdump) kernel after boot and collect kernel crash dumps
+	// (/proc/vmcore) after kernel panics. Requires kernel and kexec-tools in the image.
+	// Memory for the crash kernel is reserved on the kernel command line automatically.
+	Kdump bool `json:"kdump"`
 }
 
 type Pool struct {
@@ -301,6 +306,9 @@ func ctor(env *vmimpl.Env) (vmimpl.Pool, error) {
 	if cfg.Mem < 128 || cfg.Mem > 1048576 {
 		return nil, fmt.Errorf("bad qemu mem: %v, want [128-1048576]", cfg.Mem)
 	}
+	if cfg.Kdump && (cfg.Kernel == "" || env.OS != targets.Linux) {
+		return nil, fmt.Errorf("kdump requires kernel and is supported for linux only")
+	}
 	cfg.Kernel = osutil.Abs(cfg.Kernel)
 	cfg.Initrd = osutil.Abs(cfg.Initrd)
 
@@ -488,9 +496,29 @@ func (inst *instance) boot() error {
 		return vmimpl.MakeBootError(err, bootOutput)
 	}
 	bootOutputStop <- true
+	if inst.cfg.Kdump && inst.snapshot == nil {
+		if err := inst.loadCrashKernel(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (inst *instance) loadCrashKernel() error {
+	kernel, err := inst.Copy(inst.cfg.Kernel)
+	if err != nil {
+		return err
+	}
+	initrd := ""
+	if inst.cfg.Initrd != "" {
+		initrd, err = inst.Copy(inst.cfg.Initrd)
+		if err != nil {
+			return err
+		}
+	}
+	return vmimpl.LoadCrashKernel(inst.ssh, kernel, initrd)
+}
+
 func (inst *instance) buildQemuArgs() ([]string, error) {
 	args := []string{
 		"-m", strconv.Itoa(inst.cfg.Mem),
@@ -552,6 +580,9 @@ func (inst *instance) buildQemuArgs() ([]string, error) {
 				"init="+filepath.Join(inst.workdir, "init.sh"),
 			)
 		}
+		if inst.cfg.Kdump {
+			cmdline = append(cmdline, vmimpl.KdumpCmdline)
+		}
 		cmdline = append(cmdline, inst.cfg.Cmdline)
 		args = append(args,
 			"-kernel", inst.cfg.Kernel,
@@ -674,6 +705,7 @@ func (inst *instance) Run(ctx context.Context, command string) (
 	if err != nil {
 		return nil, nil, err
 	}
+	inst.merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	inst.merger.Add("ssh", rpipe)
 
 	sshArgs := vmimpl.SSHArgsForward(inst.debug, inst.Key, inst.Port, inst.forwardPort, false)
@@ -719,6 +751,14 @@ func (inst *instance) Info() ([]byte, error) {
 	return []byte(info), nil
 }
 
+func (inst *instance) CollectVmcore(ctx context.Context, dst string) (bool, error) {
+	if !inst.cfg.Kdump || inst.snapshot != nil {
+		return false, nil
+	}
+	return true, vmimpl.CollectVmcore(ctx, 10*time.Minute*inst.timeouts.Scale, inst.SSHOptions,
+		inst.merger.Err, false, inst.debug, dst)
+}
+
 func (inst *instance) Diagnose(rep *report.Report) ([]byte, bool) {
 	if inst.target.OS == targets.Linux {
 		if output, wait, handled := vmimpl.DiagnoseLinux(rep, inst.ssh); handled {
@@ -746,7 +786,7 @@ func (inst *instance) ssh(args ...string) ([]byte, error) {
 }
 
 func (inst *instance) sshArgs(args ...string) []string {
-	sshArgs := append(vmimpl.SSHArgs(inst.debug, inst.User, inst.Port, false), inst.User+"@localhost")
+	sshArgs := append(vmimpl.SSHArgs(inst.debug, inst.Key, inst.Port, false), inst.User+"@localhost")
 	return append(sshArgs, args...)
 }
 
@@ -14,6 +14,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
@@ -256,6 +257,20 @@ const (
 type InjectExecuting <-chan bool
 type OutputSize int
 
+// DiagnoseHang notifies Run that something in the VM is hanging (e.g. a test program call
+// is executing for too long), but the kernel has not reported anything yet.
+// On the first notification Run proactively collects diagnostics from the live VM
+// (e.g. stacks of all tasks), so that they end up in the report if the VM does not recover.
+type DiagnoseHang <-chan bool
+
+// RecordOutput makes Run save all console output and events to the recorder,
+// the recording can be replayed with Replay.
+// VM types that merge output of several sources record each source to a separate stream,
+// for the rest the merged output is recorded.
+type RecordOutput struct {
+	*vmimpl.Recorder
+}
+
 // An early notification that the command has finished / VM crashed.
 type EarlyFinishCb func()
 
@@ -265,11 +280,15 @@ type EarlyFinishCb func()
 // Accepted options:
 //   - ExitCondition: says which exit modes should be considered as errors/OK
 //   - OutputSize: how much output to keep/return
+//   - InjectExecuting: notifications about programs started executing
+//   - DiagnoseHang: notifications about hanging programs
 func (inst *Instance) Run(ctx context.Context, reporter *report.Reporter, command string, opts ...any) (
 	[]byte, *report.Report, error) {
 	exit := ExitNormal
 	var injected <-chan bool
+	var hanging <-chan bool
 	var finished func()
+	var recorder *vmimpl.Recorder
 	outputSize := beforeContextDefault
 	for _, o := range opts {
 		switch opt := o.(type) {
@@ -279,12 +298,19 @@ func (inst *Instance) Run(ctx context.Context, reporter *report.Reporter, comman
 			outputSize = int(opt)
 		case InjectExecuting:
 			injected = opt
+		case DiagnoseHang:
+			hanging = opt
 		case EarlyFinishCb:
 			finished = opt
+		case RecordOutput:
+			recorder = opt.Recorder
 		default:
 			panic(fmt.Sprintf("unknown option %#v", opt))
 		}
 	}
+	if recorder != nil {
+		ctx = vmimpl.WithRecorder(ctx, recorder)
+	}
 	outc, errc, err := inst.impl.Run(ctx, command)
 	if err != nil {
 		return nil, nil, err
@@ -293,9 +319,12 @@ func (inst *Instance) Run(ctx context.Context, reporter *report.Reporter, comman
 		inst:            inst,
 		outc:            outc,
 		injected:        injected,
+		hanging:         hanging,
 		errc:            errc,
 		finished:        finished,
 		reporter:        reporter,
+		recorder:        recorder,
+		recordOutput:    recorder != nil && !recorder.RecordsSources(),
 		beforeContext:   outputSize,
 		exit:            exit,
 		lastExecuteTime: time.Now(),
@@ -304,6 +333,159 @@ func (inst *Instance) Run(ctx context.Context, reporter *report.Reporter, comman
 	return mon.output, rep, nil
 }
 
+// Replay feeds a recording made with RecordOutput through the same crash detection logic
+// that Run uses, and returns the same results Run would return for the recorded session.
+// Events are replayed as fast as possible, so "no output" hangs that were detected
+// by timing in the original session are not detected during replay.
+// Diagnostics of hanging programs (see DiagnoseHang) are replayed at the same points of the output.
+// A hang diagnosis that was still pending when a crash was detected in the output is not replayed.
+// Accepted options: ExitCondition, OutputSize.
+func Replay(cfg *mgrconfig.Config, reporter *report.Reporter, recording []vmimpl.RecordedChunk,
+	opts ...any) ([]byte, *report.Report) {
+	exit := ExitNormal
+	outputSize := beforeContextDefault
+	for _, o := range opts {
+		switch opt := o.(type) {
+		case ExitCondition:
+			exit = opt
+		case OutputSize:
+			outputSize = int(opt)
+		default:
+			panic(fmt.Sprintf("unknown option %#v", opt))
+		}
+	}
+	recording = startPendingHangs(recording)
+	replay := &replayInstance{}
+	exited := false
+	for _, chunk := range recording {
+		switch chunk.Stream {
+		case recordDiagnose:
+			replay.diagnose = append(replay.diagnose, chunk.Data)
+		case recordHang:
+			replay.hang = append(replay.hang, chunk.Data)
+		case recordExit:
+			exited = true
+		}
+	}
+	if !exited {
+		// The session was terminated by the monitor itself (e.g. a crash was detected).
+		// If we don't detect the same crash now, finish normally when the recording ends.
+		exit |= ExitNormal
+	}
+	// All channels are unbuffered to preserve the original order of events.
+	outc := make(chan []byte)
+	errc := make(chan error)
+	injected := make(chan bool)
+	hanging := make(chan bool)
+	done := make(chan bool)
+	go func() {
+		defer close(outc)
+		for _, chunk := range recording {
+			var err error
+			if vmimpl.IsSourceStream(chunk.Stream) {
+				chunk.Stream = recordOutput
+			}
+			switch chunk.Stream {
+			case recordOutput:
+				select {
+				case outc <- chunk.Data:
+				case <-done:
+					return
+				}
+			case recordExecuting:
+				select {
+				case injected <- true:
+				case <-done:
+					return
+				}
+			case recordHang:
+				select {
+				case hanging <- true:
+				case <-done:
+					return
+				}
+			case recordExit:
+				if len(chunk.Data) != 0 {
+					err = errors.New(string(chunk.Data))
+					if err.Error() == ErrTimeout.Error() {
+						err = ErrTimeout
+					}
+				}
+				select {
+				case errc <- err:
+				case <-done:
+					return
+				}
+			}
+		}
+		if !exited {
+			select {
+			case errc <- nil:
+			case <-done:
+			}
+		}
+	}()
+	timeouts := cfg.Timeouts
+	timeouts.Scale = max(timeouts.Scale, 1)
+	timeouts.NoOutput = time.Duration(math.MaxInt64)
+	mon := &monitor{
+		inst: &Instance{
+			pool: &Pool{
+				typ:                vmimpl.Types[vmType(cfg.Type)],
+				timeouts:           timeouts,
+				statOutputReceived: new(stat.Val),
+			},
+			impl: replay,
+		},
+		outc:            outc,
+		injected:        injected,
+		hanging:         hanging,
+		errc:            errc,
+		reporter:        reporter,
+		beforeContext:   outputSize,
+		exit:            exit,
+		lastExecuteTime: time.Now(),
+	}
+	rep := mon.monitorExecution()
+	close(done)
+	return mon.output, rep
+}
+
+// startPendingHangs moves hang diagnoses that were still pending when the session exited
+// before the exit. Such diagnoses are recorded by extractError after the exit,
+// but they were started earlier, and extractError must find them pending during replay as well.
+func startPendingHangs(recording []vmimpl.RecordedChunk) []vmimpl.RecordedChunk {
+	res := append([]vmimpl.RecordedChunk{}, recording...)
+	for i := 1; i < len(res); i++ {
+		if res[i].Stream == recordHang && res[i-1].Stream == recordExit {
+			res[i-1], res[i] = res[i], res[i-1]
+		}
+	}
+	return res
+}
+
+// replayInstance returns recorded Diagnose output during Replay.
+// Output that Diagnose originally printed to the console is replayed as part of the console output,
+// so we always ask the monitor to wait for it.
+type replayInstance struct {
+	vmimpl.Instance
+	diagnose [][]byte
+	hang     [][]byte
+}
+
+func (inst *replayInstance) Diagnose(rep *report.Report) ([]byte, bool) {
+	queue := &inst.diagnose
+	if rep.Title == vmimpl.HangTitle {
+		queue = &inst.hang
+	}
+	if len(*queue) == 0 {
+		return nil, true
+	}
+	diag := (*queue)[0]
+	*queue = (*queue)[1:]
+	return diag, true
+}
+
 func (inst *Instance) Info() ([]byte, error) {
 	if ii, ok := inst.impl.(vmimpl.Infoer); ok {
 		return ii.Info()
@@ -311,6 +493,15 @@ func (inst *Instance) Info() ([]byte, error) {
 	return nil, nil
 }
 
+// CollectVmcore retrieves the kernel crash dump after a kernel panic into the dst file on the host.
+// Returns false if the VM type does not support kdump, or it's not enabled.
+func (inst *Instance) CollectVmcore(ctx context.Context, dst string) (bool, error) {
+	if vc, ok := inst.impl.(vmimpl.VmcoreCollector); ok {
+		return vc.CollectVmcore(ctx, dst)
+	}
+	return false, nil
+}
+
 func (inst *Instance) diagnose(rep *report.Report) ([]byte, bool) {
 	if rep == nil {
 		panic("rep is nil")
@@ -341,15 +532,20 @@ type monitor struct {
 	inst            *Instance
 	outc            <-chan []byte
 	injected        <-chan bool
+	hanging         <-chan bool
 	finished        func()
 	errc            <-chan error
 	reporter        *report.Reporter
+	recorder        *vmimpl.Recorder
+	recordOutput    bool // the VM type does not record its output sources itself
 	exit            ExitCondition
 	output          []byte
 	beforeContext   int
 	matchPos        int
 	lastExecuteTime time.Time
 	extractCalled   bool
+	hangDiagnosed   bool
+	hangDiag        chan []byte
 }
 
 func (mon *monitor) monitorExecution() *report.Report {
@@ -363,6 +559,7 @@ func (mon *monitor) monitorExecution() *report.Report {
 	for {
 		select {
 		case err := <-mon.errc:
+			mon.recordExit(err)
 			switch err {
 			case nil:
 				// The program has exited without errors,
@@ -396,7 +593,15 @@ func (mon *monitor) monitorExecution() *report.Report {
 				return rep
 			}
 		case <-mon.injected:
+			mon.recorder.Record(recordExecuting, nil)
 			mon.lastExecuteTime = time.Now()
+		case <-mon.hanging:
+			mon.diagnoseHang()
+		case diagOutput := <-mon.hangDiag:
+			mon.hangDiag = nil
+			if rep, done := mon.appendHangDiagnosis(diagOutput); done {
+				return rep
+			}
 		case <-ticker.C:
 			// Detect both "no output whatsoever" and "kernel episodically prints
 			// something to console, but fuzzer is not actually executing programs".
@@ -409,7 +614,22 @@ func (mon *monitor) monitorExecution() *report.Report {
 	}
 }
 
+func (mon *monitor) recordExit(err error) {
+	var data []byte
+	if err != nil {
+		data = []byte(err.Error())
+	}
+	mon.recorder.Record(recordExit, data)
+}
+
 func (mon *monitor) appendOutput(out []byte) (*report.Report, bool) {
+	if mon.recordOutput {
+		mon.recorder.Record(recordOutput, out)
+	}
+	return mon.processOutput(out)
+}
+
+func (mon *monitor) processOutput(out []byte) (*report.Report, bool) {
 	lastPos := len(mon.output)
 	mon.output = append(mon.output, out...)
 	if bytes.Contains(mon.output[lastPos:], executingProgram) {
@@ -449,9 +669,12 @@ func (mon *monitor) extractError(defaultError string) *report.Report {
 		mon.finished()
 		mon.finished = nil
 	}
+	// Diagnose is not reentrant, so the instance can be diagnosed again only after
+	// the pending hang diagnosis has finished.
+	canDiagnose := mon.waitHangDiagnosis()
 	diagOutput, diagWait := []byte{}, false
-	if defaultError != "" {
-		diagOutput, diagWait = mon.inst.diagnose(mon.createReport(defaultError))
+	if defaultError != "" && canDiagnose {
+		diagOutput, diagWait = mon.diagnose(defaultError)
 	}
 	// Give it some time to finish writing the error message.
 	// But don't wait for "no output", we already waited enough.
@@ -463,9 +686,9 @@ func (mon *monitor) extractError(defaultError string) *report.Report {
 	if mon.inst.pool.typ.Preemptible && bytes.Contains(mon.output, []byte(executorPreemptedStr)) {
 		return nil
 	}
-	if defaultError == "" && mon.reporter.ContainsCrash(mon.output[mon.matchPos:]) {
+	if defaultError == "" && canDiagnose && mon.reporter.ContainsCrash(mon.output[mon.matchPos:]) {
 		// We did not call Diagnose above because we thought there is no error, so call it now.
-		diagOutput, diagWait = mon.inst.diagnose(mon.createReport(defaultError))
+		diagOutput, diagWait = mon.diagnose(defaultError)
 		if diagWait {
 			mon.waitForOutput()
 		}
@@ -481,6 +704,71 @@ func (mon *monitor) extractError(defaultError string) *report.Report {
 	return rep
 }
 
+func (mon *monitor) diagnose(defaultError string) ([]byte, bool) {
+	diagOutput, diagWait := mon.inst.diagnose(mon.createReport(defaultError))
+	mon.recorder.Record(recordDiagnose, diagOutput)
+	return diagOutput, diagWait
+}
+
+// diagnoseHang starts collecting diagnostics from a live VM where something is hanging.
+// Diagnosis can take a while (e.g. it's done over ssh), so it runs asynchronously
+// to not delay processing of the console output, and the result is sent to hangDiag.
+func (mon *monitor) diagnoseHang() {
+	if mon.hangDiagnosed || !mon.inst.pool.typ.LiveDiagnose {
+		return
+	}
+	mon.hangDiagnosed = true
+	mon.hangDiag = make(chan []byte, 1)
+	go func() {
+		diagOutput, _ := mon.inst.diagnose(&report.Report{
+			Title: vmimpl.HangTitle,
+			Type:  crash.Hang,
+		})
+		mon.hangDiag <- diagOutput
+	}()
+}
+
+// appendHangDiagnosis handles the result of diagnoseHang.
+// For most VM types diagnosis output goes to the console, but if it's returned directly,
+// we append it to the console output.
+func (mon *monitor) appendHangDiagnosis(diagOutput []byte) (*report.Report, bool) {
+	out := mon.hangDiagnosisOutput(diagOutput)
+	if len(out) == 0 {
+		return nil, false
+	}
+	return mon.processOutput(out)
+}
+
+func (mon *monitor) hangDiagnosisOutput(diagOutput []byte) []byte {
+	// The output is replayed by Replay as the result of Diagnose, so it's not recorded as output.
+	mon.recorder.Record(recordHang, diagOutput)
+	if len(diagOutput) == 0 {
+		return nil
+	}
+	return append([]byte(vmDiagnosisStart), diagOutput...)
+}
+
+// waitHangDiagnosis waits for the pending diagnosis started by diagnoseHang (if any)
+// and appends its output. Diagnose can't be cancelled, so if it does not finish
+// in hangDiagnosisTimeout, it keeps running in the background and false is returned.
+func (mon *monitor) waitHangDiagnosis() bool {
+	if mon.hangDiag == nil {
+		return true
+	}
+	timer := time.NewTimer(hangDiagnosisTimeout * mon.inst.pool.timeouts.Scale)
+	defer timer.Stop()
+	select {
+	case diagOutput := <-mon.hangDiag:
+		mon.hangDiag = nil
+		mon.output = append(mon.output, mon.hangDiagnosisOutput(diagOutput)...)
+		return true
+	case <-timer.C:
+		return false
+	case <-Shutdown:
+		return false
+	}
+}
+
 func (mon *monitor) createReport(defaultError string) *report.Report {
 	rep := mon.reporter.ParseFrom(mon.output, mon.matchPos)
 	if rep == nil {
@@ -515,6 +803,9 @@ func (mon *monitor) waitForOutput() {
 			if !ok {
 				return
 			}
+			if mon.recordOutput {
+				mon.recorder.Record(recordOutput, out)
+			}
 			mon.output = append(mon.output, out...)
 		case <-timer.C:
 			return
@@ -532,6 +823,13 @@ const (
 	timeoutCrash         = "timed out"
 	executorPreemptedStr = "SYZ-EXECUTOR: PREEMPTED"
 	vmDiagnosisStart     = "\nVM DIAGNOSIS:\n"
+
+	// Stream names used by RecordOutput.
+	recordOutput    = "output"
+	recordExecuting = "executing"
+	recordDiagnose  = "diagnose"
+	recordHang      = "hang"
+	recordExit      = "exit"
 )
 
 var (
@@ -541,4 +839,6 @@ var (
 	afterContext         = 128 << 10
 
 	tickerPeriod = 10 * time.Second
+	// hangDiagnosisTimeout is how long a crash report waits for the pending diagnosis of a hang.
+	hangDiagnosisTimeout = time.Minute
 )
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -40,6 +41,10 @@ type testInstance struct {
 	errc           chan error
 	diagnoseBug    bool
 	diagnoseNoWait bool
+	hangDelay      time.Duration
+	// diagnosing is the number of the running Diagnose calls, reentered is set if it exceeded 1.
+	diagnosing atomic.Int32
+	reentered  atomic.Bool
 }
 
 func (inst *testInstance) Copy(hostSrc string) (string, error) {
@@ -56,6 +61,13 @@ func (inst *testInstance) Run(ctx context.Context, command string) (
 }
 
 func (inst *testInstance) Diagnose(rep *report.Report) ([]byte, bool) {
+	if inst.diagnosing.Add(1) > 1 {
+		inst.reentered.Store(true)
+	}
+	defer inst.diagnosing.Add(-1)
+	if rep.Title == vmimpl.HangTitle {
+		time.Sleep(inst.hangDelay)
+	}
 	var diag []byte
 	if inst.diagnoseBug {
 		diag = []byte("BUG: DIAGNOSE\n")
@@ -84,8 +96,9 @@ func init() {
 		return &testPool{}, nil
 	}
 	vmimpl.Register("test", vmimpl.Type{
-		Ctor:        ctor,
-		Preemptible: true,
+		Ctor:         ctor,
+		Preemptible:  true,
+		LiveDiagnose: true,
 	})
 }
 
@@ -94,8 +107,10 @@ type Test struct {
 	Exit           ExitCondition
 	DiagnoseBug    bool // Diagnose produces output that is detected as kernel crash.
 	DiagnoseNoWait bool // Diagnose returns output directly rather than to console.
+	HangDelay      time.Duration
 	Body           func(outc chan []byte, errc chan error)
 	BodyExecuting  func(outc chan []byte, errc chan error, inject chan<- bool)
+	BodyHanging    func(outc chan []byte, errc chan error, hanging chan<- bool)
 	Report         *report.Report
 }
 
@@ -334,6 +349,64 @@ var tests = []*Test{
 			errc <- nil
 		},
 	},
+	{
+		Name: "diagnose-hang",
+		BodyHanging: func(outc chan []byte, errc chan error, hanging chan<- bool) {
+			outc <- []byte("executing program\n")
+			hanging <- true
+			hanging <- true
+			time.Sleep(time.Second)
+			errc <- nil
+		},
+		Report: &report.Report{
+			Title: lostConnectionCrash,
+			Output: []byte(
+				"executing program\n" +
+					"DIAGNOSE\n" +
+					"DIAGNOSE\n",
+			),
+			Type: crash.LostConnection,
+		},
+	},
+	{
+		Name:      "diagnose-hang-pending-on-exit",
+		HangDelay: time.Second,
+		BodyHanging: func(outc chan []byte, errc chan error, hanging chan<- bool) {
+			outc <- []byte("executing program\n")
+			hanging <- true
+			time.Sleep(100 * time.Millisecond)
+			errc <- nil
+		},
+		Report: &report.Report{
+			Title: lostConnectionCrash,
+			Output: []byte(
+				"executing program\n" +
+					"DIAGNOSE\n" +
+					"DIAGNOSE\n",
+			),
+			Type: crash.LostConnection,
+		},
+	},
+	{
+		Name:           "diagnose-hang-pending-on-exit-no-wait",
+		DiagnoseNoWait: true,
+		HangDelay:      time.Second,
+		BodyHanging: func(outc chan []byte, errc chan error, hanging chan<- bool) {
+			outc <- []byte("executing program\n")
+			hanging <- true
+			time.Sleep(100 * time.Millisecond)
+			errc <- nil
+		},
+		Report: &report.Report{
+			Title: lostConnectionCrash,
+			Output: []byte(
+				"executing program\n" +
+					vmDiagnosisStart + "DIAGNOSE\n" +
+					vmDiagnosisStart + "DIAGNOSE\n",
+			),
+			Type: crash.LostConnection,
+		},
+	},
 }
 
 func TestMonitorExecution(t *testing.T) {
@@ -379,14 +452,23 @@ func testMonitorExecution(t *testing.T, test *Test) {
 	testInst := inst.impl.(*testInstance)
 	testInst.diagnoseBug = test.DiagnoseBug
 	testInst.diagnoseNoWait = test.DiagnoseNoWait
+	testInst.hangDelay = test.HangDelay
 	done := make(chan bool)
 	finishCalled := 0
 	finishCb := EarlyFinishCb(func() { finishCalled++ })
-	opts := []any{test.Exit, finishCb}
+	recording := new(bytes.Buffer)
+	recorder := vmimpl.NewRecorder(recording)
+	opts := []any{test.Exit, finishCb, RecordOutput{recorder}}
 	var inject chan bool
 	if test.BodyExecuting != nil {
 		inject = make(chan bool, 10)
 		opts = append(opts, InjectExecuting(inject))
+	} else if test.BodyHanging != nil {
+		hanging := make(chan bool, 10)
+		opts = append(opts, DiagnoseHang(hanging))
+		test.BodyExecuting = func(outc chan []byte, errc chan error, inject chan<- bool) {
+			test.BodyHanging(outc, errc, hanging)
+		}
 	} else {
 		test.BodyExecuting = func(outc chan []byte, errc chan error, inject chan<- bool) {
 			test.Body(outc, errc)
@@ -401,9 +483,29 @@ func testMonitorExecution(t *testing.T, test *Test) {
 		t.Fatal(err)
 	}
 	<-done
+	if testInst.reentered.Load() {
+		t.Fatalf("Diagnose was called while another Diagnose was running")
+	}
 	if finishCalled != 1 {
 		t.Fatalf("finish callback is called %v times", finishCalled)
 	}
+	checkMonitorReport(t, test, rep)
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if test.Report != nil && test.Report.Title == noOutputCrash {
+		// Replay does not reproduce timing-based hang detection.
+		return
+	}
+	chunks, err := vmimpl.ReadRecording(recording.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, rep = Replay(cfg, reporter, chunks, test.Exit)
+	checkMonitorReport(t, test, rep)
+}
+
+func checkMonitorReport(t *testing.T, test *Test, rep *report.Report) {
 	if test.Report != nil && rep == nil {
 		t.Fatalf("got no report")
 	}
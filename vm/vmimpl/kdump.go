@@ -0,0 +1,97 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package vmimpl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/syzkaller/pkg/log"
+	"github.com/google/syzkaller/pkg/osutil"
+)
+
+// KdumpCmdline reserves memory for the crash kernel, it needs to be present on the kernel command line.
+const KdumpCmdline = "crashkernel=256M"
+
+// crashKernelCmdline is appended to the command line of the crashed kernel for the crash kernel.
+// The crash kernel runs in a small reserved memory region on a possibly broken hardware state.
+const crashKernelCmdline = "irqpoll nr_cpus=1 reset_devices"
+
+// LoadCrashKernel loads the kernel image that is present on the target as the crash kernel,
+// so that the kernel boots into it on panic. Requires kexec-tools on the target.
+func LoadCrashKernel(ssh func(args ...string) ([]byte, error), kernel, initrd string) error {
+	// Note: kexec drops crashkernel= from the reused command line.
+	args := []string{"kexec", "-p", kernel, "--reuse-cmdline", "--append='" + crashKernelCmdline + "'"}
+	if initrd != "" {
+		args = append(args, "--initrd="+initrd)
+	}
+	if output, err := ssh(args...); err != nil {
+		return fmt.Errorf("failed to load crash kernel: %w\n%s", err, output)
+	}
+	return nil
+}
+
+// sshConnectionError is the exit status of ssh when it fails to connect
+// (as opposed to the exit status of the remote command).
+const sshConnectionError = 255
+
+// CollectVmcore waits for the crash kernel to boot and copies /proc/vmcore to the dst file on the host.
+// If the crashed kernel is still alive (e.g. the crash was a task hang or a warning that did not cause
+// a panic), the panic is forced with sysrq-c. stop, if not nil, signals that the machine has stopped.
+func CollectVmcore(ctx context.Context, timeout time.Duration, opts SSHOptions, stop <-chan error,
+	systemSSHCfg, debug bool, dst string) error {
+	sshArgs := append(SSHArgs(debug, opts.Key, opts.Port, systemSSHCfg), opts.User+"@"+opts.Addr)
+	ssh := func(command string) error {
+		_, err := osutil.RunCmd(time.Minute, "", "ssh", append(sshArgs, command)...)
+		return err
+	}
+	start := time.Now()
+	forced := false
+	for {
+		select {
+		case <-time.After(5 * time.Second):
+		case err := <-stop:
+			return fmt.Errorf("the machine stopped: %w", err)
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-Shutdown:
+			return fmt.Errorf("shutdown in progress")
+		}
+		err := ssh("test -e /proc/vmcore")
+		if err == nil {
+			break
+		}
+		var verboseErr *osutil.VerboseError
+		if !forced && errors.As(err, &verboseErr) && verboseErr.ExitCode != sshConnectionError {
+			// We are connected to the crashed kernel, it does not have /proc/vmcore.
+			log.Logf(1, "forcing kernel panic to collect vmcore")
+			ssh("echo c > /proc/sysrq-trigger")
+			forced = true
+		}
+		if time.Since(start) > timeout {
+			return fmt.Errorf("the crash kernel did not boot: %w", err)
+		}
+	}
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if debug {
+		log.Logf(0, "running command: ssh %#v", append(sshArgs, "cat /proc/vmcore"))
+	}
+	stderr := new(bytes.Buffer)
+	cmd := osutil.CommandContext(ctx, "ssh", append(sshArgs, "cat /proc/vmcore")...)
+	cmd.Stdout = f
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("failed to copy /proc/vmcore: %w\n%s", err, stderr.Bytes())
+	}
+	return f.Close()
+}
@@ -10,8 +10,21 @@ import (
 	"github.com/google/syzkaller/pkg/report"
 )
 
+// HangTitle is the title of the report passed to Diagnose when a test program call is hanging,
+// but the kernel has not reported anything (yet).
+const HangTitle = "hanging test program"
+
 // DiagnoseLinux diagnoses some Linux kernel bugs over the provided ssh callback.
 func DiagnoseLinux(rep *report.Report, ssh func(args ...string) ([]byte, error)) (output []byte, wait, handled bool) {
+	if rep.Title == HangTitle {
+		// Dump stacks of all tasks to the console (sysrq-t).
+		// Note: this works regardless of the kernel.sysrq sysctl value.
+		output, err := ssh("echo t > /proc/sysrq-trigger")
+		if err != nil {
+			return append(output, err.Error()...), false, true
+		}
+		return nil, true, true
+	}
 	if !strings.Contains(rep.Title, "MAX_LOCKDEP") {
 		return nil, false, false
 	}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 type OutputMerger struct {
@@ -16,6 +18,10 @@ type OutputMerger struct {
 	teeMu  sync.Mutex
 	tee    io.Writer
 	wg     sync.WaitGroup
+	rec    atomic.Pointer[Recorder]
+	// sendMu serializes sends to Output, so that the order of recorded chunks
+	// matches the order in which they are received from Output.
+	sendMu sync.Mutex
 }
 
 type MergerError struct {
@@ -41,6 +47,18 @@ func (merger *OutputMerger) Wait() {
 	close(merger.Output)
 }
 
+// SetRecorder makes the merger record output of every source to a separate stream before merging.
+// VM implementations are supposed to call it in Run with RecorderFromContext(ctx).
+// The recorder replaces the one set by a previous Run, nil stops recording.
+func (merger *OutputMerger) SetRecorder(rec *Recorder) {
+	if rec != nil {
+		rec.mu.Lock()
+		rec.sources = true
+		rec.mu.Unlock()
+	}
+	merger.rec.Store(rec)
+}
+
 func (merger *OutputMerger) Add(name string, r io.ReadCloser) {
 	merger.AddDecoder(name, r, nil)
 }
@@ -60,7 +78,7 @@ func (merger *OutputMerger) AddDecoder(name string, r io.ReadCloser,
 					start, size, decoded := decoder(proto)
 					proto = proto[start+size:]
 					if len(decoded) != 0 {
-						merger.Output <- decoded // note: this can block
+						merger.send(name, decoded, true) // note: this can block
 					}
 				}
 				// Remove all carriage returns.
@@ -76,11 +94,9 @@ func (merger *OutputMerger) AddDecoder(name string, r io.ReadCloser,
 						merger.tee.Write(out)
 						merger.teeMu.Unlock()
 					}
-					select {
-					case merger.Output <- append([]byte{}, out...):
+					if merger.send(name, append([]byte{}, out...), false) {
 						r := copy(pending, pending[pos+1:])
 						pending = pending[:r]
-					default:
 					}
 				}
 			}
@@ -92,10 +108,7 @@ func (merger *OutputMerger) AddDecoder(name string, r io.ReadCloser,
 						merger.tee.Write(pending)
 						merger.teeMu.Unlock()
 					}
-					select {
-					case merger.Output <- pending:
-					default:
-					}
+					merger.send(name, pending, false)
 				}
 				r.Close()
 				select {
@@ -108,3 +121,25 @@ func (merger *OutputMerger) AddDecoder(name string, r io.ReadCloser,
 		}
 	}()
 }
+
+// send records data and sends it to Output. If Output is full, send either waits
+// or drops the data and returns false. Data is recorded only if it's actually sent,
+// and before the consumer can receive it, so that the consumer's own recorded events
+// can't precede it in the recording.
+func (merger *OutputMerger) send(name string, data []byte, wait bool) bool {
+	for {
+		merger.sendMu.Lock()
+		// All sends happen under sendMu, so if there is space, the send below does not block.
+		if len(merger.Output) < cap(merger.Output) {
+			merger.rec.Load().Record(SourceStream(name), data)
+			merger.Output <- data
+			merger.sendMu.Unlock()
+			return true
+		}
+		merger.sendMu.Unlock()
+		if !wait {
+			return false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -6,7 +6,9 @@ package vmimpl
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -82,3 +84,92 @@ func TestMerger(t *testing.T) {
 		t.Fatalf("bad tee: '%s', want '%s'", got, want)
 	}
 }
+
+func TestMergerRecorder(t *testing.T) {
+	recording := new(bytes.Buffer)
+	rec := NewRecorder(recording)
+	merger := NewOutputMerger(nil)
+	merger.SetRecorder(rec)
+	if !rec.RecordsSources() {
+		t.Fatalf("recorder does not record sources")
+	}
+	rp1, wp1 := io.Pipe()
+	merger.Add("console", rp1)
+	rp2, wp2 := io.Pipe()
+	merger.Add("ssh", rp2)
+
+	wp1.Write([]byte("111\n"))
+	<-merger.Output
+	wp2.Write([]byte("222\r\n"))
+	<-merger.Output
+	wp1.Write([]byte("333"))
+	wp1.Close()
+	wp2.Close()
+	<-merger.Output
+	merger.Wait()
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	chunks, err := ReadRecording(recording.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, chunk := range chunks {
+		if !IsSourceStream(chunk.Stream) {
+			t.Fatalf("unexpected stream %q", chunk.Stream)
+		}
+		got = append(got, chunk.Stream+" "+string(chunk.Data))
+	}
+	want := []string{
+		SourceStream("console") + " 111\n",
+		SourceStream("ssh") + " 222\n",
+		SourceStream("console") + " 333\n",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("bad recording: %q, want %q", got, want)
+	}
+}
+
+func TestMergerRecorderFull(t *testing.T) {
+	recording := new(bytes.Buffer)
+	rec := NewRecorder(recording)
+	merger := NewOutputMerger(nil)
+	merger.SetRecorder(rec)
+	rp, wp := io.Pipe()
+	merger.Add("console", rp)
+
+	for i := 0; i < cap(merger.Output); i++ {
+		fmt.Fprintf(wp, "%v\n", i)
+	}
+	// Output is full, so the line may be dropped for now. Dropped lines must not be recorded,
+	// they are sent and recorded later together with the next line.
+	wp.Write([]byte("full\n"))
+	var received []string
+	received = append(received, string(<-merger.Output), string(<-merger.Output))
+	wp.Write([]byte("last\n"))
+	wp.Close()
+	merger.Wait()
+	for out := range merger.Output {
+		received = append(received, string(out))
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(received, ""); !strings.HasSuffix(got, "\n999\nfull\nlast\n") {
+		t.Fatalf("bad output: %q", got)
+	}
+
+	chunks, err := ReadRecording(recording.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recorded []string
+	for _, chunk := range chunks {
+		recorded = append(recorded, string(chunk.Data))
+	}
+	if fmt.Sprint(recorded) != fmt.Sprint(received) {
+		t.Fatalf("recorded chunks don't match received chunks:\n%q\n%q", recorded, received)
+	}
+}
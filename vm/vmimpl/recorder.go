@@ -0,0 +1,215 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package vmimpl
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Recorder saves the console output of a VM session along with timestamps and stream names
+// in a compact binary format, so that the session can be replayed later (see ReadRecording).
+//
+// The format is a magic header followed by a sequence of records.
+// Each record is: uvarint time since start in microseconds, uvarint stream id, uvarint data size, data.
+// The first record for each stream id has size 0 and carries the stream name instead of data
+// (encoded as uvarint name length followed by the name).
+type Recorder struct {
+	mu      sync.Mutex
+	w       *bufio.Writer
+	c       io.Closer
+	start   time.Time
+	streams map[string]uint64
+	sources bool
+	closed  bool
+	err     error
+}
+
+// RecordedChunk is a single piece of recorded output.
+type RecordedChunk struct {
+	Time   time.Duration // since the start of the recording
+	Stream string
+	Data   []byte
+}
+
+const (
+	recordingMagic = "SYZREC1\n"
+	sourcePrefix   = "source:"
+)
+
+type recorderKey struct{}
+
+// WithRecorder returns a context that asks the VM implementation to record output of all
+// its output sources to rec (see OutputMerger.SetRecorder).
+func WithRecorder(ctx context.Context, rec *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, rec)
+}
+
+// RecorderFromContext returns the recorder set with WithRecorder, or nil.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	rec, _ := ctx.Value(recorderKey{}).(*Recorder)
+	return rec
+}
+
+// SourceStream returns the name of the stream the output of the named source is recorded to.
+func SourceStream(name string) string {
+	return sourcePrefix + name
+}
+
+// IsSourceStream says if the stream contains output of a source recorded by OutputMerger.
+func IsSourceStream(stream string) bool {
+	return strings.HasPrefix(stream, sourcePrefix)
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	rec := &Recorder{
+		w:       bufio.NewWriter(w),
+		start:   time.Now(),
+		streams: make(map[string]uint64),
+	}
+	if c, ok := w.(io.Closer); ok {
+		rec.c = c
+	}
+	_, rec.err = rec.w.WriteString(recordingMagic)
+	return rec
+}
+
+// Record appends data for the given stream to the recording.
+// Errors are sticky and are returned from Close.
+func (rec *Recorder) Record(stream string, data []byte) {
+	if rec == nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.err != nil || rec.closed {
+		return
+	}
+	ts := uint64(time.Since(rec.start) / time.Microsecond)
+	id, ok := rec.streams[stream]
+	if !ok {
+		id = uint64(len(rec.streams)) + 1
+		rec.streams[stream] = id
+		rec.writeUvarint(ts, id, 0, uint64(len(stream)))
+		rec.write([]byte(stream))
+	}
+	rec.writeUvarint(ts, id, uint64(len(data)))
+	rec.write(data)
+}
+
+// RecordsSources says if the VM implementation records output of its sources separately,
+// i.e. the merged output does not need to be recorded.
+func (rec *Recorder) RecordsSources() bool {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return rec.sources
+}
+
+func (rec *Recorder) writeUvarint(vals ...uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	for _, v := range vals {
+		rec.write(buf[:binary.PutUvarint(buf[:], v)])
+	}
+}
+
+func (rec *Recorder) write(data []byte) {
+	if rec.err == nil {
+		_, rec.err = rec.w.Write(data)
+	}
+}
+
+// Flush writes out any buffered data.
+func (rec *Recorder) Flush() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if rec.err == nil {
+		rec.err = rec.w.Flush()
+	}
+	return rec.err
+}
+
+// Close flushes the recording and closes the underlying writer if it is an io.Closer.
+// Everything recorded after Close is dropped.
+func (rec *Recorder) Close() error {
+	err := rec.Flush()
+	rec.mu.Lock()
+	rec.closed = true
+	rec.mu.Unlock()
+	if rec.c != nil {
+		if cerr := rec.c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// ReadRecording parses a recording produced by Recorder.
+// A truncated trailing record (e.g. if the process was killed) is silently dropped.
+func ReadRecording(data []byte) ([]RecordedChunk, error) {
+	if !bytes.HasPrefix(data, []byte(recordingMagic)) {
+		return nil, fmt.Errorf("not a console recording")
+	}
+	r := bytes.NewReader(data[len(recordingMagic):])
+	streams := make(map[uint64]string)
+	var res []RecordedChunk
+	for r.Len() != 0 {
+		chunk, err := readChunk(r, streams)
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if chunk != nil {
+			res = append(res, *chunk)
+		}
+	}
+	return res, nil
+}
+
+func readChunk(r *bytes.Reader, streams map[uint64]string) (*RecordedChunk, error) {
+	var vals [3]uint64
+	for i := range vals {
+		v, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+	ts, id, size := vals[0], vals[1], vals[2]
+	if size > uint64(r.Len()) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	if _, ok := streams[id]; !ok {
+		if size != 0 {
+			return nil, fmt.Errorf("data for undeclared stream %v", id)
+		}
+		nameLen, err := binary.ReadUvarint(r)
+		if err != nil || nameLen > uint64(r.Len()) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		name := make([]byte, nameLen)
+		r.Read(name)
+		streams[id] = string(name)
+		return nil, nil
+	}
+	data := make([]byte, size)
+	r.Read(data)
+	return &RecordedChunk{
+		Time:   time.Duration(ts) * time.Microsecond,
+		Stream: streams[id],
+		Data:   data,
+	}, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2026 syzkaller project authors. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+
+package vmimpl
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder(t *testing.T) {
+	buf := new(bytes.Buffer)
+	rec := NewRecorder(buf)
+	rec.Record("console", []byte("line 1\n"))
+	rec.Record("ssh", []byte("line 2\n"))
+	rec.Record("console", nil)
+	rec.Record("console", []byte("line 3\n"))
+	assert.NoError(t, rec.Close())
+
+	chunks, err := ReadRecording(buf.Bytes())
+	assert.NoError(t, err)
+	var streams, data []string
+	for i, chunk := range chunks {
+		streams = append(streams, chunk.Stream)
+		data = append(data, string(chunk.Data))
+		if i != 0 && chunk.Time < chunks[i-1].Time {
+			t.Errorf("chunk %v time goes backwards", i)
+		}
+	}
+	assert.Equal(t, []string{"console", "ssh", "console", "console"}, streams)
+	assert.Equal(t, []string{"line 1\n", "line 2\n", "", "line 3\n"}, data)
+
+	// Truncated recordings must be still readable.
+	chunks, err = ReadRecording(buf.Bytes()[:buf.Len()-3])
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 3)
+
+	_, err = ReadRecording([]byte("garbage"))
+	assert.Error(t, err)
+}
@@ -70,6 +70,14 @@ type Infoer interface {
 	Info() ([]byte, error)
 }
 
+// VmcoreCollector is an optional interface that can be implemented by Instance.
+type VmcoreCollector interface {
+	// CollectVmcore waits for the crash (kdump) kernel to boot after a kernel panic
+	// and copies the kernel crash dump (/proc/vmcore) to the dst file on the host.
+	// Returns false if kdump is not enabled for the instance.
+	CollectVmcore(ctx context.Context, dst string) (bool, error)
+}
+
 // Env contains global constant parameters for a pool of VMs.
 type Env struct {
 	// Unique name
@@ -146,6 +154,9 @@ type Type struct {
 	// For preempted instances executor prints "SYZ-EXECUTOR: PREEMPTED" and then
 	// the host understands that the lost connection was expected and is not a bug.
 	Preemptible bool
+	// Diagnose can be called on a live instance that has not crashed (see HangTitle)
+	// without disrupting the instance.
+	LiveDiagnose bool
 }
 
 type ctorFunc func(env *Env) (Pool, error)
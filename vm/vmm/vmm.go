@@ -257,6 +257,7 @@ func (inst *instance) Run(ctx context.Context, command string) (
 	if err != nil {
 		return nil, nil, err
 	}
+	inst.merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	inst.merger.Add("ssh", rpipe)
 
 	args := append(vmimpl.SSHArgs(inst.debug, inst.Key, inst.Port, false),
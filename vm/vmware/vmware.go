@@ -215,6 +215,7 @@ func (inst *instance) Run(ctx context.Context, command string) (
 		tee = os.Stdout
 	}
 	merger := vmimpl.NewOutputMerger(tee)
+	merger.SetRecorder(vmimpl.RecorderFromContext(ctx))
 	merger.Add("dmesg", dmesg)
 	merger.Add("ssh", rpipe)
 